@@ -0,0 +1,22 @@
+-- gathering_app.jobs definition
+
+CREATE TABLE `jobs` (
+  `id` bigint NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `payload` json NULL,
+  `status` varchar(20) NOT NULL,
+  `attempts` int NOT NULL DEFAULT 0,
+  `max_attempts` int NOT NULL,
+  `run_at` DATETIME NOT NULL,
+  `lease_until` DATETIME NULL,
+  `unique_key` varchar(255) NULL,
+  `last_error` text NULL,
+  `started_at` DATETIME NULL,
+  `finished_at` DATETIME NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT jobs_unique_key_UN UNIQUE KEY (`unique_key`),
+  INDEX jobs_status_run_at_IDX (`status`, `run_at`),
+  INDEX jobs_name_created_at_IDX (`name`, `created_at`)
+);
\ No newline at end of file
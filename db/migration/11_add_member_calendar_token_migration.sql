@@ -0,0 +1,5 @@
+-- gathering_app.members calendar feed token
+
+ALTER TABLE gathering_app.members
+  ADD calendar_token varchar(64) NULL AFTER email,
+  ADD CONSTRAINT members_calendar_token_UN UNIQUE KEY (calendar_token);
\ No newline at end of file
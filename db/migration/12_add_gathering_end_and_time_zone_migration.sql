@@ -0,0 +1,5 @@
+-- gathering_app.gatherings end time and time zone, start and end are stored in UTC
+
+ALTER TABLE gatherings
+  ADD ends_at datetime DEFAULT NULL AFTER scheduled_at,
+  ADD time_zone varchar(64) NOT NULL DEFAULT 'UTC' AFTER ends_at;
\ No newline at end of file
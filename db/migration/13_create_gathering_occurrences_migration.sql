@@ -0,0 +1,24 @@
+-- recurring gatherings, the overrides reschedule or cancel single occurrences
+-- and invitations can target a single occurrence
+
+ALTER TABLE gatherings
+  ADD recurrence varchar(255) NOT NULL DEFAULT '' AFTER time_zone;
+
+CREATE TABLE `gathering_occurrence_overrides` (
+  `id` bigint NOT NULL,
+  `gathering_id` bigint NOT NULL,
+  `occurrence_at` DATETIME NOT NULL,
+  `scheduled_at` DATETIME NULL,
+  `ends_at` DATETIME NULL,
+  `cancelled` tinyint(1) NOT NULL DEFAULT 0,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT gathering_occurrence_overrides_gathering_occurrence_UN UNIQUE KEY (`gathering_id`, `occurrence_at`)
+);
+
+ALTER TABLE invitations
+  ADD occurrence_at DATETIME NULL AFTER status;
+
+ALTER TABLE attendees
+  ADD occurrence_at DATETIME NULL AFTER gathering_id;
\ No newline at end of file
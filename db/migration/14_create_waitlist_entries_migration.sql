@@ -0,0 +1,15 @@
+-- capacity of the gatherings with a fixed number of attendees, the members invited once it is reached wait
+-- for a seat on the waitlist
+
+ALTER TABLE gatherings
+  ADD capacity int NOT NULL DEFAULT 0 AFTER recurrence;
+
+CREATE TABLE `waitlist_entries` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `gathering_id` bigint NOT NULL,
+  `member_id` bigint NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT waitlist_entries_gathering_member_UN UNIQUE KEY (`gathering_id`, `member_id`),
+  INDEX waitlist_entries_member_id_IDX (`member_id`)
+);
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- signed links inviting any member holding them to a gathering, the token itself is never stored
+
+CREATE TABLE `invite_links` (
+  `id` bigint NOT NULL,
+  `gathering_id` bigint NOT NULL,
+  `max_uses` int NOT NULL DEFAULT 0,
+  `uses` int NOT NULL DEFAULT 0,
+  `expires_at` DATETIME NOT NULL,
+  `revoked_at` DATETIME NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX invite_links_gathering_id_IDX (`gathering_id`)
+);
\ No newline at end of file
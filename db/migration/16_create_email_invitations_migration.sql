@@ -0,0 +1,17 @@
+-- invitations addressed to an email that no member has registered with yet, they are turned into invitations
+-- when a member registers with the email
+
+CREATE TABLE `email_invitations` (
+  `id` bigint NOT NULL,
+  `gathering_id` bigint NOT NULL,
+  `email` varchar(255) NOT NULL,
+  `status` int NOT NULL,
+  `occurrence_at` DATETIME NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT email_invitations_gathering_email_UN UNIQUE KEY (`gathering_id`, `email`),
+  INDEX email_invitations_email_IDX (`email`)
+);
+
+CREATE INDEX members_email_IDX ON members (`email`);
\ No newline at end of file
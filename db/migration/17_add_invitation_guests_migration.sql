@@ -0,0 +1,10 @@
+-- guests the organizer lets an invited member bring, and the guests the member brings, every guest takes a seat
+-- of the gathering like its member
+
+ALTER TABLE invitations
+  ADD allowed_guests int NOT NULL DEFAULT 0 AFTER status,
+  ADD guests int NOT NULL DEFAULT 0 AFTER allowed_guests,
+  ADD guest_names JSON NULL AFTER guests;
+
+ALTER TABLE attendees
+  ADD guests int NOT NULL DEFAULT 0 AFTER occurrence_at;
\ No newline at end of file
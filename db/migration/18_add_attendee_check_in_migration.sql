@@ -0,0 +1,6 @@
+-- check-in of the attendees at their gathering, the code is encoded in the QR code the member shows
+
+ALTER TABLE attendees
+  ADD check_in_code varchar(64) NULL AFTER guests,
+  ADD checked_in_at DATETIME NULL AFTER check_in_code,
+  ADD CONSTRAINT attendees_check_in_code_UN UNIQUE KEY (`check_in_code`);
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- when the member was last invited and when they accepted the pending invitation, and the indexes the gathering
+-- analytics aggregate over
+
+ALTER TABLE invitations
+  ADD invited_at DATETIME NULL AFTER occurrence_at,
+  ADD accepted_at DATETIME NULL AFTER invited_at;
+
+UPDATE invitations SET invited_at = created_at;
+
+ALTER TABLE invitations
+  MODIFY invited_at DATETIME NOT NULL,
+  ADD INDEX invitations_gathering_id_invited_at_IDX (`gathering_id`, `invited_at`),
+  ADD INDEX invitations_gathering_id_accepted_at_IDX (`gathering_id`, `accepted_at`);
+
+ALTER TABLE gatherings
+  ADD INDEX gatherings_creator_IDX (`creator`);
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- named sets of members their owner invites to gatherings together
+
+CREATE TABLE `member_groups` (
+  `id` bigint NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `owner` bigint NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  `deleted_at` DATETIME NULL,
+  PRIMARY KEY (`id`),
+  INDEX member_groups_owner_IDX (`owner`)
+);
+
+CREATE TABLE `member_group_members` (
+  `group_id` bigint NOT NULL,
+  `member_id` bigint NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`group_id`, `member_id`),
+  INDEX member_group_members_member_id_IDX (`member_id`)
+);
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- roles of the members managing a gathering, its creator owns it
+
+CREATE TABLE `gathering_roles` (
+  `gathering_id` bigint NOT NULL,
+  `member_id` bigint NOT NULL,
+  `role` varchar(20) NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`gathering_id`, `member_id`),
+  INDEX gathering_roles_member_id_IDX (`member_id`)
+);
+
+INSERT INTO gathering_roles (gathering_id, member_id, role, created_at, updated_at)
+  SELECT id, creator, 'owner', created_at, created_at FROM gatherings;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- in-flight requests hold their key until locked_until, a key left without response past it can be claimed again
+
+ALTER TABLE idempotency_keys
+  ADD locked_until DATETIME NOT NULL AFTER response_body;
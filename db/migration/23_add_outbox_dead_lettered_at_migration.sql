@@ -0,0 +1,5 @@
+-- messages that ran out of attempts are dead-lettered, they are no longer claimed until they are replayed
+
+ALTER TABLE outbox
+  ADD dead_lettered_at DATETIME NULL AFTER delivered_at,
+  ADD INDEX outbox_dead_lettered_at_IDX (`dead_lettered_at`);
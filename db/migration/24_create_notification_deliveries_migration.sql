@@ -0,0 +1,8 @@
+-- members an event was already emailed to, so a retried event does not email them again
+
+CREATE TABLE `notification_deliveries` (
+  `event_id` bigint NOT NULL,
+  `member_id` bigint NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`event_id`, `member_id`)
+);
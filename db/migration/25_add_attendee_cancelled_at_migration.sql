@@ -0,0 +1,7 @@
+-- marks the attendees deleted because their gathering was cancelled, the calendar feeds keep showing those gatherings
+
+ALTER TABLE `attendees` ADD COLUMN `cancelled_at` DATETIME NULL AFTER `checked_in_at`;
+
+UPDATE attendees JOIN gatherings ON gatherings.id = attendees.gathering_id
+  SET attendees.cancelled_at = attendees.deleted_at
+  WHERE gatherings.deleted_at IS NOT NULL AND attendees.deleted_at >= gatherings.deleted_at;
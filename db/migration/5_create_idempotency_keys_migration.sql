@@ -0,0 +1,13 @@
+-- gathering_app.idempotency_keys definition
+
+CREATE TABLE `idempotency_keys` (
+  `key` varchar(255) NOT NULL,
+  `request_hash` char(64) NOT NULL,
+  `response_code` int NOT NULL DEFAULT 0,
+  `response_body` mediumblob NULL,
+  `expires_at` DATETIME NOT NULL,
+  `created_at` DATETIME NOT NULL,
+	`updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`key`),
+  INDEX idempotency_keys_expires_at_IDX (`expires_at`)
+);
\ No newline at end of file
@@ -0,0 +1,36 @@
+-- a member has at most one invitation and one attendee row per gathering,
+-- re-inviting revives the existing (soft-deleted or expired) row instead of inserting a new one
+
+-- keep the live row, or the oldest one, of each duplicated invitation
+DELETE duplicate FROM invitations duplicate
+JOIN invitations kept
+  ON kept.member_id = duplicate.member_id
+  AND kept.gathering_id = duplicate.gathering_id
+  AND kept.id <> duplicate.id
+  AND (
+    (kept.deleted_at IS NULL AND duplicate.deleted_at IS NOT NULL)
+    OR ((kept.deleted_at IS NULL) = (duplicate.deleted_at IS NULL) AND kept.id < duplicate.id)
+  );
+
+ALTER TABLE invitations
+  ADD CONSTRAINT invitations_member_gathering_UN UNIQUE KEY (member_id, gathering_id);
+
+-- attendees had no key at all, collapse duplicates before adding one
+CREATE TEMPORARY TABLE attendees_dedup AS
+SELECT member_id,
+  gathering_id,
+  MIN(created_at) AS created_at,
+  MAX(updated_at) AS updated_at,
+  IF(SUM(deleted_at IS NULL) > 0, NULL, MAX(deleted_at)) AS deleted_at
+FROM attendees
+GROUP BY member_id, gathering_id;
+
+DELETE FROM attendees;
+
+INSERT INTO attendees (member_id, gathering_id, created_at, updated_at, deleted_at)
+SELECT member_id, gathering_id, created_at, updated_at, deleted_at FROM attendees_dedup;
+
+DROP TEMPORARY TABLE attendees_dedup;
+
+ALTER TABLE attendees
+  ADD PRIMARY KEY (member_id, gathering_id);
\ No newline at end of file
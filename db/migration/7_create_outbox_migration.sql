@@ -0,0 +1,16 @@
+-- gathering_app.outbox definition
+
+CREATE TABLE `outbox` (
+  `id` bigint NOT NULL,
+  `event_type` varchar(100) NOT NULL,
+  `payload` json NOT NULL,
+  `occurred_at` DATETIME NOT NULL,
+  `attempts` int NOT NULL DEFAULT 0,
+  `last_error` text NULL,
+  `next_attempt_at` DATETIME NOT NULL,
+  `delivered_at` DATETIME NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX outbox_delivered_at_next_attempt_at_IDX (`delivered_at`, `next_attempt_at`)
+);
\ No newline at end of file
@@ -0,0 +1,35 @@
+-- gathering_app.webhook_subscriptions definition
+
+CREATE TABLE `webhook_subscriptions` (
+  `id` bigint NOT NULL,
+  `owner` bigint NOT NULL,
+  `url` varchar(2048) NOT NULL,
+  `event_types` json NOT NULL,
+  `secret` varchar(255) NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  `deleted_at` DATETIME NULL,
+  PRIMARY KEY (`id`),
+  INDEX webhook_subscriptions_owner_IDX (`owner`)
+);
+
+-- gathering_app.webhook_deliveries definition
+
+CREATE TABLE `webhook_deliveries` (
+  `id` bigint NOT NULL,
+  `subscription_id` bigint NOT NULL,
+  `event_id` bigint NOT NULL,
+  `event_type` varchar(100) NOT NULL,
+  `payload` json NOT NULL,
+  `status` varchar(20) NOT NULL,
+  `attempts` int NOT NULL DEFAULT 0,
+  `response_code` int NOT NULL DEFAULT 0,
+  `last_error` text NULL,
+  `next_attempt_at` DATETIME NOT NULL,
+  `delivered_at` DATETIME NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT webhook_deliveries_subscription_event_UN UNIQUE KEY (`subscription_id`, `event_id`),
+  INDEX webhook_deliveries_status_next_attempt_at_IDX (`status`, `next_attempt_at`)
+);
\ No newline at end of file
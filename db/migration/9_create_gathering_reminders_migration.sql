@@ -0,0 +1,14 @@
+-- gathering_app.gathering_reminders definition
+
+CREATE TABLE `gathering_reminders` (
+  `id` bigint NOT NULL,
+  `gathering_id` bigint NOT NULL,
+  `offset_minutes` int NOT NULL,
+  `remind_at` DATETIME NULL,
+  `sent_at` DATETIME NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT gathering_reminders_gathering_offset_UN UNIQUE KEY (`gathering_id`, `offset_minutes`),
+  INDEX gathering_reminders_sent_at_remind_at_IDX (`sent_at`, `remind_at`)
+);
\ No newline at end of file
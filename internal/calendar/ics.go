@@ -0,0 +1,114 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+const (
+	// ContentType of the encoded calendars
+	ContentType = "text/calendar; charset=utf-8"
+
+	productID = "-//Gathering App//Gathering App//EN"
+	uidDomain = "gathering.app"
+
+	// RFC 5545 lines are folded once they are longer than 75 octets, CRLF excluded
+	maxLineLength = 75
+	dateTimeUTC   = "20060102T150405Z"
+)
+
+// Encode encodes the gathering occurrences as an RFC 5545 calendar named name, one VEVENT per occurrence.
+// The occurrences of a deleted gathering and the cancelled occurrences are cancelled events
+func Encode(name string, occurrences []*model.Occurrence) []byte {
+	var buf bytes.Buffer
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+productID)
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	if name != "" {
+		writeLine(&buf, "X-WR-CALNAME:"+escapeText(name))
+	}
+
+	for _, occurrence := range occurrences {
+		writeEvent(&buf, occurrence)
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+func writeEvent(buf *bytes.Buffer, occurrence *model.Occurrence) {
+	gathering := occurrence.Gathering
+
+	status := "CONFIRMED"
+	if occurrence.Cancelled {
+		status = "CANCELLED"
+	}
+
+	// every occurrence of a recurring gathering is its own event
+	uid := fmt.Sprintf("gathering-%d@%s", gathering.ID, uidDomain)
+	if gathering.IsRecurring() {
+		uid = fmt.Sprintf("gathering-%d-%s@%s", gathering.ID, formatDateTime(occurrence.OccurrenceAt), uidDomain)
+	}
+
+	// the gathering last change stamps the event so an unchanged gathering is encoded the same on every export
+	stamp := gathering.UpdatedAt
+	if gathering.DeletedAt.Valid && gathering.DeletedAt.Time.After(stamp) {
+		stamp = gathering.DeletedAt.Time
+	}
+
+	writeLine(buf, "BEGIN:VEVENT")
+	writeLine(buf, "UID:"+uid)
+	writeLine(buf, "DTSTAMP:"+formatDateTime(stamp))
+	writeLine(buf, "LAST-MODIFIED:"+formatDateTime(stamp))
+	writeLine(buf, "DTSTART:"+formatDateTime(occurrence.ScheduledAt))
+	if occurrence.EndsAt != nil {
+		writeLine(buf, "DTEND:"+formatDateTime(*occurrence.EndsAt))
+	}
+	writeLine(buf, "SUMMARY:"+escapeText(gathering.Name))
+	if gathering.Location != "" {
+		writeLine(buf, "LOCATION:"+escapeText(gathering.Location))
+	}
+	writeLine(buf, "STATUS:"+status)
+	writeLine(buf, "END:VEVENT")
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeUTC)
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeLine writes a content line, folded so no line is longer than 75 octets without splitting a UTF-8 character
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// the space starting a continuation line counts toward its length
+		limit = maxLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,128 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestEncode(t *testing.T) {
+	scheduledAt := time.Date(2021, 11, 22, 19, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+	updatedAt := time.Date(2021, 11, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("scheduled and cancelled gatherings", func(t *testing.T) {
+		gatherings := []*model.Gathering{
+			{
+				ID:          1,
+				Name:        "Board games; snacks, drinks",
+				Location:    "Room 1\nSecond floor",
+				ScheduledAt: &scheduledAt,
+				UpdatedAt:   updatedAt,
+			},
+			{
+				ID:          2,
+				Name:        "Hike",
+				ScheduledAt: &scheduledAt,
+				UpdatedAt:   updatedAt,
+				DeletedAt:   gorm.DeletedAt{Time: updatedAt.Add(time.Hour), Valid: true},
+			},
+		}
+		occurrences := []*model.Occurrence{
+			gatherings[0].Occurrence(scheduledAt, nil),
+			gatherings[1].Occurrence(scheduledAt, nil),
+		}
+
+		expected := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//Gathering App//Gathering App//EN",
+			"CALSCALE:GREGORIAN",
+			"METHOD:PUBLISH",
+			"X-WR-CALNAME:John's gatherings",
+			"BEGIN:VEVENT",
+			"UID:gathering-1@gathering.app",
+			"DTSTAMP:20211101T080000Z",
+			"LAST-MODIFIED:20211101T080000Z",
+			"DTSTART:20211122T123000Z",
+			`SUMMARY:Board games\; snacks\, drinks`,
+			`LOCATION:Room 1\nSecond floor`,
+			"STATUS:CONFIRMED",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:gathering-2@gathering.app",
+			"DTSTAMP:20211101T090000Z",
+			"LAST-MODIFIED:20211101T090000Z",
+			"DTSTART:20211122T123000Z",
+			"SUMMARY:Hike",
+			"STATUS:CANCELLED",
+			"END:VEVENT",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n")
+
+		assert.Equal(t, expected, string(Encode("John's gatherings", occurrences)))
+	})
+
+	t.Run("end time", func(t *testing.T) {
+		endsAt := scheduledAt.Add(2 * time.Hour)
+		gathering := &model.Gathering{
+			ID:          1,
+			Name:        "Dinner",
+			ScheduledAt: &scheduledAt,
+			EndsAt:      &endsAt,
+			TimeZone:    "Asia/Jakarta",
+		}
+
+		ics := string(Encode("", []*model.Occurrence{gathering.Occurrence(scheduledAt, nil)}))
+		assert.Contains(t, ics, "\r\nDTSTART:20211122T123000Z\r\nDTEND:20211122T143000Z\r\n")
+	})
+
+	t.Run("recurring gathering occurrences", func(t *testing.T) {
+		gathering := &model.Gathering{
+			ID:          1,
+			Name:        "Weekly run",
+			ScheduledAt: &scheduledAt,
+			TimeZone:    "Asia/Jakarta",
+			Recurrence:  "FREQ=WEEKLY;COUNT=2",
+		}
+		secondAt := scheduledAt.AddDate(0, 0, 7)
+		overrides := []*model.GatheringOccurrenceOverride{{GatheringID: 1, OccurrenceAt: secondAt, Cancelled: true}}
+
+		occurrences, err := gathering.Occurrences(scheduledAt, secondAt, 10, overrides)
+		assert.NoError(t, err)
+
+		ics := string(Encode("", occurrences))
+		assert.Contains(t, ics, "\r\nUID:gathering-1-20211122T123000Z@gathering.app\r\n")
+		assert.Contains(t, ics, "\r\nDTSTART:20211129T123000Z\r\nSUMMARY:Weekly run\r\nSTATUS:CANCELLED\r\n")
+		assert.Equal(t, 1, strings.Count(ics, "STATUS:CONFIRMED"))
+	})
+
+	t.Run("long lines are folded", func(t *testing.T) {
+		gathering := &model.Gathering{
+			ID:          1,
+			Name:        strings.Repeat("é", 100),
+			ScheduledAt: &scheduledAt,
+		}
+
+		ics := string(Encode("", []*model.Occurrence{gathering.Occurrence(scheduledAt, nil)}))
+
+		var summary string
+		for i, line := range strings.Split(ics, "\r\n") {
+			assert.LessOrEqual(t, len(line), 75, "line %d", i)
+			switch {
+			case strings.HasPrefix(line, "SUMMARY:"):
+				summary = strings.TrimPrefix(line, "SUMMARY:")
+			case strings.HasPrefix(line, " ") && summary != "":
+				summary += strings.TrimPrefix(line, " ")
+			case summary != "" && !strings.HasPrefix(line, " "):
+				assert.Equal(t, gathering.Name, summary)
+				return
+			}
+		}
+		t.Fatal("no SUMMARY line")
+	})
+}
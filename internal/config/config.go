@@ -33,6 +33,14 @@ func HTTPPort() string {
 	return viper.GetString("http_port")
 }
 
+// PublicURL the URL clients reach the service on, the calendar feed and invite link URLs are built on it
+func PublicURL() string {
+	if viper.GetString("public_url") == "" {
+		return DefaultPublicURL
+	}
+	return viper.GetString("public_url")
+}
+
 // Env :nodoc:
 func Env() string {
 	return viper.GetString("env")
@@ -111,3 +119,182 @@ func DatabaseDSN() string {
 func LogLevel() string {
 	return viper.GetString("log_level")
 }
+
+// IdempotencyTTL how long a stored response can be replayed for the same Idempotency-Key
+func IdempotencyTTL() time.Duration {
+	if viper.GetInt("idempotency.ttl") <= 0 {
+		return DefaultIdempotencyTTL
+	}
+	return time.Duration(viper.GetInt("idempotency.ttl")) * time.Millisecond
+}
+
+// IdempotencyLockLease how long a request in progress holds its Idempotency-Key before a retry may take it over
+func IdempotencyLockLease() time.Duration {
+	if viper.GetInt("idempotency.lock_lease") <= 0 {
+		return DefaultIdempotencyLockLease
+	}
+	return time.Duration(viper.GetInt("idempotency.lock_lease")) * time.Millisecond
+}
+
+// AdminToken bearer token required by the admin endpoints, read from the SVC_ADMIN_TOKEN env, the admin routes are not served when empty
+func AdminToken() string {
+	return viper.GetString("admin.token")
+}
+
+// InviteLinkSecret key signing the invite link tokens, invite links are disabled when it is empty
+func InviteLinkSecret() string {
+	return viper.GetString("invite_link.secret")
+}
+
+// InviteLinkTTL how long an invite link created without an expiry can be redeemed
+func InviteLinkTTL() time.Duration {
+	if viper.GetInt("invite_link.ttl") <= 0 {
+		return DefaultInviteLinkTTL
+	}
+	return time.Duration(viper.GetInt("invite_link.ttl")) * time.Millisecond
+}
+
+// DeletionPolicy how deleting a member or gathering treats its invitations and attendees, either cascade or restrict
+func DeletionPolicy() string {
+	switch policy := viper.GetString("deletion.policy"); policy {
+	case "cascade", "restrict":
+		return policy
+	default:
+		return DefaultDeletionPolicy
+	}
+}
+
+// OutboxPollInterval how long the outbox relay waits before polling again once the outbox is drained
+func OutboxPollInterval() time.Duration {
+	if viper.GetInt("outbox.poll_interval") <= 0 {
+		return DefaultOutboxPollInterval
+	}
+	return time.Duration(viper.GetInt("outbox.poll_interval")) * time.Millisecond
+}
+
+// OutboxBatchSize how many messages the outbox relay claims per poll
+func OutboxBatchSize() int {
+	if viper.GetInt("outbox.batch_size") <= 0 {
+		return DefaultOutboxBatchSize
+	}
+	return viper.GetInt("outbox.batch_size")
+}
+
+// OutboxMaxAttempts how many times the outbox relay tries to deliver a message before giving up on it
+func OutboxMaxAttempts() int {
+	if viper.GetInt("outbox.max_attempts") <= 0 {
+		return DefaultOutboxMaxAttempts
+	}
+	return viper.GetInt("outbox.max_attempts")
+}
+
+// WebhookTimeout how long a webhook receiver has to answer a delivery
+func WebhookTimeout() time.Duration {
+	if viper.GetInt("webhook.timeout") <= 0 {
+		return DefaultWebhookTimeout
+	}
+	return time.Duration(viper.GetInt("webhook.timeout")) * time.Millisecond
+}
+
+// WebhookMaxAttempts how many times a webhook delivery is sent before it is marked as failed
+func WebhookMaxAttempts() int {
+	if viper.GetInt("webhook.max_attempts") <= 0 {
+		return DefaultWebhookMaxAttempts
+	}
+	return viper.GetInt("webhook.max_attempts")
+}
+
+// ReminderOffsetMinutes how many minutes before a new gathering starts its reminders are sent
+func ReminderOffsetMinutes() []int {
+	if !viper.IsSet("reminder.offset_minutes") {
+		return DefaultReminderOffsetMinutes
+	}
+	return viper.GetIntSlice("reminder.offset_minutes")
+}
+
+// ReminderPollInterval how long the scheduler waits before looking for due reminders again once there are none
+func ReminderPollInterval() time.Duration {
+	if viper.GetInt("reminder.poll_interval") <= 0 {
+		return DefaultReminderPollInterval
+	}
+	return time.Duration(viper.GetInt("reminder.poll_interval")) * time.Millisecond
+}
+
+// JobPollInterval how long the worker waits before polling the job queue again once no job is due
+func JobPollInterval() time.Duration {
+	if viper.GetInt("job.poll_interval") <= 0 {
+		return DefaultJobPollInterval
+	}
+	return time.Duration(viper.GetInt("job.poll_interval")) * time.Millisecond
+}
+
+// JobLease how long a job may run before another worker can take it over
+func JobLease() time.Duration {
+	if viper.GetInt("job.lease") <= 0 {
+		return DefaultJobLease
+	}
+	return time.Duration(viper.GetInt("job.lease")) * time.Millisecond
+}
+
+// JobMaxAttempts how many times a job is run before it is marked dead
+func JobMaxAttempts() int {
+	if viper.GetInt("job.max_attempts") <= 0 {
+		return DefaultJobMaxAttempts
+	}
+	return viper.GetInt("job.max_attempts")
+}
+
+// JobBatchSize how many jobs the worker claims per poll
+func JobBatchSize() int {
+	if viper.GetInt("job.batch_size") <= 0 {
+		return DefaultJobBatchSize
+	}
+	return viper.GetInt("job.batch_size")
+}
+
+// JobRetention how long the finished job runs are kept
+func JobRetention() time.Duration {
+	if viper.GetInt("job.retention") <= 0 {
+		return DefaultJobRetention
+	}
+	return time.Duration(viper.GetInt("job.retention")) * time.Millisecond
+}
+
+// SMTPHost SMTP server the emails are sent through, the emails are only logged when it is empty
+func SMTPHost() string {
+	return viper.GetString("smtp.host")
+}
+
+// SMTPPort :nodoc:
+func SMTPPort() int {
+	if viper.GetInt("smtp.port") <= 0 {
+		return DefaultSMTPPort
+	}
+	return viper.GetInt("smtp.port")
+}
+
+// SMTPUsername :nodoc:
+func SMTPUsername() string {
+	return viper.GetString("smtp.username")
+}
+
+// SMTPPassword :nodoc:
+func SMTPPassword() string {
+	return viper.GetString("smtp.password")
+}
+
+// SMTPFrom sender address of the emails
+func SMTPFrom() string {
+	if viper.GetString("smtp.from") == "" {
+		return DefaultSMTPFrom
+	}
+	return viper.GetString("smtp.from")
+}
+
+// SMTPTimeout how long sending one email may take
+func SMTPTimeout() time.Duration {
+	if viper.GetInt("smtp.timeout") <= 0 {
+		return DefaultSMTPTimeout
+	}
+	return time.Duration(viper.GetInt("smtp.timeout")) * time.Millisecond
+}
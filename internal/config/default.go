@@ -15,4 +15,45 @@ const (
 	DefaultMySQLConnMaxLifetime = 1 * time.Hour
 	// DefaultMySQLPingInterval :nodoc:
 	DefaultMySQLPingInterval = 1 * time.Second
+	// DefaultIdempotencyTTL :nodoc:
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// DefaultIdempotencyLockLease :nodoc:
+	DefaultIdempotencyLockLease = 1 * time.Minute
+	// DefaultPublicURL :nodoc:
+	DefaultPublicURL = "http://localhost:1212"
+	// DefaultInviteLinkTTL :nodoc:
+	DefaultInviteLinkTTL = 7 * 24 * time.Hour
+	// DefaultDeletionPolicy :nodoc:
+	DefaultDeletionPolicy = "cascade"
+	// DefaultOutboxPollInterval :nodoc:
+	DefaultOutboxPollInterval = 1 * time.Second
+	// DefaultOutboxBatchSize :nodoc:
+	DefaultOutboxBatchSize = 100
+	// DefaultOutboxMaxAttempts :nodoc:
+	DefaultOutboxMaxAttempts = 10
+	// DefaultWebhookTimeout :nodoc:
+	DefaultWebhookTimeout = 5 * time.Second
+	// DefaultWebhookMaxAttempts :nodoc:
+	DefaultWebhookMaxAttempts = 8
+	// DefaultReminderPollInterval :nodoc:
+	DefaultReminderPollInterval = 30 * time.Second
+	// DefaultJobPollInterval :nodoc:
+	DefaultJobPollInterval = 1 * time.Second
+	// DefaultJobLease :nodoc:
+	DefaultJobLease = 5 * time.Minute
+	// DefaultJobMaxAttempts :nodoc:
+	DefaultJobMaxAttempts = 5
+	// DefaultJobBatchSize :nodoc:
+	DefaultJobBatchSize = 10
+	// DefaultJobRetention :nodoc:
+	DefaultJobRetention = 7 * 24 * time.Hour
+	// DefaultSMTPPort :nodoc:
+	DefaultSMTPPort = 587
+	// DefaultSMTPFrom :nodoc:
+	DefaultSMTPFrom = "Gathering App <no-reply@gathering.app>"
+	// DefaultSMTPTimeout :nodoc:
+	DefaultSMTPTimeout = 10 * time.Second
 )
+
+// DefaultReminderOffsetMinutes reminders of a new gathering, a day and an hour before it starts
+var DefaultReminderOffsetMinutes = []int{24 * 60, 60}
@@ -0,0 +1,89 @@
+package console
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/config"
+	"github.com/fajarachmadyusup13/gathering-app/internal/db"
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/repository"
+	"github.com/fajarachmadyusup13/gathering-app/internal/usecase"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import records from files",
+	Long:  "This subcommand groups the commands importing records from files",
+}
+
+var importMembersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "import members from a CSV file",
+	Long: "This subcommand registers the members of a CSV file with first_name, last_name and email columns, " +
+		"the members already registered with an email of the file get its names. It prints the import report",
+	Run: runImportMembers,
+}
+
+func init() {
+	importMembersCmd.Flags().String("file", "", "path of the CSV file")
+	importMembersCmd.Flags().Bool("dry-run", false, "validate the file and report the changes without writing them")
+	if err := importMembersCmd.MarkFlagRequired("file"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	importCmd.AddCommand(importMembersCmd)
+	RootCmd.AddCommand(importCmd)
+}
+
+func runImportMembers(cmd *cobra.Command, args []string) {
+	path, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	file, err := os.Open(path)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	defer file.Close()
+
+	db.InitializeMySQLConn()
+
+	memberRepo := repository.NewMemberRepository(db.MySQL)
+	gatheringRepo := repository.NewGatheringRepository(db.MySQL)
+	invitationRepo := repository.NewInvitationRepository(db.MySQL)
+	attendeeRepo := repository.NewAttendeeRepository(db.MySQL)
+	outboxRepo := repository.NewOutboxRepository(db.MySQL)
+	waitlistRepo := repository.NewWaitlistRepository(db.MySQL)
+	emailInvitationRepo := repository.NewEmailInvitationRepository(db.MySQL)
+	occurrenceRepo := repository.NewGatheringOccurrenceRepository(db.MySQL)
+	transactionManager := repository.NewTransactionManager(db.MySQL)
+
+	// events are written to the outbox, the outbox-relay command delivers them
+	eventPublisher := event.NewOutboxPublisher(outboxRepo)
+
+	invitationUsecase := usecase.NewInvitationUsecase(invitationRepo, memberRepo, gatheringRepo, attendeeRepo, waitlistRepo,
+		emailInvitationRepo, occurrenceRepo, transactionManager, eventPublisher)
+	memberUsecase := usecase.NewMemberUsecase(memberRepo, invitationRepo, attendeeRepo, gatheringRepo, waitlistRepo,
+		invitationUsecase, transactionManager, eventPublisher, model.DeletionPolicy(config.DeletionPolicy()))
+
+	report, err := memberUsecase.ImportMembers(context.Background(), file, dryRun)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logrus.Fatal(err)
+	}
+
+	db.StopTickerCh <- true
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,55 @@
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/config"
+	"github.com/fajarachmadyusup13/gathering-app/internal/job"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// recurring jobs run by the worker, the specs are standard 5 field cron specs
+const (
+	invitationExpireJob      = "invitation.expire"
+	idempotencyKeyCleanupJob = "idempotency_key.cleanup"
+	jobCleanupJob            = "job.cleanup"
+)
+
+var jobSchedules = map[string]string{
+	invitationExpireJob:      "*/5 * * * *",
+	idempotencyKeyCleanupJob: "0 * * * *",
+	jobCleanupJob:            "0 3 * * *",
+}
+
+// registerJobs wires the handlers of the background jobs and schedules the recurring ones
+func registerJobs(
+	worker *job.Worker,
+	invitationUsecase model.InvitationUsecase,
+	idempotencyKeyRepo model.IdempotencyKeyRepository,
+	jobRepo model.JobRepository,
+) error {
+	worker.Register(invitationExpireJob, func(ctx context.Context, _ *model.Job) error {
+		expired, err := invitationUsecase.ExpirePendingInvitations(ctx)
+		logrus.WithField("expired", expired).Info("pending invitations expired")
+		return err
+	})
+
+	worker.Register(idempotencyKeyCleanupJob, func(ctx context.Context, _ *model.Job) error {
+		_, err := idempotencyKeyRepo.DeleteExpired(ctx, time.Now())
+		return err
+	})
+
+	worker.Register(jobCleanupJob, func(ctx context.Context, _ *model.Job) error {
+		_, err := jobRepo.DeleteFinishedBefore(ctx, time.Now().Add(-config.JobRetention()))
+		return err
+	})
+
+	for name, spec := range jobSchedules {
+		if err := worker.Schedule(name, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
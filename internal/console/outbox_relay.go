@@ -0,0 +1,102 @@
+package console
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/config"
+	"github.com/fajarachmadyusup13/gathering-app/internal/db"
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/notification"
+	"github.com/fajarachmadyusup13/gathering-app/internal/repository"
+	"github.com/fajarachmadyusup13/gathering-app/internal/usecase"
+	"github.com/fajarachmadyusup13/gathering-app/internal/webhook"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var outboxRelayCmd = &cobra.Command{
+	Use:   "outbox-relay",
+	Short: "run outbox relay",
+	Long:  "This subcommand delivers the events stored in the outbox to their subscribers and sends the webhook deliveries",
+	Run:   runOutboxRelay,
+}
+
+func init() {
+	RootCmd.AddCommand(outboxRelayCmd)
+}
+
+func runOutboxRelay(cmd *cobra.Command, args []string) {
+	db.InitializeMySQLConn()
+
+	outboxRepo := repository.NewOutboxRepository(db.MySQL)
+	memberRepo := repository.NewMemberRepository(db.MySQL)
+	gatheringRepo := repository.NewGatheringRepository(db.MySQL)
+	webhookSubscriptionRepo := repository.NewWebhookSubscriptionRepository(db.MySQL)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(db.MySQL)
+	notificationDeliveryRepo := repository.NewNotificationDeliveryRepository(db.MySQL)
+	gatheringRoleRepo := repository.NewGatheringRoleRepository(db.MySQL)
+
+	webhookUsecase := usecase.NewWebhookUsecase(webhookSubscriptionRepo, webhookDeliveryRepo, gatheringRoleRepo,
+		webhook.NewSender(config.WebhookTimeout()), config.WebhookMaxAttempts(), config.WebhookTimeout())
+
+	notificationUsecase := usecase.NewNotificationUsecase(memberRepo, gatheringRepo, notificationDeliveryRepo, newNotifier())
+
+	eventBus := event.NewBus()
+	registerEventSubscribers(eventBus, webhookUsecase, notificationUsecase)
+
+	relay := event.NewRelay(outboxRepo, eventBus, config.OutboxBatchSize(), config.OutboxMaxAttempts())
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	logrus.Info("outbox relay started")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		relay.Run(ctx, config.OutboxPollInterval())
+	}()
+	go func() {
+		defer wg.Done()
+		poll(ctx, config.OutboxPollInterval(), webhookUsecase.DeliverDue)
+	}()
+	wg.Wait()
+
+	logrus.Info("outbox relay stopped")
+
+	db.StopTickerCh <- true
+}
+
+// newNotifier sends the emails through the configured SMTP server, or only logs them when there is none
+func newNotifier() model.Notifier {
+	if config.SMTPHost() == "" {
+		return notification.NewLogNotifier()
+	}
+	return notification.NewSMTPNotifier(config.SMTPHost(), config.SMTPPort(), config.SMTPUsername(),
+		config.SMTPPassword(), config.SMTPFrom(), config.SMTPTimeout())
+}
+
+// poll runs batch until ctx is done, it only waits for interval once a batch found nothing to do or failed
+func poll(ctx context.Context, interval time.Duration, batch func(ctx context.Context) (int, error)) {
+	for {
+		processed, err := batch(ctx)
+		if err != nil || processed == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
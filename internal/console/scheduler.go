@@ -0,0 +1,52 @@
+package console
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/config"
+	"github.com/fajarachmadyusup13/gathering-app/internal/db"
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/repository"
+	"github.com/fajarachmadyusup13/gathering-app/internal/usecase"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var schedulerCmd = &cobra.Command{
+	Use:   "scheduler",
+	Short: "run reminder scheduler",
+	Long:  "This subcommand dispatches the gathering reminders once they are due, several schedulers can run side by side",
+	Run:   runScheduler,
+}
+
+func init() {
+	RootCmd.AddCommand(schedulerCmd)
+}
+
+func runScheduler(cmd *cobra.Command, args []string) {
+	db.InitializeMySQLConn()
+
+	gatheringRepo := repository.NewGatheringRepository(db.MySQL)
+	attendeeRepo := repository.NewAttendeeRepository(db.MySQL)
+	reminderRepo := repository.NewGatheringReminderRepository(db.MySQL)
+	occurrenceRepo := repository.NewGatheringOccurrenceRepository(db.MySQL)
+	outboxRepo := repository.NewOutboxRepository(db.MySQL)
+	transactionManager := repository.NewTransactionManager(db.MySQL)
+
+	// the due reminders are written to the outbox, the outbox-relay command emails them
+	reminderUsecase := usecase.NewReminderUsecase(reminderRepo, gatheringRepo, attendeeRepo, occurrenceRepo, transactionManager,
+		event.NewOutboxPublisher(outboxRepo))
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	logrus.Info("reminder scheduler started")
+
+	poll(ctx, config.ReminderPollInterval(), reminderUsecase.DispatchDue)
+
+	logrus.Info("reminder scheduler stopped")
+
+	db.StopTickerCh <- true
+}
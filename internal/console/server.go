@@ -9,8 +9,11 @@ import (
 	"github.com/fajarachmadyusup13/gathering-app/internal/config"
 	"github.com/fajarachmadyusup13/gathering-app/internal/db"
 	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc"
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/fajarachmadyusup13/gathering-app/internal/repository"
 	"github.com/fajarachmadyusup13/gathering-app/internal/usecase"
+	"github.com/fajarachmadyusup13/gathering-app/internal/webhook"
 	"github.com/gin-gonic/gin"
 	"github.com/labstack/gommon/log"
 	"github.com/spf13/cobra"
@@ -35,15 +38,64 @@ func run(cmd *cobra.Command, args []string) {
 	gatheringRepo := repository.NewGatheringRepository(db.MySQL)
 	invitationRepo := repository.NewInvitationRepository(db.MySQL)
 	attendeeRepo := repository.NewAttendeeRepository(db.MySQL)
+	idempotencyKeyRepo := repository.NewIdempotencyKeyRepository(db.MySQL)
+	outboxRepo := repository.NewOutboxRepository(db.MySQL)
+	webhookSubscriptionRepo := repository.NewWebhookSubscriptionRepository(db.MySQL)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(db.MySQL)
+	reminderRepo := repository.NewGatheringReminderRepository(db.MySQL)
+	jobRepo := repository.NewJobRepository(db.MySQL)
+	occurrenceRepo := repository.NewGatheringOccurrenceRepository(db.MySQL)
+	waitlistRepo := repository.NewWaitlistRepository(db.MySQL)
+	inviteLinkRepo := repository.NewInviteLinkRepository(db.MySQL)
+	emailInvitationRepo := repository.NewEmailInvitationRepository(db.MySQL)
+	analyticsRepo := repository.NewAnalyticsRepository(db.MySQL)
+	memberGroupRepo := repository.NewMemberGroupRepository(db.MySQL)
+	gatheringRoleRepo := repository.NewGatheringRoleRepository(db.MySQL)
+	transactionManager := repository.NewTransactionManager(db.MySQL)
 
-	memberUsecase := usecase.NewMemberUsecase(memberRepo)
-	gatheringUsecase := usecase.NewGatheringUsecase(gatheringRepo)
-	invitationUsecase := usecase.NewInvitationUsecase(invitationRepo, memberRepo, gatheringRepo, attendeeRepo)
+	// events are written to the outbox in the transaction of the change, the outbox-relay command delivers them
+	eventPublisher := event.NewOutboxPublisher(outboxRepo)
+	deletionPolicy := model.DeletionPolicy(config.DeletionPolicy())
+
+	invitationUsecase := usecase.NewInvitationUsecase(invitationRepo, memberRepo, gatheringRepo, attendeeRepo, waitlistRepo,
+		emailInvitationRepo, occurrenceRepo, transactionManager, eventPublisher)
+	memberUsecase := usecase.NewMemberUsecase(memberRepo, invitationRepo, attendeeRepo, gatheringRepo, waitlistRepo,
+		invitationUsecase, transactionManager, eventPublisher, deletionPolicy)
+	gatheringUsecase := usecase.NewGatheringUsecase(gatheringRepo, invitationRepo, attendeeRepo, reminderRepo, waitlistRepo,
+		gatheringRoleRepo, transactionManager, eventPublisher, deletionPolicy, config.ReminderOffsetMinutes())
+	webhookUsecase := usecase.NewWebhookUsecase(webhookSubscriptionRepo, webhookDeliveryRepo, gatheringRoleRepo,
+		webhook.NewSender(config.WebhookTimeout()), config.WebhookMaxAttempts(), config.WebhookTimeout())
+	reminderUsecase := usecase.NewReminderUsecase(reminderRepo, gatheringRepo, attendeeRepo, occurrenceRepo, transactionManager,
+		eventPublisher)
+	jobUsecase := usecase.NewJobUsecase(jobRepo)
+	outboxUsecase := usecase.NewOutboxUsecase(outboxRepo)
+	calendarUsecase := usecase.NewCalendarUsecase(memberRepo, gatheringRepo, attendeeRepo, occurrenceRepo)
+	occurrenceUsecase := usecase.NewOccurrenceUsecase(gatheringRepo, occurrenceRepo)
+	waitlistUsecase := usecase.NewWaitlistUsecase(waitlistRepo, memberRepo, gatheringRepo)
+	inviteLinkUsecase := usecase.NewInviteLinkUsecase(inviteLinkRepo, gatheringRepo, invitationUsecase, transactionManager,
+		config.InviteLinkSecret(), config.InviteLinkTTL())
+	attendeeUsecase := usecase.NewAttendeeUsecase(attendeeRepo, gatheringRepo, transactionManager, eventPublisher)
+	analyticsUsecase := usecase.NewAnalyticsUsecase(analyticsRepo, gatheringRepo)
+	memberGroupUsecase := usecase.NewMemberGroupUsecase(memberGroupRepo, memberRepo, invitationUsecase, transactionManager)
+	gatheringRoleUsecase := usecase.NewGatheringRoleUsecase(gatheringRoleRepo, gatheringRepo, memberRepo, transactionManager)
 
 	httpService := httpsvc.NewHTTPService()
 	httpService.RegisterMemberUsecase(memberUsecase)
 	httpService.RegisterGatheringUsecase(gatheringUsecase)
 	httpService.RegisterInvitationUsecase(invitationUsecase)
+	httpService.RegisterWebhookUsecase(webhookUsecase)
+	httpService.RegisterReminderUsecase(reminderUsecase)
+	httpService.RegisterJobUsecase(jobUsecase)
+	httpService.RegisterOutboxUsecase(outboxUsecase)
+	httpService.RegisterCalendarUsecase(calendarUsecase)
+	httpService.RegisterOccurrenceUsecase(occurrenceUsecase)
+	httpService.RegisterWaitlistUsecase(waitlistUsecase)
+	httpService.RegisterInviteLinkUsecase(inviteLinkUsecase)
+	httpService.RegisterAttendeeUsecase(attendeeUsecase)
+	httpService.RegisterAnalyticsUsecase(analyticsUsecase)
+	httpService.RegisterMemberGroupUsecase(memberGroupUsecase)
+	httpService.RegisterGatheringRoleUsecase(gatheringRoleUsecase)
+	httpService.RegisterIdempotencyKeyRepository(idempotencyKeyRepo)
 
 	sigCh := make(chan os.Signal, 1)
 	errCh := make(chan error, 1)
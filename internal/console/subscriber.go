@@ -0,0 +1,20 @@
+package console
+
+import (
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+// registerEventSubscribers wires the handlers reacting to the domain events published by the usecases
+func registerEventSubscribers(bus *event.Bus, webhookUsecase model.WebhookUsecase, notificationUsecase model.NotificationUsecase) {
+	for _, eventType := range model.EventTypes {
+		bus.Subscribe(eventType, event.LogEvent)
+		bus.Subscribe(eventType, webhookUsecase.EnqueueDeliveries)
+	}
+
+	bus.Subscribe(model.InvitationSent, notificationUsecase.NotifyInvitationSent)
+	bus.Subscribe(model.GatheringCancelled, notificationUsecase.NotifyGatheringCancelled)
+	bus.Subscribe(model.GatheringReminderDue, notificationUsecase.NotifyReminderDue)
+	bus.Subscribe(model.InvitationPromoted, notificationUsecase.NotifyInvitationPromoted)
+	bus.Subscribe(model.EmailInvitationSent, notificationUsecase.NotifyEmailInvitationSent)
+}
@@ -0,0 +1,65 @@
+package console
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/config"
+	"github.com/fajarachmadyusup13/gathering-app/internal/db"
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/job"
+	"github.com/fajarachmadyusup13/gathering-app/internal/repository"
+	"github.com/fajarachmadyusup13/gathering-app/internal/usecase"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "run background job worker",
+	Long:  "This subcommand runs the background jobs, such as invitation expiry and cleanups, several workers can run side by side",
+	Run:   runWorker,
+}
+
+func init() {
+	RootCmd.AddCommand(workerCmd)
+}
+
+func runWorker(cmd *cobra.Command, args []string) {
+	db.InitializeMySQLConn()
+
+	memberRepo := repository.NewMemberRepository(db.MySQL)
+	gatheringRepo := repository.NewGatheringRepository(db.MySQL)
+	invitationRepo := repository.NewInvitationRepository(db.MySQL)
+	attendeeRepo := repository.NewAttendeeRepository(db.MySQL)
+	idempotencyKeyRepo := repository.NewIdempotencyKeyRepository(db.MySQL)
+	outboxRepo := repository.NewOutboxRepository(db.MySQL)
+	jobRepo := repository.NewJobRepository(db.MySQL)
+	waitlistRepo := repository.NewWaitlistRepository(db.MySQL)
+	emailInvitationRepo := repository.NewEmailInvitationRepository(db.MySQL)
+	occurrenceRepo := repository.NewGatheringOccurrenceRepository(db.MySQL)
+	transactionManager := repository.NewTransactionManager(db.MySQL)
+
+	// events are written to the outbox, the outbox-relay command delivers them
+	eventPublisher := event.NewOutboxPublisher(outboxRepo)
+
+	invitationUsecase := usecase.NewInvitationUsecase(invitationRepo, memberRepo, gatheringRepo, attendeeRepo, waitlistRepo,
+		emailInvitationRepo, occurrenceRepo, transactionManager, eventPublisher)
+
+	worker := job.NewWorker(jobRepo, config.JobLease(), config.JobMaxAttempts(), config.JobBatchSize())
+	if err := registerJobs(worker, invitationUsecase, idempotencyKeyRepo, jobRepo); err != nil {
+		logrus.Fatal(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	logrus.Info("worker started")
+
+	worker.Run(ctx, config.JobPollInterval())
+
+	logrus.Info("worker stopped")
+
+	db.StopTickerCh <- true
+}
@@ -0,0 +1,67 @@
+package httpsvc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *HTTPService) FindGatheringAnalytics(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	intID, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, int64(intID), model.InviteToGathering) {
+		return
+	}
+
+	from, to, err := queryDateRange(c, model.DefaultAnalyticsWindow)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.analyticsUsecase.FindGatheringAnalytics(ctx, int64(intID), from, to)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindCreatorAnalytics(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	// a member only sees the analytics of the gatherings they created
+	creator, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	from, to, err := queryDateRange(c, model.DefaultAnalyticsWindow)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.analyticsUsecase.FindCreatorAnalytics(ctx, creator, from, to)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,126 @@
+package httpsvc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+)
+
+// checkInQRCodeSize is the width and height in pixels of the check-in QR codes
+const checkInQRCodeSize = 256
+
+func (s *HTTPService) ExportCheckInQRCode(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	memberID, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	gatheringID, err := strconv.ParseInt(c.Query("gathering_id"), 10, 64)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	code, err := s.attendeeUsecase.FindCheckInCode(ctx, memberID, gatheringID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	png, err := qrcode.Encode(code, qrcode.Medium, checkInQRCodeSize)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusInternalServerError, err.Error())
+		c.Error(err)
+		return
+	}
+
+	// the code grants the check-in, it must not linger in shared caches
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+func (s *HTTPService) CheckInAttendee(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.CheckInRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	res, err := s.attendeeUsecase.CheckIn(ctx, body.GatheringID, body.Code)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) UndoAttendeeCheckIn(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.CheckInRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	res, err := s.attendeeUsecase.UndoCheckIn(ctx, body.GatheringID, body.Code)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindGatheringAttendance(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, int64(intID), model.InviteToGathering) {
+		return
+	}
+
+	res, err := s.attendeeUsecase.FindAttendanceByGatheringID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
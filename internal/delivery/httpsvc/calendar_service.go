@@ -0,0 +1,105 @@
+package httpsvc
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/calendar"
+	"github.com/fajarachmadyusup13/gathering-app/internal/config"
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// calendarFeedPath serves the member calendar feeds, it has to match the route registered in InitRoutes
+const calendarFeedPath = "/member/calendarFeed"
+
+func (s *HTTPService) ExportGatheringICS(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	feed, err := s.calendarUsecase.FindGatheringCalendar(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="gathering-%d.ics"`, intID))
+	c.Data(http.StatusOK, calendar.ContentType, calendar.Encode(feed.Name, feed.Occurrences))
+}
+
+func (s *HTTPService) FindCalendarFeedURL(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	memberID, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	token, err := s.calendarUsecase.FindCalendarToken(ctx, memberID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &httpsvcModel.CalendarFeedURLResponse{URL: tokenURL(calendarFeedPath, token)})
+}
+
+func (s *HTTPService) ResetCalendarFeedURL(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	memberID, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	token, err := s.calendarUsecase.ResetCalendarToken(ctx, memberID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &httpsvcModel.CalendarFeedURLResponse{URL: tokenURL(calendarFeedPath, token)})
+}
+
+func (s *HTTPService) ExportCalendarFeed(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	feed, err := s.calendarUsecase.FindCalendarFeed(ctx, c.Query("token"))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, calendar.ContentType, calendar.Encode(feed.Name, feed.Occurrences))
+}
+
+// tokenURL builds the URL of path carrying token on the configured public URL of the service, the request headers
+// are not trusted for it
+func tokenURL(path string, token string) string {
+	tokenURL, err := url.Parse(config.PublicURL())
+	if err != nil {
+		logrus.WithField("publicURL", config.PublicURL()).Error(err)
+		tokenURL = &url.URL{}
+	}
+
+	tokenURL.Path = strings.TrimSuffix(tokenURL.Path, "/") + path
+	tokenURL.RawQuery = url.Values{"token": {token}}.Encode()
+	return tokenURL.String()
+}
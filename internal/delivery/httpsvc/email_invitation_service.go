@@ -0,0 +1,103 @@
+package httpsvc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *HTTPService) InviteEmailToGathering(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.CreateEmailInvitationRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	invitation := &model.EmailInvitation{
+		ID:           model.GenerateID(),
+		GatheringID:  body.GatheringID,
+		Email:        body.Email,
+		OccurrenceAt: body.OccurrenceAt,
+	}
+
+	res, err := s.invitationUsecase.InviteEmailToGathering(ctx, invitation)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+func (s *HTTPService) FindGatheringEmailInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, int64(intID), model.InviteToGathering) {
+		return
+	}
+
+	res, err := s.invitationUsecase.FindEmailInvitationsByGatheringID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) DeleteEmailInvitationByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	invitation, err := s.invitationUsecase.FindEmailInvitationByID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, invitation.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	res, err := s.invitationUsecase.DeleteEmailInvitationByID(ctx, invitation.ID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
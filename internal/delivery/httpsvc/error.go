@@ -0,0 +1,74 @@
+package httpsvc
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/usecase"
+)
+
+// httpStatusFromError maps usecase errors to the HTTP status returned to the client
+func httpStatusFromError(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvitationAlreadyExists),
+		errors.Is(err, usecase.ErrInvitedToOtherOccurrence),
+		errors.Is(err, usecase.ErrInvitationDeclined),
+		errors.Is(err, usecase.ErrInvitationExpired),
+		errors.Is(err, usecase.ErrEmailAlreadyRegistered),
+		errors.Is(err, usecase.ErrRecordNotDeleted),
+		errors.Is(err, usecase.ErrRestoreParentDeleted),
+		errors.Is(err, usecase.ErrDeletionRestricted),
+		errors.Is(err, usecase.ErrWebhookDeliveryPending),
+		errors.Is(err, usecase.ErrJobNotDead),
+		errors.Is(err, usecase.ErrOutboxMessageNotDeadLettered),
+		errors.Is(err, usecase.ErrNotEnoughSeats),
+		errors.Is(err, usecase.ErrAllowedGuestsBelowGuests),
+		errors.Is(err, usecase.ErrAlreadyCheckedIn),
+		errors.Is(err, usecase.ErrNotCheckedIn),
+		errors.Is(err, usecase.ErrMemberGroupTooLarge),
+		errors.Is(err, usecase.ErrGatheringOwnerRole):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrBulkInvitationEmpty),
+		errors.Is(err, usecase.ErrBulkInvitationTooLarge),
+		errors.Is(err, usecase.ErrInvalidBulkInvitationMode),
+		errors.Is(err, usecase.ErrInvalidWebhookURL),
+		errors.Is(err, usecase.ErrInvalidWebhookEventType),
+		errors.Is(err, usecase.ErrInvalidReminderOffset),
+		errors.Is(err, usecase.ErrInvalidTimeZone),
+		errors.Is(err, usecase.ErrInvalidGatheringSchedule),
+		errors.Is(err, usecase.ErrGatheringStartInPast),
+		errors.Is(err, usecase.ErrInvalidRecurrence),
+		errors.Is(err, usecase.ErrInvalidOccurrence),
+		errors.Is(err, usecase.ErrInvalidCapacity),
+		errors.Is(err, usecase.ErrInvalidInviteLink),
+		errors.Is(err, usecase.ErrInvalidEmail),
+		errors.Is(err, usecase.ErrInvalidAllowedGuests),
+		errors.Is(err, usecase.ErrInvalidGuests),
+		errors.Is(err, usecase.ErrGuestsNotAccepted),
+		errors.Is(err, usecase.ErrInvalidDateRange),
+		errors.Is(err, usecase.ErrInvalidMemberImport),
+		errors.Is(err, usecase.ErrInvalidMemberGroupName),
+		errors.Is(err, usecase.ErrMemberGroupMembersEmpty),
+		errors.Is(err, usecase.ErrInvalidGatheringRole):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrBulkInvitationRejected),
+		errors.Is(err, usecase.ErrGatheringNotScheduled),
+		errors.Is(err, usecase.ErrSeriesCheckIn):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, usecase.ErrInviteLinkExpired),
+		errors.Is(err, usecase.ErrInviteLinkRevoked),
+		errors.Is(err, usecase.ErrInviteLinkUsedUp):
+		return http.StatusGone
+	case errors.Is(err, usecase.ErrGatheringForbidden),
+		errors.Is(err, usecase.ErrInvitationForbidden),
+		errors.Is(err, usecase.ErrWebhookForbidden),
+		errors.Is(err, usecase.ErrMemberGroupForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrInviteLinksDisabled):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
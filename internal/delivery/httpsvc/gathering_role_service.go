@@ -0,0 +1,161 @@
+package httpsvc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHeader carries the id of the member making the request, see middleware.ActorHeader
+const ActorHeader = middleware.ActorHeader
+
+// actorFromRequest returns the id of the member making the request, the request gets an error when the header is missing
+func actorFromRequest(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.GetHeader(ActorHeader), 10, 64)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusUnauthorized,
+			fmt.Sprintf("the %s header must hold the id of the member making the request", ActorHeader))
+		c.Error(err)
+		return 0, false
+	}
+	return id, true
+}
+
+// authorizeGathering reports whether the role of the member making the request on the gathering grants the
+// permission, the request gets an error otherwise
+func (s *HTTPService) authorizeGathering(c *gin.Context, gatheringID int64, permission model.GatheringPermission) bool {
+	ctx := c.Request.Context()
+
+	actorID, ok := actorFromRequest(c)
+	if !ok {
+		return false
+	}
+
+	err := s.gatheringRoleUsecase.AuthorizeGathering(ctx, gatheringID, actorID, permission)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return false
+	}
+	return true
+}
+
+func (s *HTTPService) FindGatheringRoles(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.gatheringRoleUsecase.FindGatheringRoles(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) AddGatheringRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.GatheringRoleRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.ManageGatheringRoles) {
+		return
+	}
+
+	role := &model.GatheringRole{
+		GatheringID: body.GatheringID,
+		MemberID:    body.MemberID,
+		Role:        body.Role,
+	}
+	if role.Role == "" {
+		role.Role = model.GatheringCoHost
+	}
+
+	res, err := s.gatheringRoleUsecase.AddGatheringRole(ctx, role)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) RemoveGatheringRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.GatheringRoleRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.ManageGatheringRoles) {
+		return
+	}
+
+	err = s.gatheringRoleUsecase.RemoveGatheringRole(ctx, body.GatheringID, body.MemberID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	// the roles left on the gathering are returned
+	res, err := s.gatheringRoleUsecase.FindGatheringRoles(ctx, body.GatheringID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) TransferGatheringOwnership(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.GatheringRoleRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.ManageGatheringRoles) {
+		return
+	}
+
+	res, err := s.gatheringRoleUsecase.TransferGatheringOwnership(ctx, body.GatheringID, body.MemberID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
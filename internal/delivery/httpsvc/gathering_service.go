@@ -15,25 +15,36 @@ func (s *HTTPService) CreateGathering(c *gin.Context) {
 	ctx := c.Request.Context()
 	body := httpsvcModel.CreateGatheringRequest{}
 
+	creator, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
 	err := json.NewDecoder(c.Request.Body).Decode(&body)
 	if err != nil {
 		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
 		c.Error(err)
+		return
 	}
 
 	gathering := &model.Gathering{
 		ID:          model.GenerateID(),
-		Creator:     body.Creator,
+		Creator:     creator,
 		ScheduledAt: body.ScheduledAt,
+		EndsAt:      body.EndsAt,
+		TimeZone:    body.TimeZone,
+		Recurrence:  body.Recurrence,
 		Type:        body.Type,
+		Capacity:    body.Capacity,
 		Name:        body.Name,
 		Location:    body.Location,
 	}
 
 	err = s.gatheringUsecase.CreateGathering(ctx, gathering)
 	if err != nil {
-		err = middleware.NewHTTPError(http.StatusInternalServerError, err.Error())
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
 		c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusCreated, gathering)
@@ -67,21 +78,30 @@ func (s *HTTPService) UpdateGathering(c *gin.Context) {
 	if err != nil {
 		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
 		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.ID, model.UpdateGathering) {
+		return
 	}
 
 	gathering := &model.Gathering{
 		ID:          body.ID,
-		Creator:     body.Creator,
 		ScheduledAt: body.ScheduledAt,
+		EndsAt:      body.EndsAt,
+		TimeZone:    body.TimeZone,
+		Recurrence:  body.Recurrence,
 		Type:        body.Type,
+		Capacity:    body.Capacity,
 		Name:        body.Name,
 		Location:    body.Location,
 	}
 
 	res, err := s.gatheringUsecase.UpdateGatheringByID(ctx, gathering)
 	if err != nil {
-		err = middleware.NewHTTPError(http.StatusInternalServerError, err.Error())
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
 		c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusOK, res)
@@ -96,12 +116,53 @@ func (s *HTTPService) DeleteGatheringByID(c *gin.Context) {
 	if err != nil {
 		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
 		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, int64(intID), model.DeleteGathering) {
+		return
 	}
 
 	res, err := s.gatheringUsecase.DeleteGatheringByID(ctx, int64(intID))
 	if err != nil {
-		err = middleware.NewHTTPError(http.StatusInternalServerError, err.Error())
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindDeletedGatherings(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res, err := s.gatheringUsecase.FindDeletedGatherings(ctx)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) RestoreGatheringByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.gatheringUsecase.RestoreGatheringByID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
 		c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusOK, res)
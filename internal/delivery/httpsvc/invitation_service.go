@@ -2,12 +2,14 @@ package httpsvc
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
 	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,24 +21,71 @@ func (s *HTTPService) InviteMemberToGathering(c *gin.Context) {
 	if err != nil {
 		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
 		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.InviteToGathering) {
+		return
 	}
 
 	invitation := &model.Invitation{
-		ID:          model.GenerateID(),
-		MemberID:    body.MemberID,
-		GatheringID: body.GatheringID,
-		Status:      body.Status,
+		ID:            model.GenerateID(),
+		MemberID:      body.MemberID,
+		GatheringID:   body.GatheringID,
+		OccurrenceAt:  body.OccurrenceAt,
+		Status:        body.Status,
+		AllowedGuests: body.AllowedGuests,
 	}
 
 	res, err := s.invitationUsecase.InviteMemberToGathering(ctx, invitation)
 	if err != nil {
-		err = middleware.NewHTTPError(http.StatusInternalServerError, err.Error())
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
 		c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusCreated, res)
 }
 
+func (s *HTTPService) BulkInviteMembersToGathering(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.BulkInvitationRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	bulkInvitation := &model.BulkInvitation{
+		GatheringID:   body.GatheringID,
+		OccurrenceAt:  body.OccurrenceAt,
+		MemberIDs:     body.MemberIDs,
+		Status:        body.Status,
+		Mode:          body.Mode,
+		AllowedGuests: body.AllowedGuests,
+	}
+
+	report, err := s.invitationUsecase.BulkInviteMembersToGathering(ctx, bulkInvitation)
+	switch {
+	case errors.Is(err, usecase.ErrBulkInvitationRejected):
+		// the report tells which members prevented the invitation
+		c.JSON(httpStatusFromError(err), report)
+		return
+	case err != nil:
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
 func (s *HTTPService) FindInvitationByID(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -65,19 +114,74 @@ func (s *HTTPService) UpdateInvitation(c *gin.Context) {
 	if err != nil {
 		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
 		c.Error(err)
+		return
+	}
+
+	memberID, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	stored, err := s.invitationUsecase.FindInvitationByID(ctx, body.ID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	// only the invited member answers the invitation
+	if stored.MemberID != memberID {
+		err = middleware.NewHTTPError(http.StatusForbidden, usecase.ErrInvitationForbidden.Error())
+		c.Error(err)
+		return
 	}
 
 	invitation := &model.Invitation{
-		ID:          body.ID,
-		MemberID:    body.MemberID,
-		GatheringID: body.GatheringID,
+		ID:          stored.ID,
+		MemberID:    stored.MemberID,
+		GatheringID: stored.GatheringID,
 		Status:      body.Status,
+		Guests:      body.Guests,
+		GuestNames:  body.GuestNames,
 	}
 
 	res, err := s.invitationUsecase.UpdateInvitationByID(ctx, invitation)
 	if err != nil {
-		err = middleware.NewHTTPError(http.StatusInternalServerError, err.Error())
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) UpdateInvitationAllowedGuests(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.UpdateAllowedGuestsRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	invitation, err := s.invitationUsecase.FindInvitationByID(ctx, body.ID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
 		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, invitation.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	res, err := s.invitationUsecase.UpdateAllowedGuestsByID(ctx, invitation.ID, body.AllowedGuests)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusOK, res)
@@ -92,12 +196,60 @@ func (s *HTTPService) DeleteInvitationByID(c *gin.Context) {
 	if err != nil {
 		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
 		c.Error(err)
+		return
 	}
 
-	res, err := s.invitationUsecase.DeleteInvitationByID(ctx, int64(intID))
+	invitation, err := s.invitationUsecase.FindInvitationByID(ctx, int64(intID))
 	if err != nil {
-		err = middleware.NewHTTPError(http.StatusInternalServerError, err.Error())
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, invitation.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	res, err := s.invitationUsecase.DeleteInvitationByID(ctx, invitation.ID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindDeletedInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res, err := s.invitationUsecase.FindDeletedInvitations(ctx)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) RestoreInvitationByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.invitationUsecase.RestoreInvitationByID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
 		c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusOK, res)
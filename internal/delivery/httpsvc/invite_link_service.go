@@ -0,0 +1,138 @@
+package httpsvc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// redeemInviteLinkPath redeems the invite links, it has to match the route registered in InitRoutes
+const redeemInviteLinkPath = "/invitation/redeemLink"
+
+func (s *HTTPService) CreateInviteLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.CreateInviteLinkRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	link := &model.InviteLink{
+		ID:          model.GenerateID(),
+		GatheringID: body.GatheringID,
+		MaxUses:     body.MaxUses,
+	}
+	if body.ExpiresAt != nil {
+		link.ExpiresAt = *body.ExpiresAt
+	}
+
+	res, err := s.inviteLinkUsecase.CreateInviteLink(ctx, link)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, inviteLinkResponse(res))
+}
+
+func (s *HTTPService) FindGatheringInviteLinks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, int64(intID), model.InviteToGathering) {
+		return
+	}
+
+	links, err := s.inviteLinkUsecase.FindInviteLinksByGatheringID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	res := make([]*httpsvcModel.InviteLinkResponse, 0, len(links))
+	for _, link := range links {
+		res = append(res, inviteLinkResponse(link))
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) RevokeInviteLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	link, err := s.inviteLinkUsecase.FindInviteLinkByID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, link.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	res, err := s.inviteLinkUsecase.RevokeInviteLinkByID(ctx, link.ID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) RedeemInviteLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	memberID, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	res, err := s.inviteLinkUsecase.RedeemInviteLink(ctx, c.Query("token"), memberID)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+func inviteLinkResponse(link *model.InviteLink) *httpsvcModel.InviteLinkResponse {
+	return &httpsvcModel.InviteLinkResponse{
+		InviteLink: link,
+		URL:        tokenURL(redeemInviteLinkPath, link.Token),
+	}
+}
@@ -0,0 +1,60 @@
+package httpsvc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *HTTPService) FindJobRuns(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	filter := &model.JobFilter{
+		Name:   c.Query("name"),
+		Status: model.JobStatus(c.Query("status")),
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		intLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+			c.Error(err)
+			return
+		}
+		filter.Limit = intLimit
+	}
+
+	res, err := s.jobUsecase.FindJobRuns(ctx, filter)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) RetryJobByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.jobUsecase.RetryJobByID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,274 @@
+package httpsvc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *HTTPService) CreateMemberGroup(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.CreateMemberGroupRequest{}
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	group := &model.MemberGroup{
+		ID:    model.GenerateID(),
+		Name:  body.Name,
+		Owner: owner,
+	}
+
+	err = s.memberGroupUsecase.CreateGroup(ctx, group)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+func (s *HTTPService) UpdateMemberGroup(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.UpdateMemberGroupRequest{}
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	group := &model.MemberGroup{
+		ID:    body.ID,
+		Name:  body.Name,
+		Owner: owner,
+	}
+
+	res, err := s.memberGroupUsecase.UpdateGroupByID(ctx, group)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindMemberGroupByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.memberGroupUsecase.FindGroupByID(ctx, int64(intID), owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindMemberGroupsByOwner(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	res, err := s.memberGroupUsecase.FindGroupsByOwner(ctx, owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) DeleteMemberGroupByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.memberGroupUsecase.DeleteGroupByID(ctx, int64(intID), owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindMemberGroupMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.memberGroupUsecase.FindGroupMembers(ctx, int64(intID), owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) AddMemberGroupMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.MemberGroupMembersRequest{}
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.memberGroupUsecase.AddGroupMembers(ctx, body.GroupID, owner, body.MemberIDs)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) RemoveMemberGroupMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.MemberGroupMembersRequest{}
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.memberGroupUsecase.RemoveGroupMembers(ctx, body.GroupID, owner, body.MemberIDs)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) InviteGroupToGathering(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.GroupInvitationRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	// the group is only invited by its owner, who needs a role on the gathering too
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.InviteToGathering) {
+		return
+	}
+
+	groupInvitation := &model.GroupInvitation{
+		GroupID:       body.GroupID,
+		GatheringID:   body.GatheringID,
+		OccurrenceAt:  body.OccurrenceAt,
+		Status:        body.Status,
+		Mode:          body.Mode,
+		AllowedGuests: body.AllowedGuests,
+	}
+
+	report, err := s.memberGroupUsecase.InviteGroupToGathering(ctx, groupInvitation, owner)
+	switch {
+	case errors.Is(err, usecase.ErrBulkInvitationRejected):
+		// the report tells which members prevented the invitation
+		c.JSON(httpStatusFromError(err), report)
+		return
+	case err != nil:
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
@@ -2,8 +2,11 @@ package httpsvc
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
 	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
@@ -92,13 +95,148 @@ func (s *HTTPService) DeleteMemberByID(c *gin.Context) {
 	if err != nil {
 		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
 		c.Error(err)
+		return
 	}
 
 	res, err := s.memberUsecase.DeleteMemberByID(ctx, int64(intID))
 	if err != nil {
-		err = middleware.NewHTTPError(http.StatusInternalServerError, err.Error())
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindDeletedMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	res, err := s.memberUsecase.FindDeletedMembers(ctx)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
 		c.Error(err)
+		return
 	}
 
 	c.JSON(http.StatusOK, res)
 }
+
+func (s *HTTPService) RestoreMemberByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.memberUsecase.RestoreMemberByID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindMemberStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	memberID, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	from, to, err := queryDateRange(c, model.DefaultMemberStatsWindow)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.memberUsecase.FindMemberStats(ctx, memberID, from, to)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) ImportMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, model.MaxMemberImportBytes)
+	var file io.Reader = c.Request.Body
+	// a spreadsheet uploaded from a form is the file field of a multipart body, otherwise the body is the CSV
+	if c.ContentType() == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			err = middleware.NewHTTPError(importStatusFromError(err, http.StatusBadRequest), err.Error())
+			c.Error(err)
+			return
+		}
+
+		f, err := header.Open()
+		if err != nil {
+			err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+			c.Error(err)
+			return
+		}
+		defer f.Close()
+		file = f
+	}
+
+	res, err := s.memberUsecase.ImportMembers(ctx, file, dryRun)
+	if err != nil {
+		err = middleware.NewHTTPError(importStatusFromError(err, httpStatusFromError(err)), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// importStatusFromError answers a file over model.MaxMemberImportBytes with 413, and other errors with status
+func importStatusFromError(err error, status int) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return status
+}
+
+// queryDateRange parses the RFC 3339 from and to query parameters, the range defaults to the window ending now
+func queryDateRange(c *gin.Context, window time.Duration) (time.Time, time.Time, error) {
+	var err error
+
+	to := time.Now()
+	if c.Query("to") != "" {
+		to, err = time.Parse(time.RFC3339, c.Query("to"))
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	from := to.Add(-window)
+	if c.Query("from") != "" {
+		from, err = time.Parse(time.RFC3339, c.Query("from"))
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuth only lets through requests carrying the admin token as a bearer token,
+// every request is rejected when no admin token is configured.
+func AdminAuth(adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if adminToken == "" {
+			c.Error(NewHTTPError(http.StatusForbidden, "admin endpoints are disabled"))
+			c.Abort()
+			return
+		}
+
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			c.Error(NewHTTPError(http.StatusUnauthorized, "invalid admin token"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
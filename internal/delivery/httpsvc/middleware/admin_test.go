@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(adminToken string) *gin.Engine {
+		route := gin.New()
+		route.Use(CustomErrorMiddleware)
+		route.GET("/admin/member/deleted", AdminAuth(adminToken), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{})
+		})
+		return route
+	}
+
+	tests := []struct {
+		name          string
+		adminToken    string
+		authorization string
+		expectedCode  int
+	}{
+		{"valid token", "secret", "Bearer secret", http.StatusOK},
+		{"invalid token", "secret", "Bearer other", http.StatusUnauthorized},
+		{"missing token", "secret", "", http.StatusUnauthorized},
+		{"admin disabled", "", "Bearer ", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/member/deleted", nil)
+			req.Header.Set("Authorization", tt.authorization)
+
+			rec := httptest.NewRecorder()
+			newRouter(tt.adminToken).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// IdempotencyKeyHeader header carrying the client supplied idempotency key
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader set on responses replayed from a stored idempotency key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// ActorHeader carries the id of the member making the request, the gateway in front of the service sets it once
+	// the member is authenticated. The service trusts it as is, so the gateway must also strip it from the client
+	// requests
+	ActorHeader = "X-Member-ID"
+
+	// maxIdempotencyKeyLength leaves room in the key column for the member the key is scoped to
+	maxIdempotencyKeyLength = 200
+)
+
+// responseRecorder keeps a copy of everything written to the response body
+type responseRecorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response when a request is retried with the same Idempotency-Key header.
+// Requests without the header are passed through untouched. The stored response is kept for ttl while
+// a request in progress only holds its key for lockLease, so a key left behind by a crash can be retried.
+// Keys are scoped to the member in the ActorHeader, a member never gets the response stored for another member.
+func Idempotency(repo model.IdempotencyKeyRepository, ttl, lockLease time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || repo == nil {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.Error(NewHTTPError(http.StatusBadRequest, "idempotency key can't be longer than 200 characters"))
+			c.Abort()
+			return
+		}
+		key = scopeIdempotencyKey(c.GetHeader(ActorHeader), key)
+
+		ctx := c.Request.Context()
+		logger := logrus.WithFields(logrus.Fields{
+			"ctx": ctx,
+			"key": key,
+		})
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(NewHTTPError(http.StatusBadRequest, err.Error()))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		requestHash := hashRequest(c.Request.Method, c.FullPath(), body)
+
+		stored, err := repo.FindByKey(ctx, key)
+		if err != nil {
+			logger.Error(err)
+			c.Error(NewHTTPError(http.StatusInternalServerError, err.Error()))
+			c.Abort()
+			return
+		}
+
+		if stored != nil && stored.IsExpired(time.Now()) {
+			if err = repo.DeleteByKey(ctx, key); err != nil {
+				logger.Error(err)
+				c.Error(NewHTTPError(http.StatusInternalServerError, err.Error()))
+				c.Abort()
+				return
+			}
+			stored = nil
+		}
+
+		now := time.Now()
+		idempotencyKey := &model.IdempotencyKey{
+			Key:         key,
+			RequestHash: requestHash,
+			LockedUntil: now.Add(lockLease),
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		switch {
+		case stored == nil:
+			err = repo.Create(ctx, idempotencyKey)
+		case stored.RequestHash == requestHash && !stored.IsCompleted() && !stored.IsLocked(now):
+			err = reclaimIdempotencyKey(ctx, repo, idempotencyKey, now)
+		default:
+			replayIdempotentResponse(c, stored, requestHash)
+			return
+		}
+
+		switch {
+		case errors.Is(err, model.ErrDuplicateEntry):
+			c.Error(NewHTTPError(http.StatusConflict, "a request with this idempotency key is still being processed"))
+			c.Abort()
+			return
+		case err != nil:
+			logger.Error(err)
+			c.Error(NewHTTPError(http.StatusInternalServerError, err.Error()))
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = recorder
+
+		c.Next()
+
+		// failed requests release the key so the client is able to retry them
+		if len(c.Errors) > 0 || recorder.Status() >= http.StatusInternalServerError {
+			if err = repo.DeleteByKey(ctx, key); err != nil {
+				logger.Error(err)
+			}
+			return
+		}
+
+		idempotencyKey.ResponseCode = recorder.Status()
+		idempotencyKey.ResponseBody = recorder.body.Bytes()
+		if err = repo.UpdateResponse(ctx, idempotencyKey); err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// reclaimIdempotencyKey takes over the key of an abandoned request, losing the race to another retry is reported as a duplicate
+func reclaimIdempotencyKey(ctx context.Context, repo model.IdempotencyKeyRepository, idempotencyKey *model.IdempotencyKey, now time.Time) error {
+	reclaimed, err := repo.Reclaim(ctx, idempotencyKey, now)
+	switch {
+	case err != nil:
+		return err
+	case !reclaimed:
+		return model.ErrDuplicateEntry
+	}
+	return nil
+}
+
+func replayIdempotentResponse(c *gin.Context, stored *model.IdempotencyKey, requestHash string) {
+	switch {
+	case stored.RequestHash != requestHash:
+		c.Error(NewHTTPError(http.StatusUnprocessableEntity, "idempotency key was already used with a different request"))
+		c.Abort()
+	case !stored.IsCompleted():
+		c.Error(NewHTTPError(http.StatusConflict, "a request with this idempotency key is still being processed"))
+		c.Abort()
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(stored.ResponseCode, gin.MIMEJSON+"; charset=utf-8", stored.ResponseBody)
+		c.Abort()
+	}
+}
+
+// scopeIdempotencyKey prefixes the key with the member making the request, requests without a member share the
+// unscoped keys
+func scopeIdempotencyKey(actor, key string) string {
+	if actor == "" {
+		return key
+	}
+	return actor + ":" + key
+}
+
+func hashRequest(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
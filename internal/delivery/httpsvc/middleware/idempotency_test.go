@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotentRouter(repo model.IdempotencyKeyRepository, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	route := gin.New()
+	route.Use(CustomErrorMiddleware)
+	route.POST("/gathering/create", Idempotency(repo, time.Hour, time.Minute), handler)
+	return route
+}
+
+func newIdempotentRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/gathering/create", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := `{"name":"gathering"}`
+	requestHash := hashRequest(http.MethodPost, "/gathering/create", []byte(body))
+
+	created := func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	}
+
+	t.Run("without key", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest("", body))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("first request stores the response", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockRepo.EXPECT().UpdateResponse(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, idempotencyKey *model.IdempotencyKey) error {
+				assert.Equal(t, http.StatusCreated, idempotencyKey.ResponseCode)
+				assert.JSONEq(t, `{"id":1}`, string(idempotencyKey.ResponseBody))
+				assert.Equal(t, requestHash, idempotencyKey.RequestHash)
+				return nil
+			})
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest("key-1", body))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("retry replays the stored response", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(&model.IdempotencyKey{
+			Key:          "key-1",
+			RequestHash:  requestHash,
+			ResponseCode: http.StatusCreated,
+			ResponseBody: []byte(`{"id":1}`),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}, nil)
+
+		handler := func(c *gin.Context) {
+			t.Fatal("handler must not be called on replay")
+		}
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, handler).ServeHTTP(rec, newIdempotentRequest("key-1", body))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get(IdempotentReplayedHeader))
+		assert.JSONEq(t, `{"id":1}`, rec.Body.String())
+	})
+
+	t.Run("key reused with a different body", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(&model.IdempotencyKey{
+			Key:          "key-1",
+			RequestHash:  requestHash,
+			ResponseCode: http.StatusCreated,
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest("key-1", `{"name":"other"}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("request still in progress", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(model.ErrDuplicateEntry)
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest("key-1", body))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("request still in progress after lookup", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(&model.IdempotencyKey{
+			Key:         "key-1",
+			RequestHash: requestHash,
+			LockedUntil: time.Now().Add(time.Minute),
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest("key-1", body))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("abandoned request is reclaimed", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(&model.IdempotencyKey{
+			Key:         "key-1",
+			RequestHash: requestHash,
+			LockedUntil: time.Now().Add(-time.Second),
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}, nil)
+		mockRepo.EXPECT().Reclaim(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, idempotencyKey *model.IdempotencyKey, now time.Time) (bool, error) {
+				assert.True(t, idempotencyKey.IsLocked(now))
+				return true, nil
+			})
+		mockRepo.EXPECT().UpdateResponse(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest("key-1", body))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("abandoned request reclaimed by another retry", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(&model.IdempotencyKey{
+			Key:         "key-1",
+			RequestHash: requestHash,
+			LockedUntil: time.Now().Add(-time.Second),
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}, nil)
+		mockRepo.EXPECT().Reclaim(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest("key-1", body))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("expired key is released", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(&model.IdempotencyKey{
+			Key:          "key-1",
+			RequestHash:  "other",
+			ResponseCode: http.StatusCreated,
+			ExpiresAt:    time.Now().Add(-time.Minute),
+		}, nil)
+		mockRepo.EXPECT().DeleteByKey(gomock.Any(), "key-1").Times(1).Return(nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockRepo.EXPECT().UpdateResponse(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest("key-1", body))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed request releases the key", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "key-1").Times(1).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockRepo.EXPECT().DeleteByKey(gomock.Any(), "key-1").Times(1).Return(nil)
+
+		handler := func(c *gin.Context) {
+			c.Error(NewHTTPError(http.StatusInternalServerError, "error"))
+		}
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, handler).ServeHTTP(rec, newIdempotentRequest("key-1", body))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("keys are scoped to the member", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+		mockRepo.EXPECT().FindByKey(gomock.Any(), "322:key-1").Times(1).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, idempotencyKey *model.IdempotencyKey) error {
+				assert.Equal(t, "322:key-1", idempotencyKey.Key)
+				return nil
+			})
+		mockRepo.EXPECT().UpdateResponse(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		req := newIdempotentRequest("key-1", body)
+		req.Header.Set(ActorHeader, "322")
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		mockRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+
+		rec := httptest.NewRecorder()
+		newIdempotentRouter(mockRepo, created).ServeHTTP(rec, newIdempotentRequest(strings.Repeat("k", 201), body))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
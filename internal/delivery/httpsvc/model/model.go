@@ -24,31 +24,135 @@ type FindByIDRequest struct {
 }
 
 type CreateGatheringRequest struct {
-	Creator     int64               `json:"creator"`
 	Name        string              `json:"name"`
 	Location    string              `json:"location"`
 	ScheduledAt *time.Time          `json:"scheduled_at"`
+	EndsAt      *time.Time          `json:"ends_at"`
+	TimeZone    string              `json:"time_zone"`
+	Recurrence  string              `json:"recurrence"`
 	Type        model.GatheringType `json:"type"`
+	Capacity    int                 `json:"capacity"`
 }
 
 type UpdateGatheringRequest struct {
 	ID          int64               `json:"id"`
-	Creator     int64               `json:"creator"`
 	Name        string              `json:"name"`
 	Location    string              `json:"location"`
 	ScheduledAt *time.Time          `json:"scheduled_at"`
+	EndsAt      *time.Time          `json:"ends_at"`
+	TimeZone    string              `json:"time_zone"`
+	Recurrence  string              `json:"recurrence"`
 	Type        model.GatheringType `json:"type"`
+	Capacity    int                 `json:"capacity"`
+}
+
+type UpdateGatheringRemindersRequest struct {
+	GatheringID   int64 `json:"gathering_id"`
+	OffsetMinutes []int `json:"offset_minutes"`
+}
+
+type GatheringOccurrenceRequest struct {
+	GatheringID  int64      `json:"gathering_id"`
+	OccurrenceAt time.Time  `json:"occurrence_at"`
+	ScheduledAt  *time.Time `json:"scheduled_at"`
+	EndsAt       *time.Time `json:"ends_at"`
 }
 
 type CreateInvitationRequest struct {
-	MemberID    int64                  `json:"member_id"`
-	GatheringID int64                  `json:"gathering_id"`
-	Status      model.InvitationStatus `json:"status"`
+	MemberID      int64                  `json:"member_id"`
+	GatheringID   int64                  `json:"gathering_id"`
+	OccurrenceAt  *time.Time             `json:"occurrence_at"`
+	Status        model.InvitationStatus `json:"status"`
+	AllowedGuests int                    `json:"allowed_guests"`
+}
+
+type CreateEmailInvitationRequest struct {
+	Email        string     `json:"email"`
+	GatheringID  int64      `json:"gathering_id"`
+	OccurrenceAt *time.Time `json:"occurrence_at"`
+}
+
+type BulkInvitationRequest struct {
+	GatheringID   int64                    `json:"gathering_id"`
+	OccurrenceAt  *time.Time               `json:"occurrence_at"`
+	MemberIDs     []int64                  `json:"member_ids"`
+	Status        model.InvitationStatus   `json:"status"`
+	Mode          model.BulkInvitationMode `json:"mode"`
+	AllowedGuests int                      `json:"allowed_guests"`
+}
+
+type CreateInviteLinkRequest struct {
+	GatheringID int64      `json:"gathering_id"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	MaxUses     int        `json:"max_uses"`
+}
+
+// InviteLinkResponse holds the link with the URL members redeem it on
+type InviteLinkResponse struct {
+	*model.InviteLink
+	URL string `json:"url"`
 }
 
 type UpdateInvitationRequest struct {
-	ID          int64                  `json:"id"`
-	MemberID    int64                  `json:"member_id"`
-	GatheringID int64                  `json:"gathering_id"`
-	Status      model.InvitationStatus `json:"status"`
+	ID         int64                  `json:"id"`
+	Status     model.InvitationStatus `json:"status"`
+	Guests     int                    `json:"guests"`
+	GuestNames []string               `json:"guest_names"`
+}
+
+type UpdateAllowedGuestsRequest struct {
+	ID            int64 `json:"id"`
+	AllowedGuests int   `json:"allowed_guests"`
+}
+
+type CheckInRequest struct {
+	GatheringID int64  `json:"gathering_id"`
+	Code        string `json:"code"`
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	URL        string            `json:"url"`
+	EventTypes []model.EventType `json:"event_types"`
+	Secret     string            `json:"secret"`
+}
+
+// CreateWebhookSubscriptionResponse is the only response exposing the secret used to sign the deliveries
+type CreateWebhookSubscriptionResponse struct {
+	*model.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// CalendarFeedURLResponse holds the tokenized URL calendar apps subscribe to
+type CalendarFeedURLResponse struct {
+	URL string `json:"url"`
+}
+
+type CreateMemberGroupRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateMemberGroupRequest struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type MemberGroupMembersRequest struct {
+	GroupID   int64   `json:"group_id"`
+	MemberIDs []int64 `json:"member_ids"`
+}
+
+type GroupInvitationRequest struct {
+	GroupID       int64                    `json:"group_id"`
+	GatheringID   int64                    `json:"gathering_id"`
+	OccurrenceAt  *time.Time               `json:"occurrence_at"`
+	Status        model.InvitationStatus   `json:"status"`
+	Mode          model.BulkInvitationMode `json:"mode"`
+	AllowedGuests int                      `json:"allowed_guests"`
+}
+
+// GatheringRoleRequest names the member whose role on the gathering changes, Role is only read when adding one
+type GatheringRoleRequest struct {
+	GatheringID int64                   `json:"gathering_id"`
+	MemberID    int64                   `json:"member_id"`
+	Role        model.GatheringRoleType `json:"role"`
 }
@@ -0,0 +1,148 @@
+package httpsvc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// occurrencesWindow how far ahead the occurrences are listed when no end is given
+const occurrencesWindow = 90 * 24 * time.Hour
+
+func (s *HTTPService) FindGatheringOccurrences(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	intID, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	from := time.Now()
+	if c.Query("from") != "" {
+		from, err = time.Parse(time.RFC3339, c.Query("from"))
+		if err != nil {
+			err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+			c.Error(err)
+			return
+		}
+	}
+
+	to := from.Add(occurrencesWindow)
+	if c.Query("to") != "" {
+		to, err = time.Parse(time.RFC3339, c.Query("to"))
+		if err != nil {
+			err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+			c.Error(err)
+			return
+		}
+	}
+
+	var limit int
+	if c.Query("limit") != "" {
+		limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil {
+			err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+			c.Error(err)
+			return
+		}
+	}
+
+	res, err := s.occurrenceUsecase.FindOccurrences(ctx, int64(intID), from, to, limit)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) RescheduleGatheringOccurrence(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.GatheringOccurrenceRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.UpdateGathering) {
+		return
+	}
+
+	override := &model.GatheringOccurrenceOverride{
+		GatheringID:  body.GatheringID,
+		OccurrenceAt: body.OccurrenceAt,
+		ScheduledAt:  body.ScheduledAt,
+		EndsAt:       body.EndsAt,
+	}
+
+	res, err := s.occurrenceUsecase.RescheduleOccurrence(ctx, override)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) CancelGatheringOccurrence(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.GatheringOccurrenceRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.UpdateGathering) {
+		return
+	}
+
+	res, err := s.occurrenceUsecase.CancelOccurrence(ctx, body.GatheringID, body.OccurrenceAt)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) ResetGatheringOccurrence(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.GatheringOccurrenceRequest{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, body.GatheringID, model.UpdateGathering) {
+		return
+	}
+
+	res, err := s.occurrenceUsecase.ResetOccurrence(ctx, body.GatheringID, body.OccurrenceAt)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,55 @@
+package httpsvc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *HTTPService) FindDeadLetteredOutboxMessages(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var limit int
+	if rawLimit := c.Query("limit"); rawLimit != "" {
+		intLimit, err := strconv.Atoi(rawLimit)
+		if err != nil {
+			err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+			c.Error(err)
+			return
+		}
+		limit = intLimit
+	}
+
+	res, err := s.outboxUsecase.FindDeadLetteredMessages(ctx, limit)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) ReplayOutboxMessageByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.outboxUsecase.ReplayMessageByID(ctx, int64(intID))
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,67 @@
+package httpsvc
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/roster"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *HTTPService) ExportGatheringRoster(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	intID, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	if !s.authorizeGathering(c, int64(intID), model.InviteToGathering) {
+		return
+	}
+
+	contentType := c.NegotiateFormat(roster.CSVContentType, roster.JSONLinesContentType)
+	if contentType == "" {
+		err = middleware.NewHTTPError(http.StatusNotAcceptable, "the roster is exported as text/csv or application/x-ndjson")
+		c.Error(err)
+		return
+	}
+
+	// the headers are only sent once the roster is found, an error before that is answered as JSON
+	started := false
+	writer := roster.NewWriter(contentType, c.Writer)
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+		c.Header("Content-Type", contentType+"; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="gathering-%d-roster.%s"`, intID, roster.FileExtension(contentType)))
+		c.Status(http.StatusOK)
+	}
+
+	err = s.invitationUsecase.ExportRosterByGatheringID(ctx, int64(intID), func(entry *model.RosterEntry) error {
+		start()
+		return writer.Write(entry)
+	})
+	if err == nil {
+		start()
+		err = writer.Flush()
+	}
+
+	switch {
+	case err != nil && !started:
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+	case err != nil:
+		// part of the roster is already sent, the response can only be cut short
+		logrus.WithFields(logrus.Fields{"ctx": ctx, "gatheringID": intID}).Error(err)
+		c.Abort()
+	}
+}
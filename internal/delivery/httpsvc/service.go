@@ -1,15 +1,31 @@
 package httpsvc
 
 import (
+	"github.com/fajarachmadyusup13/gathering-app/internal/config"
 	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 )
 
 type HTTPService struct {
-	memberUsecase     model.MemberUsecase
-	gatheringUsecase  model.GatheringUsecase
-	invitationUsecase model.InvitationUsecase
+	memberUsecase        model.MemberUsecase
+	gatheringUsecase     model.GatheringUsecase
+	invitationUsecase    model.InvitationUsecase
+	webhookUsecase       model.WebhookUsecase
+	reminderUsecase      model.ReminderUsecase
+	jobUsecase           model.JobUsecase
+	outboxUsecase        model.OutboxUsecase
+	calendarUsecase      model.CalendarUsecase
+	occurrenceUsecase    model.OccurrenceUsecase
+	waitlistUsecase      model.WaitlistUsecase
+	inviteLinkUsecase    model.InviteLinkUsecase
+	attendeeUsecase      model.AttendeeUsecase
+	analyticsUsecase     model.AnalyticsUsecase
+	memberGroupUsecase   model.MemberGroupUsecase
+	gatheringRoleUsecase model.GatheringRoleUsecase
+
+	idempotencyKeyRepo model.IdempotencyKeyRepository
 }
 
 func NewHTTPService() *HTTPService {
@@ -19,24 +35,99 @@ func NewHTTPService() *HTTPService {
 func (s *HTTPService) InitRoutes(route *gin.Engine) {
 	route.Use(middleware.CustomErrorMiddleware)
 
+	idempotent := middleware.Idempotency(s.idempotencyKeyRepo, config.IdempotencyTTL(), config.IdempotencyLockLease())
+
 	member := route.Group("/member")
-	member.POST("register", s.RegisterMember)
+	member.POST("register", idempotent, s.RegisterMember)
 	member.POST("update", s.UpdateMember)
 	member.GET("findByID", s.FindMemberByID)
 	member.POST("deleteByID", s.DeleteMemberByID)
+	member.GET("calendarFeedURL", s.FindCalendarFeedURL)
+	member.POST("resetCalendarFeedURL", s.ResetCalendarFeedURL)
+	member.GET("calendarFeed", s.ExportCalendarFeed)
+	member.GET("waitlist", s.FindMemberWaitlist)
+	member.GET("stats", s.FindMemberStats)
 
 	gathering := route.Group("/gathering")
-	gathering.POST("create", s.CreateGathering)
+	gathering.POST("create", idempotent, s.CreateGathering)
 	gathering.POST("update", s.UpdateGathering)
 	gathering.GET("findByID", s.FindGatheringByID)
 	gathering.POST("deleteByID", s.DeleteGatheringByID)
+	gathering.GET("ics", s.ExportGatheringICS)
+	gathering.GET("reminders", s.FindGatheringReminders)
+	gathering.POST("updateReminders", s.UpdateGatheringReminders)
+	gathering.GET("occurrences", s.FindGatheringOccurrences)
+	gathering.POST("rescheduleOccurrence", s.RescheduleGatheringOccurrence)
+	gathering.POST("cancelOccurrence", s.CancelGatheringOccurrence)
+	gathering.POST("resetOccurrence", s.ResetGatheringOccurrence)
+	gathering.GET("waitlist", s.FindGatheringWaitlist)
+	gathering.GET("emailInvitations", s.FindGatheringEmailInvitations)
+	gathering.POST("createInviteLink", idempotent, s.CreateInviteLink)
+	gathering.GET("inviteLinks", s.FindGatheringInviteLinks)
+	gathering.POST("revokeInviteLink", s.RevokeInviteLink)
+	gathering.GET("attendance", s.FindGatheringAttendance)
+	gathering.GET("analytics", s.FindGatheringAnalytics)
+	gathering.GET("creatorAnalytics", s.FindCreatorAnalytics)
+	gathering.GET("roster", s.ExportGatheringRoster)
+	gathering.GET("roles", s.FindGatheringRoles)
+	gathering.POST("addRole", s.AddGatheringRole)
+	gathering.POST("removeRole", s.RemoveGatheringRole)
+	gathering.POST("transferOwnership", s.TransferGatheringOwnership)
 
 	invitation := route.Group("/invitation")
-	invitation.POST("invite", s.InviteMemberToGathering)
+	invitation.POST("invite", idempotent, s.InviteMemberToGathering)
+	invitation.POST("bulkInvite", idempotent, s.BulkInviteMembersToGathering)
+	invitation.POST("inviteByEmail", idempotent, s.InviteEmailToGathering)
+	invitation.POST("deleteEmailInvitationByID", s.DeleteEmailInvitationByID)
 	invitation.GET("findByID", s.FindInvitationByID)
 	invitation.POST("update", s.UpdateInvitation)
+	invitation.POST("updateAllowedGuests", s.UpdateInvitationAllowedGuests)
 	invitation.POST("deleteByID", s.DeleteInvitationByID)
+	invitation.POST("redeemLink", idempotent, s.RedeemInviteLink)
+	invitation.POST("inviteGroup", idempotent, s.InviteGroupToGathering)
+
+	group := route.Group("/group")
+	group.POST("create", idempotent, s.CreateMemberGroup)
+	group.POST("update", s.UpdateMemberGroup)
+	group.GET("findByID", s.FindMemberGroupByID)
+	group.GET("findByOwner", s.FindMemberGroupsByOwner)
+	group.POST("deleteByID", s.DeleteMemberGroupByID)
+	group.GET("members", s.FindMemberGroupMembers)
+	group.POST("addMembers", s.AddMemberGroupMembers)
+	group.POST("removeMembers", s.RemoveMemberGroupMembers)
+
+	attendee := route.Group("/attendee")
+	attendee.GET("checkInQRCode", s.ExportCheckInQRCode)
+	attendee.POST("checkIn", s.CheckInAttendee)
+	attendee.POST("undoCheckIn", s.UndoAttendeeCheckIn)
 
+	webhook := route.Group("/webhook")
+	webhook.POST("create", s.CreateWebhookSubscription)
+	webhook.GET("findByID", s.FindWebhookSubscriptionByID)
+	webhook.GET("findByOwner", s.FindWebhookSubscriptionsByOwner)
+	webhook.POST("deleteByID", s.DeleteWebhookSubscriptionByID)
+	webhook.GET("deliveries", s.FindWebhookDeliveries)
+	webhook.POST("replayDelivery", s.ReplayWebhookDelivery)
+
+	// the admin routes are not served at all without an admin token, set it through the SVC_ADMIN_TOKEN env
+	adminToken := config.AdminToken()
+	if adminToken == "" {
+		logrus.Warn("admin.token is not set, the admin routes are disabled")
+		return
+	}
+
+	admin := route.Group("/admin", middleware.AdminAuth(adminToken))
+	admin.GET("member/deleted", s.FindDeletedMembers)
+	admin.POST("member/restore", s.RestoreMemberByID)
+	admin.POST("member/import", s.ImportMembers)
+	admin.GET("gathering/deleted", s.FindDeletedGatherings)
+	admin.POST("gathering/restore", s.RestoreGatheringByID)
+	admin.GET("invitation/deleted", s.FindDeletedInvitations)
+	admin.POST("invitation/restore", s.RestoreInvitationByID)
+	admin.GET("job/runs", s.FindJobRuns)
+	admin.POST("job/retry", s.RetryJobByID)
+	admin.GET("outbox/deadLettered", s.FindDeadLetteredOutboxMessages)
+	admin.POST("outbox/replay", s.ReplayOutboxMessageByID)
 }
 
 func (s *HTTPService) RegisterMemberUsecase(m model.MemberUsecase) {
@@ -50,3 +141,55 @@ func (s *HTTPService) RegisterGatheringUsecase(g model.GatheringUsecase) {
 func (s *HTTPService) RegisterInvitationUsecase(i model.InvitationUsecase) {
 	s.invitationUsecase = i
 }
+
+func (s *HTTPService) RegisterWebhookUsecase(w model.WebhookUsecase) {
+	s.webhookUsecase = w
+}
+
+func (s *HTTPService) RegisterReminderUsecase(r model.ReminderUsecase) {
+	s.reminderUsecase = r
+}
+
+func (s *HTTPService) RegisterJobUsecase(j model.JobUsecase) {
+	s.jobUsecase = j
+}
+
+func (s *HTTPService) RegisterOutboxUsecase(o model.OutboxUsecase) {
+	s.outboxUsecase = o
+}
+
+func (s *HTTPService) RegisterCalendarUsecase(c model.CalendarUsecase) {
+	s.calendarUsecase = c
+}
+
+func (s *HTTPService) RegisterOccurrenceUsecase(o model.OccurrenceUsecase) {
+	s.occurrenceUsecase = o
+}
+
+func (s *HTTPService) RegisterWaitlistUsecase(w model.WaitlistUsecase) {
+	s.waitlistUsecase = w
+}
+
+func (s *HTTPService) RegisterInviteLinkUsecase(i model.InviteLinkUsecase) {
+	s.inviteLinkUsecase = i
+}
+
+func (s *HTTPService) RegisterAttendeeUsecase(a model.AttendeeUsecase) {
+	s.attendeeUsecase = a
+}
+
+func (s *HTTPService) RegisterAnalyticsUsecase(a model.AnalyticsUsecase) {
+	s.analyticsUsecase = a
+}
+
+func (s *HTTPService) RegisterMemberGroupUsecase(m model.MemberGroupUsecase) {
+	s.memberGroupUsecase = m
+}
+
+func (s *HTTPService) RegisterGatheringRoleUsecase(g model.GatheringRoleUsecase) {
+	s.gatheringRoleUsecase = g
+}
+
+func (s *HTTPService) RegisterIdempotencyKeyRepository(i model.IdempotencyKeyRepository) {
+	s.idempotencyKeyRepo = i
+}
@@ -0,0 +1,175 @@
+package httpsvc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/middleware"
+	httpsvcModel "github.com/fajarachmadyusup13/gathering-app/internal/delivery/httpsvc/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *HTTPService) CreateWebhookSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	body := httpsvcModel.CreateWebhookSubscriptionRequest{}
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&body)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	subscription := &model.WebhookSubscription{
+		ID:         model.GenerateID(),
+		Owner:      owner,
+		URL:        body.URL,
+		EventTypes: body.EventTypes,
+		Secret:     body.Secret,
+	}
+
+	err = s.webhookUsecase.CreateSubscription(ctx, subscription)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, httpsvcModel.CreateWebhookSubscriptionResponse{
+		WebhookSubscription: subscription,
+		Secret:              subscription.Secret,
+	})
+}
+
+func (s *HTTPService) FindWebhookSubscriptionByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.webhookUsecase.FindSubscriptionByID(ctx, int64(intID), owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindWebhookSubscriptionsByOwner(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	res, err := s.webhookUsecase.FindSubscriptionsByOwner(ctx, owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) DeleteWebhookSubscriptionByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.webhookUsecase.DeleteSubscriptionByID(ctx, int64(intID), owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) FindWebhookDeliveries(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	subscriptionID := c.Query("subscriptionID")
+
+	intSubscriptionID, err := strconv.Atoi(subscriptionID)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.webhookUsecase.FindDeliveriesBySubscriptionID(ctx, int64(intSubscriptionID), owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *HTTPService) ReplayWebhookDelivery(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	owner, ok := actorFromRequest(c)
+	if !ok {
+		return
+	}
+
+	id := c.Query("id")
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		err = middleware.NewHTTPError(http.StatusBadRequest, err.Error())
+		c.Error(err)
+		return
+	}
+
+	res, err := s.webhookUsecase.ReplayDeliveryByID(ctx, int64(intID), owner)
+	if err != nil {
+		err = middleware.NewHTTPError(httpStatusFromError(err), err.Error())
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// Bus is an in-process publisher delivering events synchronously to their subscribers
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[model.EventType][]model.EventHandler
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[model.EventType][]model.EventHandler),
+	}
+}
+
+// Subscribe registers handler for every event of the given type
+func (b *Bus) Subscribe(eventType model.EventType, handler model.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish runs every subscriber of the events, a failing subscriber does not prevent the others from running
+func (b *Bus) Publish(ctx context.Context, events ...*model.Event) error {
+	var errs []error
+	for _, e := range events {
+		b.mu.RLock()
+		handlers := b.handlers[e.Type]
+		b.mu.RUnlock()
+
+		for _, handler := range handlers {
+			if err := handler(ctx, e); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"ctx":   ctx,
+					"event": e,
+				}).Error(err)
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusPublish(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("delivers to the subscribers of the event type", func(t *testing.T) {
+		bus := NewBus()
+
+		var received []*model.Event
+		bus.Subscribe(model.GatheringCancelled, func(_ context.Context, e *model.Event) error {
+			received = append(received, e)
+			return nil
+		})
+		bus.Subscribe(model.EventType("other"), func(_ context.Context, _ *model.Event) error {
+			t.Fatal("subscriber of another type must not be called")
+			return nil
+		})
+
+		event, err := model.NewEvent(model.GatheringCancelled, &model.GatheringCancelledPayload{MemberIDs: []int64{1}})
+		assert.NoError(t, err)
+
+		err = bus.Publish(ctx, event)
+		assert.NoError(t, err)
+		assert.Len(t, received, 1)
+	})
+
+	t.Run("failing subscriber does not stop the others", func(t *testing.T) {
+		bus := NewBus()
+
+		called := false
+		bus.Subscribe(model.GatheringCancelled, func(_ context.Context, _ *model.Event) error {
+			return errors.New("error")
+		})
+		bus.Subscribe(model.GatheringCancelled, func(_ context.Context, _ *model.Event) error {
+			called = true
+			return nil
+		})
+
+		event, err := model.NewEvent(model.GatheringCancelled, &model.GatheringCancelledPayload{})
+		assert.NoError(t, err)
+
+		err = bus.Publish(ctx, event)
+		assert.Error(t, err)
+		assert.True(t, called)
+	})
+}
@@ -0,0 +1,28 @@
+package event
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+// OutboxPublisher stores the events in the outbox instead of delivering them,
+// publishing inside a transaction commits the events together with the change they describe
+type OutboxPublisher struct {
+	outboxRepo model.OutboxRepository
+}
+
+func NewOutboxPublisher(outboxRepo model.OutboxRepository) *OutboxPublisher {
+	return &OutboxPublisher{
+		outboxRepo: outboxRepo,
+	}
+}
+
+func (p *OutboxPublisher) Publish(ctx context.Context, events ...*model.Event) error {
+	messages := make([]*model.OutboxMessage, 0, len(events))
+	for _, e := range events {
+		messages = append(messages, model.NewOutboxMessage(e))
+	}
+
+	return p.outboxRepo.Create(ctx, messages)
+}
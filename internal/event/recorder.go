@@ -0,0 +1,54 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+// Recorder is a publisher keeping every published event in memory, it lets tests assert which events were emitted
+type Recorder struct {
+	mu     sync.Mutex
+	events []*model.Event
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Publish(ctx context.Context, events ...*model.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, events...)
+	return nil
+}
+
+// Events returns the published events in publishing order
+func (r *Recorder) Events() []*model.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*model.Event(nil), r.events...)
+}
+
+// Types returns the type of every published event in publishing order
+func (r *Recorder) Types() []model.EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	types := make([]model.EventType, 0, len(r.events))
+	for _, e := range r.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+// Reset forgets the published events
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = nil
+}
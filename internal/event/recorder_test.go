@@ -0,0 +1,29 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder()
+
+	created, err := model.NewEvent(model.GatheringCreated, &model.Gathering{ID: 1})
+	assert.NoError(t, err)
+	sent, err := model.NewEvent(model.InvitationSent, &model.Invitation{ID: 2})
+	assert.NoError(t, err)
+
+	assert.NoError(t, recorder.Publish(context.TODO(), created))
+	assert.NoError(t, recorder.Publish(context.TODO(), sent))
+	assert.Equal(t, []model.EventType{model.GatheringCreated, model.InvitationSent}, recorder.Types())
+
+	var invitation model.Invitation
+	assert.NoError(t, recorder.Events()[1].DecodePayload(&invitation))
+	assert.Equal(t, int64(2), invitation.ID)
+
+	recorder.Reset()
+	assert.Empty(t, recorder.Events())
+}
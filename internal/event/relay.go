@@ -0,0 +1,107 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/jpillora/backoff"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// relayLease how long a claimed message is hidden from the other relays while it is dispatched
+	relayLease = 1 * time.Minute
+)
+
+// Relay delivers the outbox messages to the subscribers. A message is retried with exponential backoff until every
+// subscriber succeeds, so subscribers must tolerate receiving the same event more than once.
+type Relay struct {
+	outboxRepo  model.OutboxRepository
+	publisher   model.EventPublisher
+	batchSize   int
+	maxAttempts int
+	backoff     *backoff.Backoff
+}
+
+func NewRelay(outboxRepo model.OutboxRepository, publisher model.EventPublisher, batchSize int, maxAttempts int) *Relay {
+	return &Relay{
+		outboxRepo:  outboxRepo,
+		publisher:   publisher,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		backoff: &backoff.Backoff{
+			Factor: 2,
+			Jitter: true,
+			Min:    1 * time.Second,
+			Max:    10 * time.Minute,
+		},
+	}
+}
+
+// Run relays the outbox until ctx is done, it only sleeps for pollInterval once the outbox is drained
+func (r *Relay) Run(ctx context.Context, pollInterval time.Duration) {
+	for {
+		relayed, err := r.RelayBatch(ctx)
+		if err != nil || relayed < r.batchSize {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// RelayBatch dispatches one batch of due messages and returns how many were claimed
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	messages, err := r.outboxRepo.ClaimDue(ctx, time.Now(), relayLease, r.batchSize)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	for _, message := range messages {
+		r.dispatch(ctx, message)
+	}
+
+	return len(messages), nil
+}
+
+func (r *Relay) dispatch(ctx context.Context, message *model.OutboxMessage) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"message": message,
+	})
+
+	err := r.publisher.Publish(ctx, message.Event())
+	if err == nil {
+		if err = r.outboxRepo.MarkDelivered(ctx, message.ID, time.Now()); err != nil {
+			logger.Error(err)
+		}
+		return
+	}
+
+	logger.Error(err)
+	if message.Attempts+1 >= r.maxAttempts {
+		logger.Error("outbox message reached the maximum attempts, it is dead-lettered until it is replayed")
+		if err = r.outboxRepo.MarkDeadLettered(ctx, message.ID, err.Error(), time.Now()); err != nil {
+			logger.Error(err)
+		}
+		return
+	}
+
+	nextAttemptAt := time.Now().Add(r.backoff.ForAttempt(float64(message.Attempts)))
+	if err = r.outboxRepo.MarkFailed(ctx, message.ID, err.Error(), nextAttemptAt); err != nil {
+		logger.Error(err)
+	}
+}
@@ -0,0 +1,117 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelayBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	e, err := model.NewEvent(model.InvitationSent, &model.Invitation{ID: 1})
+	assert.NoError(t, err)
+	message := model.NewOutboxMessage(e)
+
+	t.Run("delivered", func(t *testing.T) {
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().ClaimDue(ctx, gomock.Any(), relayLease, 10).Times(1).
+			Return([]*model.OutboxMessage{message}, nil)
+		mockOutboxRepo.EXPECT().MarkDelivered(ctx, message.ID, gomock.Any()).Times(1).Return(nil)
+
+		recorder := NewRecorder()
+		relay := NewRelay(mockOutboxRepo, recorder, 10, 3)
+
+		relayed, err := relay.RelayBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, relayed)
+		assert.Equal(t, []model.EventType{model.InvitationSent}, recorder.Types())
+		assert.Equal(t, e.ID, recorder.Events()[0].ID)
+	})
+
+	t.Run("failed subscriber schedules a retry", func(t *testing.T) {
+		bus := NewBus()
+		bus.Subscribe(model.InvitationSent, func(_ context.Context, _ *model.Event) error {
+			return errors.New("error")
+		})
+
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().ClaimDue(ctx, gomock.Any(), relayLease, 10).Times(1).
+			Return([]*model.OutboxMessage{message}, nil)
+		mockOutboxRepo.EXPECT().MarkFailed(ctx, message.ID, "error", gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ int64, _ string, nextAttemptAt time.Time) error {
+				assert.True(t, nextAttemptAt.After(time.Now()))
+				return nil
+			})
+
+		relay := NewRelay(mockOutboxRepo, bus, 10, 3)
+
+		relayed, err := relay.RelayBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, relayed)
+	})
+
+	t.Run("last failed attempt dead-letters the message", func(t *testing.T) {
+		bus := NewBus()
+		bus.Subscribe(model.InvitationSent, func(_ context.Context, _ *model.Event) error {
+			return errors.New("error")
+		})
+
+		exhausted := *message
+		exhausted.Attempts = 2
+
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().ClaimDue(ctx, gomock.Any(), relayLease, 10).Times(1).
+			Return([]*model.OutboxMessage{&exhausted}, nil)
+		mockOutboxRepo.EXPECT().MarkDeadLettered(ctx, message.ID, "error", gomock.Any()).Times(1).Return(nil)
+		mockOutboxRepo.EXPECT().MarkFailed(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		relay := NewRelay(mockOutboxRepo, bus, 10, 3)
+
+		relayed, err := relay.RelayBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, relayed)
+	})
+
+	t.Run("error claim", func(t *testing.T) {
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().ClaimDue(ctx, gomock.Any(), relayLease, 10).Times(1).
+			Return(nil, errors.New("error"))
+
+		relay := NewRelay(mockOutboxRepo, NewRecorder(), 10, 3)
+
+		relayed, err := relay.RelayBatch(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, 0, relayed)
+	})
+}
+
+func TestOutboxPublisher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	e, err := model.NewEvent(model.GatheringCreated, &model.Gathering{ID: 1})
+	assert.NoError(t, err)
+
+	mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+	mockOutboxRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, messages []*model.OutboxMessage) error {
+			assert.Len(t, messages, 1)
+			assert.Equal(t, e.ID, messages[0].ID)
+			assert.Equal(t, model.GatheringCreated, messages[0].EventType)
+			assert.Equal(t, e.OccurredAt, messages[0].NextAttemptAt)
+			return nil
+		})
+
+	err = NewOutboxPublisher(mockOutboxRepo).Publish(ctx, e)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,19 @@
+package event
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// LogEvent writes the event to the log, it is meant as an audit trail of the domain changes
+func LogEvent(ctx context.Context, e *model.Event) error {
+	logrus.WithFields(logrus.Fields{
+		"ctx":        ctx,
+		"eventID":    e.ID,
+		"eventType":  e.Type,
+		"occurredAt": e.OccurredAt,
+	}).Info(string(e.Payload))
+	return nil
+}
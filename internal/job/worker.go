@@ -0,0 +1,211 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/jpillora/backoff"
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+)
+
+// schedule a recurring job, next is the tick its next run is enqueued for
+type schedule struct {
+	name string
+	cron cron.Schedule
+	next time.Time
+}
+
+// Worker runs the jobs stored in the job queue. A job is leased while it runs so several workers can share the queue,
+// it is retried with exponential backoff and marked dead once it failed max_attempts times.
+// A job that outlives its lease can be run again by another worker, so handlers must be idempotent.
+type Worker struct {
+	jobRepo     model.JobRepository
+	handlers    map[string]model.JobHandler
+	schedules   []*schedule
+	lease       time.Duration
+	maxAttempts int
+	batchSize   int
+	backoff     *backoff.Backoff
+}
+
+func NewWorker(jobRepo model.JobRepository, lease time.Duration, maxAttempts int, batchSize int) *Worker {
+	return &Worker{
+		jobRepo:     jobRepo,
+		handlers:    map[string]model.JobHandler{},
+		lease:       lease,
+		maxAttempts: maxAttempts,
+		batchSize:   batchSize,
+		backoff: &backoff.Backoff{
+			Factor: 2,
+			Jitter: true,
+			Min:    10 * time.Second,
+			Max:    1 * time.Hour,
+		},
+	}
+}
+
+// Register sets the handler running the jobs of the given name
+func (w *Worker) Register(name string, handler model.JobHandler) {
+	w.handlers[name] = handler
+}
+
+// Schedule enqueues a run of the named job on every tick of the standard 5 field cron spec,
+// ticks missed while no worker was running are skipped
+func (w *Worker) Schedule(name string, spec string) error {
+	if _, ok := w.handlers[name]; !ok {
+		return fmt.Errorf("no handler registered for job %q", name)
+	}
+
+	c, err := cron.ParseStandard(spec)
+	if err != nil {
+		return err
+	}
+
+	w.schedules = append(w.schedules, &schedule{
+		name: name,
+		cron: c,
+		next: c.Next(time.Now()),
+	})
+	return nil
+}
+
+// Run enqueues the scheduled jobs and runs the due jobs until ctx is done, it only sleeps for pollInterval once
+// there is no due job left
+func (w *Worker) Run(ctx context.Context, pollInterval time.Duration) {
+	for {
+		w.EnqueueScheduled(ctx, time.Now())
+
+		ran, err := w.RunBatch(ctx)
+		if err != nil || ran < w.batchSize {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// EnqueueScheduled enqueues a run of every recurring job whose tick has come. The run of a tick has a unique key,
+// so it is enqueued once however many workers are running
+func (w *Worker) EnqueueScheduled(ctx context.Context, now time.Time) {
+	for _, s := range w.schedules {
+		if s.next.After(now) {
+			continue
+		}
+
+		logger := logrus.WithFields(logrus.Fields{
+			"ctx":  ctx,
+			"job":  s.name,
+			"tick": s.next,
+		})
+
+		job, err := model.NewJob(s.name, nil, s.next, w.maxAttempts)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+		uniqueKey := fmt.Sprintf("%s@%s", s.name, s.next.UTC().Format(time.RFC3339))
+		job.UniqueKey = &uniqueKey
+
+		if err = w.jobRepo.Create(ctx, job); err != nil {
+			// the tick is kept so the run is enqueued on the next poll
+			logger.Error(err)
+			continue
+		}
+
+		s.next = s.cron.Next(now)
+	}
+}
+
+// RunBatch runs one batch of due jobs and returns how many were claimed
+func (w *Worker) RunBatch(ctx context.Context) (int, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	jobs, err := w.jobRepo.ClaimDue(ctx, time.Now(), w.lease, w.batchSize)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		w.run(ctx, job)
+	}
+
+	return len(jobs), nil
+}
+
+func (w *Worker) run(ctx context.Context, job *model.Job) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+		"job": job,
+	})
+
+	// the claim this run holds, the outcome is only saved while the job is still held by it
+	claimedAttempts := job.Attempts
+
+	var err error
+	handler, ok := w.handlers[job.Name]
+	switch {
+	case !ok:
+		err = fmt.Errorf("no handler registered for job %q", job.Name)
+		// retrying can't help, a worker that knows the job has to be deployed and the job retried
+		job.Attempts = job.MaxAttempts
+	case job.Attempts > job.MaxAttempts:
+		// the job outlived its lease on its last attempt
+		err = fmt.Errorf("job lease expired on its last attempt")
+	default:
+		err = w.call(ctx, handler, job)
+	}
+
+	now := time.Now()
+	job.LeaseUntil = nil
+	switch {
+	case err == nil:
+		job.Status = model.JobSucceeded
+		job.LastError = ""
+		job.FinishedAt = &now
+	case job.Attempts >= job.MaxAttempts:
+		logger.Error(err)
+		job.Status = model.JobDead
+		job.LastError = err.Error()
+		job.FinishedAt = &now
+	default:
+		logger.Error(err)
+		job.Status = model.JobPending
+		job.LastError = err.Error()
+		job.RunAt = now.Add(w.backoff.ForAttempt(float64(job.Attempts - 1)))
+	}
+
+	saved, err := w.jobRepo.UpdateClaimed(ctx, job, claimedAttempts)
+	switch {
+	case err != nil:
+		logger.Error(err)
+	case !saved:
+		logger.Warn("job lease expired while it ran and another worker claimed it, its outcome is dropped")
+	}
+}
+
+// call runs the handler within the lease of the job, a panic fails the job instead of stopping the worker
+func (w *Worker) call(ctx context.Context, handler model.JobHandler, job *model.Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, w.lease)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
@@ -0,0 +1,187 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	newClaimedJob := func(name string, attempts int) *model.Job {
+		return &model.Job{ID: 1, Name: name, Status: model.JobRunning, Attempts: attempts, MaxAttempts: 3}
+	}
+
+	t.Run("succeeded", func(t *testing.T) {
+		job := newClaimedJob("cleanup", 1)
+
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Times(1).Return([]*model.Job{job}, nil)
+		mockJobRepo.EXPECT().UpdateClaimed(ctx, job, job.Attempts).Times(1).Return(true, nil)
+
+		worker := NewWorker(mockJobRepo, time.Minute, 3, 10)
+		ran := false
+		worker.Register("cleanup", func(_ context.Context, _ *model.Job) error {
+			ran = true
+			return nil
+		})
+
+		claimed, err := worker.RunBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+		assert.True(t, ran)
+		assert.Equal(t, model.JobSucceeded, job.Status)
+		assert.NotNil(t, job.FinishedAt)
+		assert.Nil(t, job.LeaseUntil)
+	})
+
+	t.Run("failed run is retried later", func(t *testing.T) {
+		job := newClaimedJob("cleanup", 1)
+
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Times(1).Return([]*model.Job{job}, nil)
+		mockJobRepo.EXPECT().UpdateClaimed(ctx, job, job.Attempts).Times(1).Return(true, nil)
+
+		worker := NewWorker(mockJobRepo, time.Minute, 3, 10)
+		worker.Register("cleanup", func(_ context.Context, _ *model.Job) error {
+			return errors.New("error")
+		})
+
+		_, err := worker.RunBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, model.JobPending, job.Status)
+		assert.Equal(t, "error", job.LastError)
+		assert.True(t, job.RunAt.After(time.Now()))
+	})
+
+	t.Run("last failed attempt is dead-lettered", func(t *testing.T) {
+		job := newClaimedJob("cleanup", 3)
+
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Times(1).Return([]*model.Job{job}, nil)
+		mockJobRepo.EXPECT().UpdateClaimed(ctx, job, job.Attempts).Times(1).Return(true, nil)
+
+		worker := NewWorker(mockJobRepo, time.Minute, 3, 10)
+		worker.Register("cleanup", func(_ context.Context, _ *model.Job) error {
+			panic("boom")
+		})
+
+		_, err := worker.RunBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, model.JobDead, job.Status)
+		assert.Equal(t, "job panicked: boom", job.LastError)
+	})
+
+	t.Run("unknown job is dead-lettered", func(t *testing.T) {
+		job := newClaimedJob("unknown", 1)
+
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Times(1).Return([]*model.Job{job}, nil)
+		mockJobRepo.EXPECT().UpdateClaimed(ctx, job, job.Attempts).Times(1).Return(true, nil)
+
+		worker := NewWorker(mockJobRepo, time.Minute, 3, 10)
+
+		_, err := worker.RunBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, model.JobDead, job.Status)
+	})
+
+	t.Run("lease expired on the last attempt", func(t *testing.T) {
+		job := newClaimedJob("cleanup", 4)
+
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Times(1).Return([]*model.Job{job}, nil)
+		mockJobRepo.EXPECT().UpdateClaimed(ctx, job, job.Attempts).Times(1).Return(true, nil)
+
+		worker := NewWorker(mockJobRepo, time.Minute, 3, 10)
+		worker.Register("cleanup", func(_ context.Context, _ *model.Job) error {
+			t.Fatal("the job must not run again")
+			return nil
+		})
+
+		_, err := worker.RunBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, model.JobDead, job.Status)
+	})
+}
+
+func TestRunBatchLostClaim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	job := &model.Job{ID: 1, Name: "cleanup", Status: model.JobRunning, Attempts: 1, MaxAttempts: 3}
+
+	mockJobRepo := mock.NewMockJobRepository(ctrl)
+	mockJobRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Times(1).Return([]*model.Job{job}, nil)
+	mockJobRepo.EXPECT().UpdateClaimed(ctx, job, 1).Times(1).Return(false, nil)
+
+	worker := NewWorker(mockJobRepo, time.Minute, 3, 10)
+	worker.Register("cleanup", func(_ context.Context, _ *model.Job) error {
+		return nil
+	})
+
+	claimed, err := worker.RunBatch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+}
+
+func TestEnqueueScheduled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("one run per tick", func(t *testing.T) {
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, job *model.Job) error {
+				assert.Equal(t, "cleanup", job.Name)
+				assert.Equal(t, model.JobPending, job.Status)
+				assert.Equal(t, 3, job.MaxAttempts)
+				assert.Equal(t, "cleanup@"+job.RunAt.UTC().Format(time.RFC3339), *job.UniqueKey)
+				return nil
+			})
+
+		worker := NewWorker(mockJobRepo, time.Minute, 3, 10)
+		worker.Register("cleanup", func(_ context.Context, _ *model.Job) error { return nil })
+		assert.NoError(t, worker.Schedule("cleanup", "* * * * *"))
+
+		// the next tick is at most a minute away, the second call is still before the tick after it
+		now := time.Now().Add(time.Minute)
+		worker.EnqueueScheduled(ctx, now)
+		worker.EnqueueScheduled(ctx, now)
+	})
+
+	t.Run("failed enqueue is retried on the next poll", func(t *testing.T) {
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		gomock.InOrder(
+			mockJobRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(errors.New("error")),
+			mockJobRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil),
+		)
+
+		worker := NewWorker(mockJobRepo, time.Minute, 3, 10)
+		worker.Register("cleanup", func(_ context.Context, _ *model.Job) error { return nil })
+		assert.NoError(t, worker.Schedule("cleanup", "* * * * *"))
+
+		now := time.Now().Add(time.Minute)
+		worker.EnqueueScheduled(ctx, now)
+		worker.EnqueueScheduled(ctx, now)
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		worker := NewWorker(nil, time.Minute, 3, 10)
+		worker.Register("cleanup", func(_ context.Context, _ *model.Job) error { return nil })
+
+		assert.Error(t, worker.Schedule("cleanup", "every minute"))
+		assert.Error(t, worker.Schedule("unknown", "* * * * *"))
+	})
+}
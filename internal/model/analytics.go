@@ -0,0 +1,75 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultAnalyticsWindow is how far back the gathering analytics look when no window is given
+const DefaultAnalyticsWindow = 90 * 24 * time.Hour
+
+type (
+	// AnalyticsScope selects the gatherings the analytics aggregate over, either one gathering or every gathering of
+	// a creator, and the window [From, To) of the invitations sent
+	AnalyticsScope struct {
+		GatheringID int64
+		Creator     int64
+		From        time.Time
+		To          time.Time
+	}
+
+	// InvitationFunnel counts the invitations sent within the window by their status
+	InvitationFunnel struct {
+		Sent     int64 `json:"sent"`
+		Pending  int64 `json:"pending"`
+		Accepted int64 `json:"accepted"`
+		Declined int64 `json:"declined"`
+		Expired  int64 `json:"expired"`
+		// AverageAcceptanceSeconds is how long the members took to accept a pending invitation on average, nil when
+		// none was accepted
+		AverageAcceptanceSeconds *float64 `json:"average_acceptance_seconds"`
+	}
+
+	// SeatUsage sums the seats taken by the attendees and their guests over the capacity of the gatherings
+	SeatUsage struct {
+		Taken    int64 `json:"taken"`
+		Capacity int64 `json:"capacity"`
+	}
+
+	// DailyAnalytics counts the invitations sent, the invitations accepted and the attendees checked in on a day
+	DailyAnalytics struct {
+		// Day is the UTC date formatted as 2006-01-02
+		Day       string `json:"day"`
+		Sent      int64  `json:"sent"`
+		Accepted  int64  `json:"accepted"`
+		CheckedIn int64  `json:"checked_in"`
+	}
+
+	GatheringAnalytics struct {
+		GatheringID int64             `json:"gathering_id,omitempty"`
+		Creator     int64             `json:"creator,omitempty"`
+		From        time.Time         `json:"from"`
+		To          time.Time         `json:"to"`
+		Funnel      *InvitationFunnel `json:"funnel"`
+		Seats       *SeatUsage        `json:"seats"`
+		// FillRate is the share of the capacity taken, nil when the gatherings have no capacity
+		FillRate *float64          `json:"fill_rate"`
+		Daily    []*DailyAnalytics `json:"daily"`
+	}
+
+	AnalyticsRepository interface {
+		CountInvitationFunnel(ctx context.Context, scope *AnalyticsScope) (*InvitationFunnel, error)
+		CountSeatUsage(ctx context.Context, scope *AnalyticsScope) (*SeatUsage, error)
+		CountDaily(ctx context.Context, scope *AnalyticsScope) ([]*DailyAnalytics, error)
+	}
+
+	AnalyticsUsecase interface {
+		FindGatheringAnalytics(ctx context.Context, gatheringID int64, from, to time.Time) (*GatheringAnalytics, error)
+		FindCreatorAnalytics(ctx context.Context, creator int64, from, to time.Time) (*GatheringAnalytics, error)
+	}
+)
+
+// FillRate is the share of the capacity taken, nil when there is no capacity
+func (u *SeatUsage) FillRate() *float64 {
+	return rate(u.Taken, u.Capacity)
+}
@@ -9,17 +9,53 @@ import (
 
 type (
 	Attendee struct {
-		MemberID    int64
-		GatheringID int64
+		MemberID    int64 `gorm:"primaryKey;autoIncrement:false"`
+		GatheringID int64 `gorm:"primaryKey;autoIncrement:false"`
+		// OccurrenceAt is the only occurrence of a recurring gathering the member attends, nil for the whole series
+		OccurrenceAt *time.Time `json:"occurrence_at"`
+		// Guests is how many guests come with the member, the attendee takes 1 + Guests seats
+		Guests int `json:"guests"`
+		// CheckInCode is encoded in the QR code the member shows at the gathering, it is never exposed in the JSON
+		CheckInCode *string `json:"-"`
+		// CheckedInAt is when the member showed up at the gathering, nil until they check in
+		CheckedInAt *time.Time `json:"checked_in_at"`
+		// CancelledAt is when the gathering was cancelled while the member attended it, the row is deleted with it
+		CancelledAt *time.Time     `json:"cancelled_at"`
 		CreatedAt   time.Time      `json:"created_at"`
 		UpdatedAt   time.Time      `json:"updated_at"`
 		DeletedAt   gorm.DeletedAt `json:"deleted_at"`
 	}
 
+	// Attendance counts the people expected at a gathering and those who checked in, guests included
+	Attendance struct {
+		GatheringID int64 `json:"gathering_id"`
+		Expected    int64 `json:"expected"`
+		CheckedIn   int64 `json:"checked_in"`
+	}
+
 	AttendeeRepository interface {
 		Create(ctx context.Context, attendee *Attendee) error
+		Revive(ctx context.Context, attendee *Attendee) error
 		FindByMemberID(ctx context.Context, memberID int64) ([]*Attendee, error)
+		FindCancelledByMemberID(ctx context.Context, memberID int64) ([]*Attendee, error)
 		FindByGatheringID(ctx context.Context, gatheringID int64) ([]*Attendee, error)
+		CountSeatsByGatheringID(ctx context.Context, gatheringID int64) (int64, error)
+		UpdateGuestsByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64, guests int) error
+		FindByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64) (*Attendee, error)
+		FindByCheckInCode(ctx context.Context, code string) (*Attendee, error)
+		UpdateCheckInCode(ctx context.Context, memberID int64, gatheringID int64, code string) error
+		UpdateCheckedInAt(ctx context.Context, memberID int64, gatheringID int64, checkedInAt *time.Time) (bool, error)
+		FindAttendanceByGatheringID(ctx context.Context, gatheringID int64) (*Attendance, error)
 		DeleteByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64) (*Attendee, error)
+		DeleteByMemberID(ctx context.Context, memberID int64) error
+		DeleteByGatheringID(ctx context.Context, gatheringID int64) error
+		RestoreByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64) (*Attendee, error)
+	}
+
+	AttendeeUsecase interface {
+		FindCheckInCode(ctx context.Context, memberID int64, gatheringID int64) (string, error)
+		CheckIn(ctx context.Context, gatheringID int64, code string) (*Attendee, error)
+		UndoCheckIn(ctx context.Context, gatheringID int64, code string) (*Attendee, error)
+		FindAttendanceByGatheringID(ctx context.Context, gatheringID int64) (*Attendance, error)
 	}
 )
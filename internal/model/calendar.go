@@ -0,0 +1,18 @@
+package model
+
+import "context"
+
+type (
+	// CalendarFeed lists the occurrences of the gatherings in a calendar, cancelled ones included
+	CalendarFeed struct {
+		Name        string
+		Occurrences []*Occurrence
+	}
+
+	CalendarUsecase interface {
+		FindCalendarToken(ctx context.Context, memberID int64) (string, error)
+		ResetCalendarToken(ctx context.Context, memberID int64) (string, error)
+		FindCalendarFeed(ctx context.Context, token string) (*CalendarFeed, error)
+		FindGatheringCalendar(ctx context.Context, gatheringID int64) (*CalendarFeed, error)
+	}
+)
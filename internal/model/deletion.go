@@ -0,0 +1,10 @@
+package model
+
+type DeletionPolicy string
+
+const (
+	// CascadeDeletion deletes the invitations and attendees of a deleted member or gathering
+	CascadeDeletion = DeletionPolicy("cascade")
+	// RestrictDeletion refuses to delete a member or gathering that still has live invitations
+	RestrictDeletion = DeletionPolicy("restrict")
+)
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+type (
+	// EmailInvitation invites someone who is not a member yet, it is turned into an Invitation of the member
+	// registering with its email
+	EmailInvitation struct {
+		ID          int64  `json:"id"`
+		GatheringID int64  `json:"gathering_id"`
+		Email       string `json:"email"`
+		// Status is always pending, the member the invitation is linked to accepts it
+		Status InvitationStatus `json:"status"`
+		// OccurrenceAt limits the invitation to one occurrence of a recurring gathering, nil invites to the whole series
+		OccurrenceAt *time.Time `json:"occurrence_at"`
+		CreatedAt    time.Time  `json:"created_at"`
+		UpdatedAt    time.Time  `json:"updated_at"`
+	}
+
+	EmailInvitationRepository interface {
+		Create(ctx context.Context, invitation *EmailInvitation) error
+		FindByID(ctx context.Context, invitationID int64) (*EmailInvitation, error)
+		FindByEmail(ctx context.Context, email string) ([]*EmailInvitation, error)
+		FindByGatheringID(ctx context.Context, gatheringID int64) ([]*EmailInvitation, error)
+		DeleteByID(ctx context.Context, invitationID int64) error
+	}
+)
+
+// NormalizeEmail lowercases the bare email address, it reports false when email is not a bare address
+func NormalizeEmail(email string) (string, bool) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", false
+	}
+	return email, true
+}
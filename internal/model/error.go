@@ -0,0 +1,8 @@
+package model
+
+import "errors"
+
+var (
+	// ErrDuplicateEntry returned by repositories when a unique constraint is violated
+	ErrDuplicateEntry = errors.New("duplicate entry")
+)
@@ -0,0 +1,164 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type (
+	EventType string
+
+	Event struct {
+		ID         int64           `json:"id"`
+		Type       EventType       `json:"type"`
+		OccurredAt time.Time       `json:"occurred_at"`
+		Payload    json.RawMessage `json:"payload"`
+	}
+
+	EventHandler func(ctx context.Context, event *Event) error
+
+	EventPublisher interface {
+		Publish(ctx context.Context, events ...*Event) error
+	}
+
+	GatheringRescheduledPayload struct {
+		Gathering           *Gathering `json:"gathering"`
+		PreviousScheduledAt *time.Time `json:"previous_scheduled_at"`
+	}
+
+	GatheringCancelledPayload struct {
+		Gathering *Gathering `json:"gathering"`
+		MemberIDs []int64    `json:"member_ids"`
+	}
+
+	// EventScope is what an event is about, the gathering it happened on and the member it concerns. Either is 0 when
+	// the event has none
+	EventScope struct {
+		GatheringID int64
+		MemberID    int64
+	}
+
+	GatheringReminderDuePayload struct {
+		// Gathering starts and ends as the occurrence the reminder is for
+		Gathering *Gathering `json:"gathering"`
+		// OccurrenceAt identifies the occurrence of a recurring gathering, it is nil for the other gatherings
+		OccurrenceAt  *time.Time `json:"occurrence_at,omitempty"`
+		OffsetMinutes int        `json:"offset_minutes"`
+		MemberIDs     []int64    `json:"member_ids"`
+	}
+)
+
+// member events carry the *Member, invitation events the *Invitation and gathering.created the *Gathering as payload
+const (
+	MemberRegistered = EventType("member.registered")
+	MemberDeleted    = EventType("member.deleted")
+
+	GatheringCreated     = EventType("gathering.created")
+	GatheringRescheduled = EventType("gathering.rescheduled")
+	GatheringCancelled   = EventType("gathering.cancelled")
+	GatheringReminderDue = EventType("gathering.reminder_due")
+
+	InvitationSent     = EventType("invitation.sent")
+	InvitationAccepted = EventType("invitation.accepted")
+	InvitationDeleted  = EventType("invitation.deleted")
+	InvitationDeclined = EventType("invitation.declined")
+	// InvitationPromoted is published when a waitlisted member gets a seat
+	InvitationPromoted = EventType("invitation.promoted")
+	// InvitationLinked is published when a member registers with the email of an email invitation
+	InvitationLinked = EventType("invitation.linked")
+
+	// EmailInvitationSent carries the *EmailInvitation sent to someone who is not a member yet
+	EmailInvitationSent = EventType("email_invitation.sent")
+
+	// AttendeeCheckedIn and AttendeeCheckInUndone carry the *Attendee checked in at its gathering, or no longer
+	AttendeeCheckedIn     = EventType("attendee.checked_in")
+	AttendeeCheckInUndone = EventType("attendee.check_in_undone")
+)
+
+// EventTypes lists every event type published by the usecases
+var EventTypes = []EventType{
+	MemberRegistered,
+	MemberDeleted,
+	GatheringCreated,
+	GatheringRescheduled,
+	GatheringCancelled,
+	GatheringReminderDue,
+	InvitationSent,
+	InvitationAccepted,
+	InvitationDeleted,
+	InvitationDeclined,
+	InvitationPromoted,
+	InvitationLinked,
+	EmailInvitationSent,
+	AttendeeCheckedIn,
+	AttendeeCheckInUndone,
+}
+
+// NewEvent creates an event of the given type with the payload encoded as JSON
+func NewEvent(eventType EventType, payload interface{}) (*Event, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:         GenerateID(),
+		Type:       eventType,
+		OccurredAt: time.Now(),
+		Payload:    b,
+	}, nil
+}
+
+// DecodePayload decodes the event payload into v
+func (e *Event) DecodePayload(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// Scope decodes the gathering and the member the event is about from its payload
+func (e *Event) Scope() (*EventScope, error) {
+	switch e.Type {
+	case MemberRegistered, MemberDeleted:
+		var member Member
+		if err := e.DecodePayload(&member); err != nil {
+			return nil, err
+		}
+		return &EventScope{MemberID: member.ID}, nil
+	case GatheringCreated:
+		var gathering Gathering
+		if err := e.DecodePayload(&gathering); err != nil {
+			return nil, err
+		}
+		return &EventScope{GatheringID: gathering.ID}, nil
+	case GatheringRescheduled, GatheringCancelled, GatheringReminderDue:
+		var payload struct {
+			Gathering *Gathering `json:"gathering"`
+		}
+		if err := e.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		if payload.Gathering == nil {
+			return &EventScope{}, nil
+		}
+		return &EventScope{GatheringID: payload.Gathering.ID}, nil
+	case EmailInvitationSent:
+		var invitation EmailInvitation
+		if err := e.DecodePayload(&invitation); err != nil {
+			return nil, err
+		}
+		return &EventScope{GatheringID: invitation.GatheringID}, nil
+	case AttendeeCheckedIn, AttendeeCheckInUndone:
+		var attendee Attendee
+		if err := e.DecodePayload(&attendee); err != nil {
+			return nil, err
+		}
+		return &EventScope{GatheringID: attendee.GatheringID, MemberID: attendee.MemberID}, nil
+	default:
+		// the invitation events
+		var invitation Invitation
+		if err := e.DecodePayload(&invitation); err != nil {
+			return nil, err
+		}
+		return &EventScope{GatheringID: invitation.GatheringID, MemberID: invitation.MemberID}, nil
+	}
+}
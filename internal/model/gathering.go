@@ -2,7 +2,10 @@ package model
 
 import (
 	"context"
+	"encoding/json"
 	"time"
+	// the time zone database is embedded so gathering time zones resolve on hosts without one
+	_ "time/tzdata"
 
 	"gorm.io/gorm"
 )
@@ -11,22 +14,32 @@ type (
 	GatheringType int
 
 	Gathering struct {
-		ID          int64          `json:"id"`
-		Creator     int64          `json:"creator"`
-		Type        GatheringType  `json:"type"`
-		ScheduledAt *time.Time     `json:"scheduled_at"`
-		Name        string         `json:"name"`
-		Location    string         `json:"location"`
-		CreatedAt   time.Time      `json:"created_at"`
-		UpdatedAt   time.Time      `json:"updated_at"`
-		DeletedAt   gorm.DeletedAt `json:"deleted_at"`
+		ID          int64         `json:"id"`
+		Creator     int64         `json:"creator"`
+		Type        GatheringType `json:"type"`
+		ScheduledAt *time.Time    `json:"scheduled_at"`
+		EndsAt      *time.Time    `json:"ends_at"`
+		TimeZone    string        `json:"time_zone"`
+		// Recurrence is an RFC 5545 RRULE such as "FREQ=WEEKLY;COUNT=10", empty when the gathering does not recur
+		Recurrence string `json:"recurrence"`
+		// Capacity caps how many members attend a gathering WithFixedNumberOfAttendees, 0 doesn't cap it
+		Capacity  int            `json:"capacity"`
+		Name      string         `json:"name"`
+		Location  string         `json:"location"`
+		CreatedAt time.Time      `json:"created_at"`
+		UpdatedAt time.Time      `json:"updated_at"`
+		DeletedAt gorm.DeletedAt `json:"deleted_at"`
 	}
 
 	GatheringRepository interface {
 		Create(ctx context.Context, gathering *Gathering) error
 		FindByID(ctx context.Context, gatheringID int64) (*Gathering, error)
+		FindByIDsWithDeleted(ctx context.Context, gatheringIDs []int64) ([]*Gathering, error)
+		LockByID(ctx context.Context, gatheringID int64) (*Gathering, error)
 		UpdateByID(ctx context.Context, gathering *Gathering) (*Gathering, error)
 		DeleteByID(ctx context.Context, gatheringID int64) (*Gathering, error)
+		FindAllDeleted(ctx context.Context) ([]*Gathering, error)
+		RestoreByID(ctx context.Context, gatheringID int64) (*Gathering, error)
 	}
 
 	GatheringUsecase interface {
@@ -34,14 +47,64 @@ type (
 		FindGatheringByID(ctx context.Context, gatheringID int64) (*Gathering, error)
 		UpdateGatheringByID(ctx context.Context, gathering *Gathering) (*Gathering, error)
 		DeleteGatheringByID(ctx context.Context, gatheringID int64) (*Gathering, error)
+		FindDeletedGatherings(ctx context.Context) ([]*Gathering, error)
+		RestoreGatheringByID(ctx context.Context, gatheringID int64) (*Gathering, error)
 	}
 )
 
+// DefaultTimeZone of a gathering created without one
+const DefaultTimeZone = "UTC"
+
 const (
 	WithFixedNumberOfAttendees   = GatheringType(1)
 	WithExpirationForInvitations = GatheringType(2)
 )
 
+// IsCapacityLimited reports whether the gathering has a fixed number of seats, the members invited once they are
+// taken are waitlisted
+func (g *Gathering) IsCapacityLimited() bool {
+	return g.Type == WithFixedNumberOfAttendees && g.Capacity > 0
+}
+
 func (g *Gathering) ImmutableColumns() []string {
 	return []string{"created_at"}
 }
+
+// TimeLocation loads the gathering time zone, it falls back to UTC when the zone is unknown
+func (g *Gathering) TimeLocation() *time.Location {
+	if g.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(g.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LocalScheduledAt is the gathering start in its time zone
+func (g *Gathering) LocalScheduledAt() *time.Time {
+	return inLocation(g.ScheduledAt, g.TimeLocation())
+}
+
+// LocalEndsAt is the gathering end in its time zone
+func (g *Gathering) LocalEndsAt() *time.Time {
+	return inLocation(g.EndsAt, g.TimeLocation())
+}
+
+// MarshalJSON renders the start and end in the gathering time zone, they are stored in UTC
+func (g Gathering) MarshalJSON() ([]byte, error) {
+	type gathering Gathering
+	local := gathering(g)
+	local.ScheduledAt = g.LocalScheduledAt()
+	local.EndsAt = g.LocalEndsAt()
+	return json.Marshal(local)
+}
+
+func inLocation(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := t.In(loc)
+	return &local
+}
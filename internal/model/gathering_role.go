@@ -0,0 +1,76 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	GatheringRoleType string
+
+	// GatheringPermission is something a member may do on a gathering depending on their role
+	GatheringPermission int
+
+	// GatheringRole gives a member a role on a gathering, a gathering has exactly one owner, its Creator
+	GatheringRole struct {
+		GatheringID int64             `json:"gathering_id" gorm:"primaryKey"`
+		MemberID    int64             `json:"member_id" gorm:"primaryKey"`
+		Role        GatheringRoleType `json:"role"`
+		CreatedAt   time.Time         `json:"created_at"`
+		UpdatedAt   time.Time         `json:"updated_at"`
+	}
+
+	GatheringRoleRepository interface {
+		Save(ctx context.Context, role *GatheringRole) error
+		FindByGatheringID(ctx context.Context, gatheringID int64) ([]*GatheringRole, error)
+		FindByGatheringIDAndMemberID(ctx context.Context, gatheringID int64, memberID int64) (*GatheringRole, error)
+		DeleteByGatheringIDAndMemberID(ctx context.Context, gatheringID int64, memberID int64) error
+	}
+
+	GatheringRoleUsecase interface {
+		FindGatheringRoles(ctx context.Context, gatheringID int64) ([]*GatheringRole, error)
+		AddGatheringRole(ctx context.Context, role *GatheringRole) (*GatheringRole, error)
+		RemoveGatheringRole(ctx context.Context, gatheringID int64, memberID int64) error
+		TransferGatheringOwnership(ctx context.Context, gatheringID int64, newOwner int64) (*Gathering, error)
+		AuthorizeGathering(ctx context.Context, gatheringID int64, actorID int64, permission GatheringPermission) error
+	}
+)
+
+const (
+	GatheringOwner     = GatheringRoleType("owner")
+	GatheringCoHost    = GatheringRoleType("co_host")
+	GatheringModerator = GatheringRoleType("moderator")
+)
+
+const (
+	// UpdateGathering edits the details of the gathering
+	UpdateGathering = GatheringPermission(iota + 1)
+	// DeleteGathering deletes the gathering
+	DeleteGathering
+	// InviteToGathering invites members to the gathering, one by one, in bulk, by email or with links
+	InviteToGathering
+	// ManageGatheringRoles adds and removes co-hosts and moderators and transfers the ownership
+	ManageGatheringRoles
+)
+
+var gatheringRolePermissions = map[GatheringRoleType][]GatheringPermission{
+	GatheringOwner:     {UpdateGathering, DeleteGathering, InviteToGathering, ManageGatheringRoles},
+	GatheringCoHost:    {UpdateGathering, InviteToGathering},
+	GatheringModerator: {InviteToGathering},
+}
+
+// IsValid reports whether the role is one of the known roles
+func (r GatheringRoleType) IsValid() bool {
+	_, ok := gatheringRolePermissions[r]
+	return ok
+}
+
+// Can reports whether the role grants the permission
+func (r GatheringRoleType) Can(permission GatheringPermission) bool {
+	for _, p := range gatheringRolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
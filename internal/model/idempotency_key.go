@@ -0,0 +1,44 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	IdempotencyKey struct {
+		Key          string    `json:"key" gorm:"primary_key"`
+		RequestHash  string    `json:"request_hash"`
+		ResponseCode int       `json:"response_code"`
+		ResponseBody []byte    `json:"response_body"`
+		LockedUntil  time.Time `json:"locked_until"`
+		ExpiresAt    time.Time `json:"expires_at"`
+		CreatedAt    time.Time `json:"created_at"`
+		UpdatedAt    time.Time `json:"updated_at"`
+	}
+
+	IdempotencyKeyRepository interface {
+		Create(ctx context.Context, idempotencyKey *IdempotencyKey) error
+		FindByKey(ctx context.Context, key string) (*IdempotencyKey, error)
+		Reclaim(ctx context.Context, idempotencyKey *IdempotencyKey, now time.Time) (bool, error)
+		UpdateResponse(ctx context.Context, idempotencyKey *IdempotencyKey) error
+		DeleteByKey(ctx context.Context, key string) error
+		DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	}
+)
+
+// IsCompleted reports whether the response of the request holding the key has been stored
+func (i *IdempotencyKey) IsCompleted() bool {
+	return i.ResponseCode != 0
+}
+
+// IsExpired reports whether the key can no longer be replayed at the given time
+func (i *IdempotencyKey) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// IsLocked reports whether the request holding the key may still be in progress at the given time,
+// once the lock passed without a stored response the request is considered abandoned
+func (i *IdempotencyKey) IsLocked(now time.Time) bool {
+	return now.Before(i.LockedUntil)
+}
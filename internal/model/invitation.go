@@ -10,37 +10,192 @@ import (
 type (
 	InvitationStatus int
 
+	BulkInvitationMode string
+
+	BulkInvitationResultStatus string
+
 	Invitation struct {
 		ID          int64
 		MemberID    int64
 		GatheringID int64
 		Status      InvitationStatus
-		CreatedAt   time.Time      `json:"created_at"`
-		UpdatedAt   time.Time      `json:"updated_at"`
-		DeletedAt   gorm.DeletedAt `json:"deleted_at"`
+		// AllowedGuests is how many guests the organizer lets the member bring
+		AllowedGuests int `json:"allowed_guests"`
+		// Guests is how many guests the member brings, each takes a seat of the gathering
+		Guests int `json:"guests"`
+		// GuestNames optionally names the guests, there are at most Guests names
+		GuestNames []string `json:"guest_names" gorm:"serializer:json"`
+		// OccurrenceAt limits the invitation to one occurrence of a recurring gathering, nil invites to the whole series.
+		// A member holds a single invitation per gathering, so it is for either one occurrence or the whole series
+		OccurrenceAt *time.Time `json:"occurrence_at"`
+		// InvitedAt is when the member was last invited, an invitation sent again restarts it
+		InvitedAt time.Time `json:"invited_at" gorm:"autoCreateTime"`
+		// AcceptedAt is when the member accepted the pending invitation, nil for invitations created accepted
+		AcceptedAt *time.Time     `json:"accepted_at"`
+		CreatedAt  time.Time      `json:"created_at"`
+		UpdatedAt  time.Time      `json:"updated_at"`
+		DeletedAt  gorm.DeletedAt `json:"deleted_at"`
+		// WaitlistPosition is set when the invitation put the member on the waitlist of a full gathering
+		WaitlistPosition int64 `json:"waitlist_position,omitempty" gorm:"-"`
+	}
+
+	BulkInvitation struct {
+		GatheringID int64
+		MemberIDs   []int64
+		Status      InvitationStatus
+		Mode        BulkInvitationMode
+		// AllowedGuests is how many guests each member may bring
+		AllowedGuests int
+		// OccurrenceAt limits the invitations to one occurrence of a recurring gathering
+		OccurrenceAt *time.Time
+	}
+
+	BulkInvitationResult struct {
+		MemberID     int64                      `json:"member_id"`
+		InvitationID int64                      `json:"invitation_id,omitempty"`
+		Status       BulkInvitationResultStatus `json:"status"`
+		Error        string                     `json:"error,omitempty"`
+	}
+
+	BulkInvitationReport struct {
+		GatheringID int64                   `json:"gathering_id"`
+		Mode        BulkInvitationMode      `json:"mode"`
+		Invited     int                     `json:"invited"`
+		Waitlisted  int                     `json:"waitlisted"`
+		Skipped     int                     `json:"skipped"`
+		Failed      int                     `json:"failed"`
+		Results     []*BulkInvitationResult `json:"results"`
 	}
 
 	InvitationRepository interface {
 		Create(ctx context.Context, invitation *Invitation) error
 		FindByID(ctx context.Context, invitationID int64) (*Invitation, error)
+		FindByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64) (*Invitation, error)
+		FindByGatheringIDAndMemberIDs(ctx context.Context, gatheringID int64, memberIDs []int64) ([]*Invitation, error)
+		FindByMemberID(ctx context.Context, memberID int64) ([]*Invitation, error)
+		FindByGatheringID(ctx context.Context, gatheringID int64) ([]*Invitation, error)
 		UpdateByID(ctx context.Context, invitation *Invitation) (*Invitation, error)
+		UpdateAllowedGuestsByID(ctx context.Context, invitationID int64, allowedGuests int) error
 		DeleteByID(ctx context.Context, invitationID int64) (*Invitation, error)
+		DeleteByMemberID(ctx context.Context, memberID int64) error
+		DeleteByGatheringID(ctx context.Context, gatheringID int64) error
+		Revive(ctx context.Context, invitation *Invitation) (*Invitation, error)
+		FindAllDeleted(ctx context.Context) ([]*Invitation, error)
+		RestoreByID(ctx context.Context, invitationID int64) (*Invitation, error)
+		LockPendingOfStartedGatherings(ctx context.Context, now time.Time) ([]*Invitation, error)
+		ExpireByIDs(ctx context.Context, invitationIDs []int64) error
+		CountStatsByMemberID(ctx context.Context, memberID int64, from, to, now time.Time) (*MemberStats, error)
+		StreamRosterByGatheringID(ctx context.Context, gatheringID int64, fn func(entry *RosterEntry) error) error
 	}
 
 	InvitationUsecase interface {
 		InviteMemberToGathering(ctx context.Context, invitation *Invitation) (*Invitation, error)
+		BulkInviteMembersToGathering(ctx context.Context, bulkInvitation *BulkInvitation) (*BulkInvitationReport, error)
 		FindInvitationByID(ctx context.Context, invitationID int64) (*Invitation, error)
 		UpdateInvitationByID(ctx context.Context, invitation *Invitation) (*Invitation, error)
+		UpdateAllowedGuestsByID(ctx context.Context, invitationID int64, allowedGuests int) (*Invitation, error)
 		DeleteInvitationByID(ctx context.Context, invitationID int64) (*Invitation, error)
+		FindDeletedInvitations(ctx context.Context) ([]*Invitation, error)
+		RestoreInvitationByID(ctx context.Context, invitationID int64) (*Invitation, error)
+		ExpirePendingInvitations(ctx context.Context) (int64, error)
+		InviteEmailToGathering(ctx context.Context, invitation *EmailInvitation) (*EmailInvitation, error)
+		FindEmailInvitationsByGatheringID(ctx context.Context, gatheringID int64) ([]*EmailInvitation, error)
+		FindEmailInvitationByID(ctx context.Context, invitationID int64) (*EmailInvitation, error)
+		DeleteEmailInvitationByID(ctx context.Context, invitationID int64) (*EmailInvitation, error)
+		LinkEmailInvitations(ctx context.Context, member *Member) ([]*Invitation, error)
+		ExportRosterByGatheringID(ctx context.Context, gatheringID int64, fn func(entry *RosterEntry) error) error
 	}
 )
 
 const (
-	Pending = InvitationStatus(1)
-	Active  = InvitationStatus(2)
-	Expired = InvitationStatus(3)
+	Pending  = InvitationStatus(1)
+	Active   = InvitationStatus(2)
+	Expired  = InvitationStatus(3)
+	Declined = InvitationStatus(4)
+)
+
+var invitationStatusNames = map[InvitationStatus]string{
+	Pending:  "pending",
+	Active:   "accepted",
+	Expired:  "expired",
+	Declined: "declined",
+}
+
+const (
+	// BulkInvitationTransactional invites every member or none of them
+	BulkInvitationTransactional = BulkInvitationMode("transactional")
+	// BulkInvitationBestEffort invites as many members as possible
+	BulkInvitationBestEffort = BulkInvitationMode("best_effort")
+
+	// MaxBulkInvitationMembers limits how many members can be invited in one call
+	MaxBulkInvitationMembers = 500
+)
+
+const (
+	BulkInvitationInvited        = BulkInvitationResultStatus("invited")
+	BulkInvitationWaitlisted     = BulkInvitationResultStatus("waitlisted")
+	BulkInvitationAlreadyInvited = BulkInvitationResultStatus("already_invited")
+	BulkInvitationMemberNotFound = BulkInvitationResultStatus("member_not_found")
+	BulkInvitationFailed         = BulkInvitationResultStatus("failed")
+	BulkInvitationRolledBack     = BulkInvitationResultStatus("rolled_back")
 )
 
 func (i *Invitation) ImmutableColumns() []string {
-	return []string{"created_at", "deleted_at"}
+	return []string{"member_id", "gathering_id", "created_at", "deleted_at", "occurrence_at", "invited_at", "allowed_guests"}
+}
+
+// String names the status the way the members see it, an active invitation is an accepted one
+func (s InvitationStatus) String() string {
+	if name, ok := invitationStatusNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// IsLive reports whether the invitation is neither deleted, expired nor declined, a member can only hold one live
+// invitation per gathering
+func (i *Invitation) IsLive() bool {
+	return !i.DeletedAt.Valid && i.Status != Expired && i.Status != Declined
+}
+
+// ExpiresAt is when the pending invitation to the gathering with expiring invitations expires: when the occurrence it
+// is for starts or, for the whole series, when the first occurrence after the member was invited that is not
+// cancelled starts. It is nil when the invitation does not expire
+func (i *Invitation) ExpiresAt(gathering *Gathering, overrides []*GatheringOccurrenceOverride) (*time.Time, error) {
+	switch {
+	case gathering.Type != WithExpirationForInvitations || gathering.ScheduledAt == nil:
+		return nil, nil
+	case !gathering.IsRecurring():
+		return gathering.ScheduledAt, nil
+	case i.OccurrenceAt != nil:
+		return &gathering.Occurrence(*i.OccurrenceAt, overrides).ScheduledAt, nil
+	}
+
+	occurrence, err := gathering.NextOccurrence(i.InvitedAt, overrides)
+	if err != nil || occurrence == nil {
+		return nil, err
+	}
+	return &occurrence.ScheduledAt, nil
+}
+
+// Seats is how many seats of the gathering the member of the invitation and their guests take
+func (i *Invitation) Seats() int64 {
+	return int64(1 + i.Guests)
+}
+
+// Tally recomputes the report counters from its results
+func (r *BulkInvitationReport) Tally() {
+	r.Invited, r.Waitlisted, r.Skipped, r.Failed = 0, 0, 0, 0
+	for _, result := range r.Results {
+		switch result.Status {
+		case BulkInvitationInvited:
+			r.Invited++
+		case BulkInvitationWaitlisted:
+			r.Waitlisted++
+		case BulkInvitationAlreadyInvited:
+			r.Skipped++
+		default:
+			r.Failed++
+		}
+	}
 }
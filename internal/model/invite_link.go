@@ -0,0 +1,51 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// InviteLink lets any member holding its signed token join the gathering until the link expires, is revoked or
+	// runs out of uses
+	InviteLink struct {
+		ID          int64 `json:"id"`
+		GatheringID int64 `json:"gathering_id"`
+		// MaxUses caps how many members can redeem the link, 0 means unlimited
+		MaxUses   int        `json:"max_uses"`
+		Uses      int        `json:"uses"`
+		ExpiresAt time.Time  `json:"expires_at"`
+		RevokedAt *time.Time `json:"revoked_at"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		// Token is the signed token redeeming the link, it is derived from the link and never stored
+		Token string `json:"token,omitempty" gorm:"-"`
+	}
+
+	InviteLinkRepository interface {
+		Create(ctx context.Context, link *InviteLink) error
+		FindByID(ctx context.Context, linkID int64) (*InviteLink, error)
+		LockByID(ctx context.Context, linkID int64) (*InviteLink, error)
+		FindByGatheringID(ctx context.Context, gatheringID int64) ([]*InviteLink, error)
+		IncrementUses(ctx context.Context, linkID int64) error
+		RevokeByID(ctx context.Context, linkID int64, revokedAt time.Time) error
+	}
+
+	InviteLinkUsecase interface {
+		CreateInviteLink(ctx context.Context, link *InviteLink) (*InviteLink, error)
+		FindInviteLinksByGatheringID(ctx context.Context, gatheringID int64) ([]*InviteLink, error)
+		FindInviteLinkByID(ctx context.Context, linkID int64) (*InviteLink, error)
+		RevokeInviteLinkByID(ctx context.Context, linkID int64) (*InviteLink, error)
+		RedeemInviteLink(ctx context.Context, token string, memberID int64) (*Invitation, error)
+	}
+)
+
+// IsExpired reports whether the link can no longer be redeemed at now
+func (l *InviteLink) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// IsUsedUp reports whether the link was redeemed as many times as allowed
+func (l *InviteLink) IsUsedUp() bool {
+	return l.MaxUses > 0 && l.Uses >= l.MaxUses
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type (
+	JobStatus string
+
+	// Job one run of a background job, recurring jobs get a run per schedule tick
+	Job struct {
+		ID          int64           `json:"id"`
+		Name        string          `json:"name"`
+		Payload     json.RawMessage `json:"payload"`
+		Status      JobStatus       `json:"status"`
+		Attempts    int             `json:"attempts"`
+		MaxAttempts int             `json:"max_attempts"`
+		RunAt       time.Time       `json:"run_at"`
+		LeaseUntil  *time.Time      `json:"lease_until"`
+		UniqueKey   *string         `json:"unique_key"`
+		LastError   string          `json:"last_error"`
+		StartedAt   *time.Time      `json:"started_at"`
+		FinishedAt  *time.Time      `json:"finished_at"`
+		CreatedAt   time.Time       `json:"created_at"`
+		UpdatedAt   time.Time       `json:"updated_at"`
+	}
+
+	JobFilter struct {
+		Name   string
+		Status JobStatus
+		Limit  int
+	}
+
+	JobHandler func(ctx context.Context, job *Job) error
+
+	JobRepository interface {
+		Create(ctx context.Context, job *Job) error
+		FindByID(ctx context.Context, jobID int64) (*Job, error)
+		FindRuns(ctx context.Context, filter *JobFilter) ([]*Job, error)
+		ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Job, error)
+		Update(ctx context.Context, job *Job) error
+		UpdateClaimed(ctx context.Context, job *Job, attempts int) (bool, error)
+		DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+	}
+
+	JobUsecase interface {
+		FindJobRuns(ctx context.Context, filter *JobFilter) ([]*Job, error)
+		RetryJobByID(ctx context.Context, jobID int64) (*Job, error)
+	}
+)
+
+const (
+	JobPending   = JobStatus("pending")
+	JobRunning   = JobStatus("running")
+	JobSucceeded = JobStatus("succeeded")
+	// JobDead a job that failed max_attempts times, it is only run again when it is retried
+	JobDead = JobStatus("dead")
+)
+
+const (
+	// DefaultJobRunsLimit how many job runs are listed when no limit is given
+	DefaultJobRunsLimit = 50
+	// MaxJobRunsLimit how many job runs can be listed at once
+	MaxJobRunsLimit = 500
+)
+
+// NewJob creates a pending job due at runAt, payload is encoded as JSON unless it is nil
+func NewJob(name string, payload interface{}, runAt time.Time, maxAttempts int) (*Job, error) {
+	job := &Job{
+		ID:          GenerateID(),
+		Name:        name,
+		Status:      JobPending,
+		MaxAttempts: maxAttempts,
+		RunAt:       runAt,
+	}
+
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		job.Payload = b
+	}
+
+	return job, nil
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,21 +10,31 @@ import (
 
 type (
 	Member struct {
-		ID        int64          `json:"id" gorm:"primary_key"`
-		FirstName string         `json:"first_name"`
-		LastName  string         `json:"last_name"`
-		Email     string         `json:"email"`
-		CreatedAt time.Time      `json:"created_at"`
-		UpdatedAt time.Time      `json:"updated_at"`
-		DeletedAt gorm.DeletedAt `json:"deleted_at"`
+		ID        int64  `json:"id" gorm:"primary_key"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Email     string `json:"email"`
+		// CalendarToken grants access to the member calendar feed, it is never exposed in the member JSON
+		CalendarToken *string        `json:"-"`
+		CreatedAt     time.Time      `json:"created_at"`
+		UpdatedAt     time.Time      `json:"updated_at"`
+		DeletedAt     gorm.DeletedAt `json:"deleted_at"`
 	}
 
 	MemberRepository interface {
 		Create(ctx context.Context, member *Member) error
 		FindByID(ctx context.Context, memberID int64) (*Member, error)
+		FindByIDs(ctx context.Context, memberIDs []int64) ([]*Member, error)
 		FindAll(ctx context.Context) ([]int64, error)
 		UpdateByID(ctx context.Context, member *Member) (*Member, error)
 		DeleteByID(ctx context.Context, memberID int64) (*Member, error)
+		FindAllDeleted(ctx context.Context) ([]*Member, error)
+		RestoreByID(ctx context.Context, memberID int64) (*Member, error)
+		FindByEmail(ctx context.Context, email string) (*Member, error)
+		FindByEmails(ctx context.Context, emails []string) ([]*Member, error)
+		CreateBatch(ctx context.Context, members []*Member) error
+		FindByCalendarToken(ctx context.Context, token string) (*Member, error)
+		UpdateCalendarToken(ctx context.Context, memberID int64, token string) error
 	}
 
 	MemberUsecase interface {
@@ -31,9 +42,13 @@ type (
 		FindMemberByID(ctx context.Context, memberID int64) (*Member, error)
 		UpdateMemberByID(ctx context.Context, member *Member) (*Member, error)
 		DeleteMemberByID(ctx context.Context, memberID int64) (*Member, error)
+		FindDeletedMembers(ctx context.Context) ([]*Member, error)
+		RestoreMemberByID(ctx context.Context, memberID int64) (*Member, error)
+		FindMemberStats(ctx context.Context, memberID int64, from, to time.Time) (*MemberStats, error)
+		ImportMembers(ctx context.Context, r io.Reader, dryRun bool) (*MemberImportReport, error)
 	}
 )
 
 func (m *Member) ImmutableColumns() []string {
-	return []string{"created_at", "deleted_at"}
+	return []string{"created_at", "deleted_at", "calendar_token"}
 }
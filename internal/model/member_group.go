@@ -0,0 +1,67 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxMemberGroupMembers caps the members of a group, so a whole group can be invited at once
+const MaxMemberGroupMembers = MaxBulkInvitationMembers
+
+type (
+	// MemberGroup is a named set of members its owner invites to gatherings together
+	MemberGroup struct {
+		ID        int64          `json:"id"`
+		Name      string         `json:"name"`
+		Owner     int64          `json:"owner"`
+		CreatedAt time.Time      `json:"created_at"`
+		UpdatedAt time.Time      `json:"updated_at"`
+		DeletedAt gorm.DeletedAt `json:"deleted_at"`
+	}
+
+	MemberGroupMember struct {
+		GroupID   int64     `json:"group_id" gorm:"primaryKey"`
+		MemberID  int64     `json:"member_id" gorm:"primaryKey"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// GroupInvitation invites the members of the group to the gathering like a BulkInvitation
+	GroupInvitation struct {
+		GroupID       int64
+		GatheringID   int64
+		Status        InvitationStatus
+		Mode          BulkInvitationMode
+		AllowedGuests int
+		OccurrenceAt  *time.Time
+	}
+
+	MemberGroupRepository interface {
+		Create(ctx context.Context, group *MemberGroup) error
+		FindByID(ctx context.Context, groupID int64) (*MemberGroup, error)
+		LockByID(ctx context.Context, groupID int64) (*MemberGroup, error)
+		FindByOwner(ctx context.Context, owner int64) ([]*MemberGroup, error)
+		UpdateByID(ctx context.Context, group *MemberGroup) (*MemberGroup, error)
+		DeleteByID(ctx context.Context, groupID int64) (*MemberGroup, error)
+		AddMembers(ctx context.Context, groupID int64, memberIDs []int64) error
+		RemoveMembers(ctx context.Context, groupID int64, memberIDs []int64) error
+		FindMemberIDs(ctx context.Context, groupID int64) ([]int64, error)
+	}
+
+	MemberGroupUsecase interface {
+		CreateGroup(ctx context.Context, group *MemberGroup) error
+		FindGroupByID(ctx context.Context, groupID, owner int64) (*MemberGroup, error)
+		FindGroupsByOwner(ctx context.Context, owner int64) ([]*MemberGroup, error)
+		UpdateGroupByID(ctx context.Context, group *MemberGroup) (*MemberGroup, error)
+		DeleteGroupByID(ctx context.Context, groupID, owner int64) (*MemberGroup, error)
+		FindGroupMembers(ctx context.Context, groupID, owner int64) ([]*Member, error)
+		AddGroupMembers(ctx context.Context, groupID, owner int64, memberIDs []int64) ([]*Member, error)
+		RemoveGroupMembers(ctx context.Context, groupID, owner int64, memberIDs []int64) ([]*Member, error)
+		InviteGroupToGathering(ctx context.Context, groupInvitation *GroupInvitation, owner int64) (*BulkInvitationReport, error)
+	}
+)
+
+func (g *MemberGroup) ImmutableColumns() []string {
+	return []string{"created_at", "deleted_at", "owner"}
+}
@@ -0,0 +1,57 @@
+package model
+
+const (
+	// MemberImportBatchSize is how many rows of an import are written in one transaction
+	MemberImportBatchSize = 100
+	// MaxMemberImportBytes limits the size of an imported CSV file
+	MaxMemberImportBytes = 10 << 20
+)
+
+type (
+	MemberImportStatus string
+
+	// MemberImportRow is a row of an imported CSV file, Line is its line in the file
+	MemberImportRow struct {
+		Line   int                `json:"line"`
+		Email  string             `json:"email"`
+		Status MemberImportStatus `json:"status"`
+		Error  string             `json:"error,omitempty"`
+		Member *Member            `json:"-"`
+	}
+
+	MemberImportReport struct {
+		DryRun    bool `json:"dry_run"`
+		Created   int  `json:"created"`
+		Updated   int  `json:"updated"`
+		Unchanged int  `json:"unchanged"`
+		Failed    int  `json:"failed"`
+		// Errors lists the rows that failed, in line order
+		Errors []*MemberImportRow `json:"errors"`
+	}
+)
+
+const (
+	MemberImportCreated   = MemberImportStatus("created")
+	MemberImportUpdated   = MemberImportStatus("updated")
+	MemberImportUnchanged = MemberImportStatus("unchanged")
+	MemberImportFailed    = MemberImportStatus("failed")
+)
+
+// Tally recomputes the report counters and errors from the rows
+func (r *MemberImportReport) Tally(rows []*MemberImportRow) {
+	r.Created, r.Updated, r.Unchanged, r.Failed = 0, 0, 0, 0
+	r.Errors = []*MemberImportRow{}
+	for _, row := range rows {
+		switch row.Status {
+		case MemberImportCreated:
+			r.Created++
+		case MemberImportUpdated:
+			r.Updated++
+		case MemberImportUnchanged:
+			r.Unchanged++
+		default:
+			r.Failed++
+			r.Errors = append(r.Errors, row)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// DefaultMemberStatsWindow is how far back the member statistics look when no window is given
+const DefaultMemberStatsWindow = 365 * 24 * time.Hour
+
+// MemberStats reports how reliably a member attends the gatherings they are invited to, out of the occurrences
+// starting within [From, To). Only the occurrences whose organizers checked attendees in count the no-shows
+type MemberStats struct {
+	MemberID int64     `json:"member_id"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Invited  int64     `json:"invited"`
+	Accepted int64     `json:"accepted"`
+	Attended int64     `json:"attended"`
+	NoShows  int64     `json:"no_shows"`
+	// the rates are nil when there is nothing to compute them from yet
+	AcceptanceRate *float64 `json:"acceptance_rate"`
+	AttendanceRate *float64 `json:"attendance_rate"`
+	NoShowRate     *float64 `json:"no_show_rate"`
+}
+
+// ComputeRates computes the rates from the counts, attendance and no-shows are rated against each other
+func (s *MemberStats) ComputeRates() {
+	s.AcceptanceRate = rate(s.Accepted, s.Invited)
+	s.AttendanceRate = rate(s.Attended, s.Attended+s.NoShows)
+	s.NoShowRate = rate(s.NoShows, s.Attended+s.NoShows)
+}
+
+func rate(count, total int64) *float64 {
+	if total == 0 {
+		return nil
+	}
+	r := float64(count) / float64(total)
+	return &r
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/fajarachmadyusup13/gathering-app/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,21 @@ func (m *MockAttendeeRepository) EXPECT() *MockAttendeeRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountSeatsByGatheringID mocks base method.
+func (m *MockAttendeeRepository) CountSeatsByGatheringID(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSeatsByGatheringID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSeatsByGatheringID indicates an expected call of CountSeatsByGatheringID.
+func (mr *MockAttendeeRepositoryMockRecorder) CountSeatsByGatheringID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSeatsByGatheringID", reflect.TypeOf((*MockAttendeeRepository)(nil).CountSeatsByGatheringID), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockAttendeeRepository) Create(arg0 context.Context, arg1 *model.Attendee) error {
 	m.ctrl.T.Helper()
@@ -49,6 +65,34 @@ func (mr *MockAttendeeRepositoryMockRecorder) Create(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttendeeRepository)(nil).Create), arg0, arg1)
 }
 
+// DeleteByGatheringID mocks base method.
+func (m *MockAttendeeRepository) DeleteByGatheringID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByGatheringID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByGatheringID indicates an expected call of DeleteByGatheringID.
+func (mr *MockAttendeeRepositoryMockRecorder) DeleteByGatheringID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByGatheringID", reflect.TypeOf((*MockAttendeeRepository)(nil).DeleteByGatheringID), arg0, arg1)
+}
+
+// DeleteByMemberID mocks base method.
+func (m *MockAttendeeRepository) DeleteByMemberID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByMemberID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByMemberID indicates an expected call of DeleteByMemberID.
+func (mr *MockAttendeeRepositoryMockRecorder) DeleteByMemberID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByMemberID", reflect.TypeOf((*MockAttendeeRepository)(nil).DeleteByMemberID), arg0, arg1)
+}
+
 // DeleteByMemberIDAndGatheringID mocks base method.
 func (m *MockAttendeeRepository) DeleteByMemberIDAndGatheringID(arg0 context.Context, arg1, arg2 int64) (*model.Attendee, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +108,36 @@ func (mr *MockAttendeeRepositoryMockRecorder) DeleteByMemberIDAndGatheringID(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByMemberIDAndGatheringID", reflect.TypeOf((*MockAttendeeRepository)(nil).DeleteByMemberIDAndGatheringID), arg0, arg1, arg2)
 }
 
+// FindAttendanceByGatheringID mocks base method.
+func (m *MockAttendeeRepository) FindAttendanceByGatheringID(arg0 context.Context, arg1 int64) (*model.Attendance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAttendanceByGatheringID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Attendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAttendanceByGatheringID indicates an expected call of FindAttendanceByGatheringID.
+func (mr *MockAttendeeRepositoryMockRecorder) FindAttendanceByGatheringID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAttendanceByGatheringID", reflect.TypeOf((*MockAttendeeRepository)(nil).FindAttendanceByGatheringID), arg0, arg1)
+}
+
+// FindByCheckInCode mocks base method.
+func (m *MockAttendeeRepository) FindByCheckInCode(arg0 context.Context, arg1 string) (*model.Attendee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCheckInCode", arg0, arg1)
+	ret0, _ := ret[0].(*model.Attendee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCheckInCode indicates an expected call of FindByCheckInCode.
+func (mr *MockAttendeeRepositoryMockRecorder) FindByCheckInCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCheckInCode", reflect.TypeOf((*MockAttendeeRepository)(nil).FindByCheckInCode), arg0, arg1)
+}
+
 // FindByGatheringID mocks base method.
 func (m *MockAttendeeRepository) FindByGatheringID(arg0 context.Context, arg1 int64) ([]*model.Attendee, error) {
 	m.ctrl.T.Helper()
@@ -93,3 +167,105 @@ func (mr *MockAttendeeRepositoryMockRecorder) FindByMemberID(arg0, arg1 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMemberID", reflect.TypeOf((*MockAttendeeRepository)(nil).FindByMemberID), arg0, arg1)
 }
+
+// FindByMemberIDAndGatheringID mocks base method.
+func (m *MockAttendeeRepository) FindByMemberIDAndGatheringID(arg0 context.Context, arg1, arg2 int64) (*model.Attendee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByMemberIDAndGatheringID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Attendee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByMemberIDAndGatheringID indicates an expected call of FindByMemberIDAndGatheringID.
+func (mr *MockAttendeeRepositoryMockRecorder) FindByMemberIDAndGatheringID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMemberIDAndGatheringID", reflect.TypeOf((*MockAttendeeRepository)(nil).FindByMemberIDAndGatheringID), arg0, arg1, arg2)
+}
+
+// FindCancelledByMemberID mocks base method.
+func (m *MockAttendeeRepository) FindCancelledByMemberID(arg0 context.Context, arg1 int64) ([]*model.Attendee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCancelledByMemberID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Attendee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCancelledByMemberID indicates an expected call of FindCancelledByMemberID.
+func (mr *MockAttendeeRepositoryMockRecorder) FindCancelledByMemberID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCancelledByMemberID", reflect.TypeOf((*MockAttendeeRepository)(nil).FindCancelledByMemberID), arg0, arg1)
+}
+
+// RestoreByMemberIDAndGatheringID mocks base method.
+func (m *MockAttendeeRepository) RestoreByMemberIDAndGatheringID(arg0 context.Context, arg1, arg2 int64) (*model.Attendee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreByMemberIDAndGatheringID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Attendee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreByMemberIDAndGatheringID indicates an expected call of RestoreByMemberIDAndGatheringID.
+func (mr *MockAttendeeRepositoryMockRecorder) RestoreByMemberIDAndGatheringID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreByMemberIDAndGatheringID", reflect.TypeOf((*MockAttendeeRepository)(nil).RestoreByMemberIDAndGatheringID), arg0, arg1, arg2)
+}
+
+// Revive mocks base method.
+func (m *MockAttendeeRepository) Revive(arg0 context.Context, arg1 *model.Attendee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revive", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revive indicates an expected call of Revive.
+func (mr *MockAttendeeRepositoryMockRecorder) Revive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revive", reflect.TypeOf((*MockAttendeeRepository)(nil).Revive), arg0, arg1)
+}
+
+// UpdateCheckInCode mocks base method.
+func (m *MockAttendeeRepository) UpdateCheckInCode(arg0 context.Context, arg1, arg2 int64, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCheckInCode", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCheckInCode indicates an expected call of UpdateCheckInCode.
+func (mr *MockAttendeeRepositoryMockRecorder) UpdateCheckInCode(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckInCode", reflect.TypeOf((*MockAttendeeRepository)(nil).UpdateCheckInCode), arg0, arg1, arg2, arg3)
+}
+
+// UpdateCheckedInAt mocks base method.
+func (m *MockAttendeeRepository) UpdateCheckedInAt(arg0 context.Context, arg1, arg2 int64, arg3 *time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCheckedInAt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCheckedInAt indicates an expected call of UpdateCheckedInAt.
+func (mr *MockAttendeeRepositoryMockRecorder) UpdateCheckedInAt(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckedInAt", reflect.TypeOf((*MockAttendeeRepository)(nil).UpdateCheckedInAt), arg0, arg1, arg2, arg3)
+}
+
+// UpdateGuestsByMemberIDAndGatheringID mocks base method.
+func (m *MockAttendeeRepository) UpdateGuestsByMemberIDAndGatheringID(arg0 context.Context, arg1, arg2 int64, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestsByMemberIDAndGatheringID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGuestsByMemberIDAndGatheringID indicates an expected call of UpdateGuestsByMemberIDAndGatheringID.
+func (mr *MockAttendeeRepositoryMockRecorder) UpdateGuestsByMemberIDAndGatheringID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestsByMemberIDAndGatheringID", reflect.TypeOf((*MockAttendeeRepository)(nil).UpdateGuestsByMemberIDAndGatheringID), arg0, arg1, arg2, arg3)
+}
@@ -64,6 +64,21 @@ func (mr *MockGatheringRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockGatheringRepository)(nil).DeleteByID), arg0, arg1)
 }
 
+// FindAllDeleted mocks base method.
+func (m *MockGatheringRepository) FindAllDeleted(arg0 context.Context) ([]*model.Gathering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllDeleted", arg0)
+	ret0, _ := ret[0].([]*model.Gathering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllDeleted indicates an expected call of FindAllDeleted.
+func (mr *MockGatheringRepositoryMockRecorder) FindAllDeleted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllDeleted", reflect.TypeOf((*MockGatheringRepository)(nil).FindAllDeleted), arg0)
+}
+
 // FindByID mocks base method.
 func (m *MockGatheringRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Gathering, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +94,51 @@ func (mr *MockGatheringRepositoryMockRecorder) FindByID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockGatheringRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindByIDsWithDeleted mocks base method.
+func (m *MockGatheringRepository) FindByIDsWithDeleted(arg0 context.Context, arg1 []int64) ([]*model.Gathering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDsWithDeleted", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Gathering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDsWithDeleted indicates an expected call of FindByIDsWithDeleted.
+func (mr *MockGatheringRepositoryMockRecorder) FindByIDsWithDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDsWithDeleted", reflect.TypeOf((*MockGatheringRepository)(nil).FindByIDsWithDeleted), arg0, arg1)
+}
+
+// LockByID mocks base method.
+func (m *MockGatheringRepository) LockByID(arg0 context.Context, arg1 int64) (*model.Gathering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Gathering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByID indicates an expected call of LockByID.
+func (mr *MockGatheringRepositoryMockRecorder) LockByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByID", reflect.TypeOf((*MockGatheringRepository)(nil).LockByID), arg0, arg1)
+}
+
+// RestoreByID mocks base method.
+func (m *MockGatheringRepository) RestoreByID(arg0 context.Context, arg1 int64) (*model.Gathering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Gathering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreByID indicates an expected call of RestoreByID.
+func (mr *MockGatheringRepositoryMockRecorder) RestoreByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreByID", reflect.TypeOf((*MockGatheringRepository)(nil).RestoreByID), arg0, arg1)
+}
+
 // UpdateByID mocks base method.
 func (m *MockGatheringRepository) UpdateByID(arg0 context.Context, arg1 *model.Gathering) (*model.Gathering, error) {
 	m.ctrl.T.Helper()
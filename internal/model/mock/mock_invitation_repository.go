@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/fajarachmadyusup13/gathering-app/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,21 @@ func (m *MockInvitationRepository) EXPECT() *MockInvitationRepositoryMockRecorde
 	return m.recorder
 }
 
+// CountStatsByMemberID mocks base method.
+func (m *MockInvitationRepository) CountStatsByMemberID(arg0 context.Context, arg1 int64, arg2, arg3, arg4 time.Time) (*model.MemberStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStatsByMemberID", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.MemberStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStatsByMemberID indicates an expected call of CountStatsByMemberID.
+func (mr *MockInvitationRepositoryMockRecorder) CountStatsByMemberID(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStatsByMemberID", reflect.TypeOf((*MockInvitationRepository)(nil).CountStatsByMemberID), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Create mocks base method.
 func (m *MockInvitationRepository) Create(arg0 context.Context, arg1 *model.Invitation) error {
 	m.ctrl.T.Helper()
@@ -49,6 +65,20 @@ func (mr *MockInvitationRepositoryMockRecorder) Create(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvitationRepository)(nil).Create), arg0, arg1)
 }
 
+// DeleteByGatheringID mocks base method.
+func (m *MockInvitationRepository) DeleteByGatheringID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByGatheringID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByGatheringID indicates an expected call of DeleteByGatheringID.
+func (mr *MockInvitationRepositoryMockRecorder) DeleteByGatheringID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByGatheringID", reflect.TypeOf((*MockInvitationRepository)(nil).DeleteByGatheringID), arg0, arg1)
+}
+
 // DeleteByID mocks base method.
 func (m *MockInvitationRepository) DeleteByID(arg0 context.Context, arg1 int64) (*model.Invitation, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +94,79 @@ func (mr *MockInvitationRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockInvitationRepository)(nil).DeleteByID), arg0, arg1)
 }
 
+// DeleteByMemberID mocks base method.
+func (m *MockInvitationRepository) DeleteByMemberID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByMemberID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByMemberID indicates an expected call of DeleteByMemberID.
+func (mr *MockInvitationRepositoryMockRecorder) DeleteByMemberID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByMemberID", reflect.TypeOf((*MockInvitationRepository)(nil).DeleteByMemberID), arg0, arg1)
+}
+
+// ExpireByIDs mocks base method.
+func (m *MockInvitationRepository) ExpireByIDs(arg0 context.Context, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireByIDs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireByIDs indicates an expected call of ExpireByIDs.
+func (mr *MockInvitationRepositoryMockRecorder) ExpireByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireByIDs", reflect.TypeOf((*MockInvitationRepository)(nil).ExpireByIDs), arg0, arg1)
+}
+
+// FindAllDeleted mocks base method.
+func (m *MockInvitationRepository) FindAllDeleted(arg0 context.Context) ([]*model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllDeleted", arg0)
+	ret0, _ := ret[0].([]*model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllDeleted indicates an expected call of FindAllDeleted.
+func (mr *MockInvitationRepositoryMockRecorder) FindAllDeleted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllDeleted", reflect.TypeOf((*MockInvitationRepository)(nil).FindAllDeleted), arg0)
+}
+
+// FindByGatheringID mocks base method.
+func (m *MockInvitationRepository) FindByGatheringID(arg0 context.Context, arg1 int64) ([]*model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByGatheringID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByGatheringID indicates an expected call of FindByGatheringID.
+func (mr *MockInvitationRepositoryMockRecorder) FindByGatheringID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByGatheringID", reflect.TypeOf((*MockInvitationRepository)(nil).FindByGatheringID), arg0, arg1)
+}
+
+// FindByGatheringIDAndMemberIDs mocks base method.
+func (m *MockInvitationRepository) FindByGatheringIDAndMemberIDs(arg0 context.Context, arg1 int64, arg2 []int64) ([]*model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByGatheringIDAndMemberIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByGatheringIDAndMemberIDs indicates an expected call of FindByGatheringIDAndMemberIDs.
+func (mr *MockInvitationRepositoryMockRecorder) FindByGatheringIDAndMemberIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByGatheringIDAndMemberIDs", reflect.TypeOf((*MockInvitationRepository)(nil).FindByGatheringIDAndMemberIDs), arg0, arg1, arg2)
+}
+
 // FindByID mocks base method.
 func (m *MockInvitationRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Invitation, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +182,109 @@ func (mr *MockInvitationRepositoryMockRecorder) FindByID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockInvitationRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindByMemberID mocks base method.
+func (m *MockInvitationRepository) FindByMemberID(arg0 context.Context, arg1 int64) ([]*model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByMemberID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByMemberID indicates an expected call of FindByMemberID.
+func (mr *MockInvitationRepositoryMockRecorder) FindByMemberID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMemberID", reflect.TypeOf((*MockInvitationRepository)(nil).FindByMemberID), arg0, arg1)
+}
+
+// FindByMemberIDAndGatheringID mocks base method.
+func (m *MockInvitationRepository) FindByMemberIDAndGatheringID(arg0 context.Context, arg1, arg2 int64) (*model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByMemberIDAndGatheringID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByMemberIDAndGatheringID indicates an expected call of FindByMemberIDAndGatheringID.
+func (mr *MockInvitationRepositoryMockRecorder) FindByMemberIDAndGatheringID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMemberIDAndGatheringID", reflect.TypeOf((*MockInvitationRepository)(nil).FindByMemberIDAndGatheringID), arg0, arg1, arg2)
+}
+
+// LockPendingOfStartedGatherings mocks base method.
+func (m *MockInvitationRepository) LockPendingOfStartedGatherings(arg0 context.Context, arg1 time.Time) ([]*model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPendingOfStartedGatherings", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPendingOfStartedGatherings indicates an expected call of LockPendingOfStartedGatherings.
+func (mr *MockInvitationRepositoryMockRecorder) LockPendingOfStartedGatherings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPendingOfStartedGatherings", reflect.TypeOf((*MockInvitationRepository)(nil).LockPendingOfStartedGatherings), arg0, arg1)
+}
+
+// RestoreByID mocks base method.
+func (m *MockInvitationRepository) RestoreByID(arg0 context.Context, arg1 int64) (*model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreByID indicates an expected call of RestoreByID.
+func (mr *MockInvitationRepositoryMockRecorder) RestoreByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreByID", reflect.TypeOf((*MockInvitationRepository)(nil).RestoreByID), arg0, arg1)
+}
+
+// Revive mocks base method.
+func (m *MockInvitationRepository) Revive(arg0 context.Context, arg1 *model.Invitation) (*model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revive", arg0, arg1)
+	ret0, _ := ret[0].(*model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revive indicates an expected call of Revive.
+func (mr *MockInvitationRepositoryMockRecorder) Revive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revive", reflect.TypeOf((*MockInvitationRepository)(nil).Revive), arg0, arg1)
+}
+
+// StreamRosterByGatheringID mocks base method.
+func (m *MockInvitationRepository) StreamRosterByGatheringID(arg0 context.Context, arg1 int64, arg2 func(*model.RosterEntry) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamRosterByGatheringID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamRosterByGatheringID indicates an expected call of StreamRosterByGatheringID.
+func (mr *MockInvitationRepositoryMockRecorder) StreamRosterByGatheringID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamRosterByGatheringID", reflect.TypeOf((*MockInvitationRepository)(nil).StreamRosterByGatheringID), arg0, arg1, arg2)
+}
+
+// UpdateAllowedGuestsByID mocks base method.
+func (m *MockInvitationRepository) UpdateAllowedGuestsByID(arg0 context.Context, arg1 int64, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAllowedGuestsByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAllowedGuestsByID indicates an expected call of UpdateAllowedGuestsByID.
+func (mr *MockInvitationRepositoryMockRecorder) UpdateAllowedGuestsByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAllowedGuestsByID", reflect.TypeOf((*MockInvitationRepository)(nil).UpdateAllowedGuestsByID), arg0, arg1, arg2)
+}
+
 // UpdateByID mocks base method.
 func (m *MockInvitationRepository) UpdateByID(arg0 context.Context, arg1 *model.Invitation) (*model.Invitation, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +49,20 @@ func (mr *MockMemberRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMemberRepository)(nil).Create), arg0, arg1)
 }
 
+// CreateBatch mocks base method.
+func (m *MockMemberRepository) CreateBatch(arg0 context.Context, arg1 []*model.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockMemberRepositoryMockRecorder) CreateBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockMemberRepository)(nil).CreateBatch), arg0, arg1)
+}
+
 // DeleteByID mocks base method.
 func (m *MockMemberRepository) DeleteByID(arg0 context.Context, arg1 int64) (*model.Member, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +93,66 @@ func (mr *MockMemberRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockMemberRepository)(nil).FindAll), arg0)
 }
 
+// FindAllDeleted mocks base method.
+func (m *MockMemberRepository) FindAllDeleted(arg0 context.Context) ([]*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllDeleted", arg0)
+	ret0, _ := ret[0].([]*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllDeleted indicates an expected call of FindAllDeleted.
+func (mr *MockMemberRepositoryMockRecorder) FindAllDeleted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllDeleted", reflect.TypeOf((*MockMemberRepository)(nil).FindAllDeleted), arg0)
+}
+
+// FindByCalendarToken mocks base method.
+func (m *MockMemberRepository) FindByCalendarToken(arg0 context.Context, arg1 string) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCalendarToken", arg0, arg1)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCalendarToken indicates an expected call of FindByCalendarToken.
+func (mr *MockMemberRepositoryMockRecorder) FindByCalendarToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCalendarToken", reflect.TypeOf((*MockMemberRepository)(nil).FindByCalendarToken), arg0, arg1)
+}
+
+// FindByEmail mocks base method.
+func (m *MockMemberRepository) FindByEmail(arg0 context.Context, arg1 string) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", arg0, arg1)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockMemberRepositoryMockRecorder) FindByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockMemberRepository)(nil).FindByEmail), arg0, arg1)
+}
+
+// FindByEmails mocks base method.
+func (m *MockMemberRepository) FindByEmails(arg0 context.Context, arg1 []string) ([]*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmails", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmails indicates an expected call of FindByEmails.
+func (mr *MockMemberRepositoryMockRecorder) FindByEmails(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmails", reflect.TypeOf((*MockMemberRepository)(nil).FindByEmails), arg0, arg1)
+}
+
 // FindByID mocks base method.
 func (m *MockMemberRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Member, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +168,36 @@ func (mr *MockMemberRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockMemberRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindByIDs mocks base method.
+func (m *MockMemberRepository) FindByIDs(arg0 context.Context, arg1 []int64) ([]*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockMemberRepositoryMockRecorder) FindByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockMemberRepository)(nil).FindByIDs), arg0, arg1)
+}
+
+// RestoreByID mocks base method.
+func (m *MockMemberRepository) RestoreByID(arg0 context.Context, arg1 int64) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreByID indicates an expected call of RestoreByID.
+func (mr *MockMemberRepositoryMockRecorder) RestoreByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreByID", reflect.TypeOf((*MockMemberRepository)(nil).RestoreByID), arg0, arg1)
+}
+
 // UpdateByID mocks base method.
 func (m *MockMemberRepository) UpdateByID(arg0 context.Context, arg1 *model.Member) (*model.Member, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +212,17 @@ func (mr *MockMemberRepositoryMockRecorder) UpdateByID(arg0, arg1 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByID", reflect.TypeOf((*MockMemberRepository)(nil).UpdateByID), arg0, arg1)
 }
+
+// UpdateCalendarToken mocks base method.
+func (m *MockMemberRepository) UpdateCalendarToken(arg0 context.Context, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCalendarToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCalendarToken indicates an expected call of UpdateCalendarToken.
+func (mr *MockMemberRepositoryMockRecorder) UpdateCalendarToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCalendarToken", reflect.TypeOf((*MockMemberRepository)(nil).UpdateCalendarToken), arg0, arg1, arg2)
+}
@@ -6,7 +6,9 @@ package mock
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/fajarachmadyusup13/gathering-app/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -50,6 +52,21 @@ func (mr *MockMemberUsecaseMockRecorder) DeleteMemberByID(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMemberByID", reflect.TypeOf((*MockMemberUsecase)(nil).DeleteMemberByID), arg0, arg1)
 }
 
+// FindDeletedMembers mocks base method.
+func (m *MockMemberUsecase) FindDeletedMembers(arg0 context.Context) ([]*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedMembers", arg0)
+	ret0, _ := ret[0].([]*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedMembers indicates an expected call of FindDeletedMembers.
+func (mr *MockMemberUsecaseMockRecorder) FindDeletedMembers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedMembers", reflect.TypeOf((*MockMemberUsecase)(nil).FindDeletedMembers), arg0)
+}
+
 // FindMemberByID mocks base method.
 func (m *MockMemberUsecase) FindMemberByID(arg0 context.Context, arg1 int64) (*model.Member, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +82,36 @@ func (mr *MockMemberUsecaseMockRecorder) FindMemberByID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMemberByID", reflect.TypeOf((*MockMemberUsecase)(nil).FindMemberByID), arg0, arg1)
 }
 
+// FindMemberStats mocks base method.
+func (m *MockMemberUsecase) FindMemberStats(arg0 context.Context, arg1 int64, arg2, arg3 time.Time) (*model.MemberStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMemberStats", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.MemberStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMemberStats indicates an expected call of FindMemberStats.
+func (mr *MockMemberUsecaseMockRecorder) FindMemberStats(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMemberStats", reflect.TypeOf((*MockMemberUsecase)(nil).FindMemberStats), arg0, arg1, arg2, arg3)
+}
+
+// ImportMembers mocks base method.
+func (m *MockMemberUsecase) ImportMembers(arg0 context.Context, arg1 io.Reader, arg2 bool) (*model.MemberImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportMembers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.MemberImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportMembers indicates an expected call of ImportMembers.
+func (mr *MockMemberUsecaseMockRecorder) ImportMembers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMembers", reflect.TypeOf((*MockMemberUsecase)(nil).ImportMembers), arg0, arg1, arg2)
+}
+
 // Register mocks base method.
 func (m *MockMemberUsecase) Register(arg0 context.Context, arg1 *model.Member) error {
 	m.ctrl.T.Helper()
@@ -79,6 +126,21 @@ func (mr *MockMemberUsecaseMockRecorder) Register(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockMemberUsecase)(nil).Register), arg0, arg1)
 }
 
+// RestoreMemberByID mocks base method.
+func (m *MockMemberUsecase) RestoreMemberByID(arg0 context.Context, arg1 int64) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMemberByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreMemberByID indicates an expected call of RestoreMemberByID.
+func (mr *MockMemberUsecaseMockRecorder) RestoreMemberByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMemberByID", reflect.TypeOf((*MockMemberUsecase)(nil).RestoreMemberByID), arg0, arg1)
+}
+
 // UpdateMemberByID mocks base method.
 func (m *MockMemberUsecase) UpdateMemberByID(arg0 context.Context, arg1 *model.Member) (*model.Member, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// EmailTemplate names the templates an email is rendered from
+	EmailTemplate string
+
+	// EmailData is what the email templates are rendered with, the email is sent to Member
+	EmailData struct {
+		Member     *Member
+		Gathering  *Gathering
+		Invitation *Invitation
+	}
+
+	// NotificationDelivery records that the email of an event reached a member, a retried event skips the members
+	// it already reached
+	NotificationDelivery struct {
+		EventID   int64     `json:"event_id" gorm:"primaryKey"`
+		MemberID  int64     `json:"member_id" gorm:"primaryKey"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	NotificationDeliveryRepository interface {
+		Create(ctx context.Context, delivery *NotificationDelivery) error
+		FindMemberIDsByEventID(ctx context.Context, eventID int64) ([]int64, error)
+	}
+
+	Notifier interface {
+		Notify(ctx context.Context, template EmailTemplate, data *EmailData) error
+	}
+
+	NotificationUsecase interface {
+		NotifyInvitationSent(ctx context.Context, event *Event) error
+		NotifyGatheringCancelled(ctx context.Context, event *Event) error
+		NotifyReminderDue(ctx context.Context, event *Event) error
+		NotifyInvitationPromoted(ctx context.Context, event *Event) error
+		NotifyEmailInvitationSent(ctx context.Context, event *Event) error
+	}
+)
+
+const (
+	InvitationEmail   = EmailTemplate("invitation")
+	ReminderEmail     = EmailTemplate("reminder")
+	CancellationEmail = EmailTemplate("cancellation")
+	PromotionEmail    = EmailTemplate("promotion")
+	// EmailInvitationEmail invites someone who is not a member yet, its Member only has an Email
+	EmailInvitationEmail = EmailTemplate("email_invitation")
+)
+
+// EmailTemplates lists every template the notifiers have to render
+var EmailTemplates = []EmailTemplate{
+	InvitationEmail,
+	ReminderEmail,
+	CancellationEmail,
+	PromotionEmail,
+	EmailInvitationEmail,
+}
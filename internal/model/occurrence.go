@@ -0,0 +1,251 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+type (
+	// GatheringOccurrenceOverride reschedules or cancels one occurrence of a recurring gathering,
+	// OccurrenceAt is the start the recurrence rule gives the occurrence
+	GatheringOccurrenceOverride struct {
+		ID           int64      `json:"id"`
+		GatheringID  int64      `json:"gathering_id"`
+		OccurrenceAt time.Time  `json:"occurrence_at"`
+		ScheduledAt  *time.Time `json:"scheduled_at"`
+		EndsAt       *time.Time `json:"ends_at"`
+		Cancelled    bool       `json:"cancelled"`
+		CreatedAt    time.Time  `json:"created_at"`
+		UpdatedAt    time.Time  `json:"updated_at"`
+	}
+
+	// Occurrence is one instance of a gathering, a gathering that does not recur has a single occurrence.
+	// OccurrenceAt identifies the occurrence, it stays the same when the occurrence is rescheduled
+	Occurrence struct {
+		GatheringID  int64      `json:"gathering_id"`
+		OccurrenceAt time.Time  `json:"occurrence_at"`
+		ScheduledAt  time.Time  `json:"scheduled_at"`
+		EndsAt       *time.Time `json:"ends_at"`
+		Cancelled    bool       `json:"cancelled"`
+		Overridden   bool       `json:"overridden"`
+		Gathering    *Gathering `json:"-"`
+	}
+
+	GatheringOccurrenceRepository interface {
+		FindByGatheringID(ctx context.Context, gatheringID int64) ([]*GatheringOccurrenceOverride, error)
+		FindByGatheringIDs(ctx context.Context, gatheringIDs []int64) ([]*GatheringOccurrenceOverride, error)
+		Save(ctx context.Context, override *GatheringOccurrenceOverride) error
+		DeleteByGatheringIDAndOccurrenceAt(ctx context.Context, gatheringID int64, occurrenceAt time.Time) error
+	}
+
+	OccurrenceUsecase interface {
+		FindOccurrences(ctx context.Context, gatheringID int64, from, to time.Time, limit int) ([]*Occurrence, error)
+		RescheduleOccurrence(ctx context.Context, override *GatheringOccurrenceOverride) (*Occurrence, error)
+		CancelOccurrence(ctx context.Context, gatheringID int64, occurrenceAt time.Time) (*Occurrence, error)
+		ResetOccurrence(ctx context.Context, gatheringID int64, occurrenceAt time.Time) (*Occurrence, error)
+	}
+)
+
+const (
+	// MaxRecurrenceLength of the RRULE of a gathering
+	MaxRecurrenceLength = 255
+
+	// DefaultOccurrencesLimit how many occurrences are listed when no limit is given
+	DefaultOccurrencesLimit = 50
+	// MaxOccurrencesLimit caps how many occurrences are listed at once
+	MaxOccurrencesLimit = 500
+)
+
+// IsRecurring reports whether the gathering repeats following its recurrence rule
+func (g *Gathering) IsRecurring() bool {
+	return g.Recurrence != "" && g.ScheduledAt != nil
+}
+
+// RecurrenceRule parses the RRULE of the gathering, it starts at the gathering start in the gathering time zone
+// so the occurrences keep their local time across daylight saving changes.
+// Only daily, weekly and monthly rules are supported, and at most one occurrence a day
+func (g *Gathering) RecurrenceRule() (*rrule.RRule, error) {
+	if g.ScheduledAt == nil {
+		return nil, errors.New("a recurring gathering needs a start")
+	}
+	if len(g.Recurrence) > MaxRecurrenceLength || strings.ContainsAny(g.Recurrence, "\r\n") {
+		return nil, fmt.Errorf("recurrence must be a single RRULE of at most %d characters", MaxRecurrenceLength)
+	}
+
+	loc := g.TimeLocation()
+	option, err := rrule.StrToROptionInLocation(g.Recurrence, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case option.Freq != rrule.DAILY && option.Freq != rrule.WEEKLY && option.Freq != rrule.MONTHLY:
+		return nil, errors.New("recurrence FREQ must be DAILY, WEEKLY or MONTHLY")
+	case !option.Dtstart.IsZero():
+		return nil, errors.New("recurrence starts with the gathering, DTSTART is not allowed")
+	case len(option.Byhour) > 0 || len(option.Byminute) > 0 || len(option.Bysecond) > 0:
+		return nil, errors.New("recurrence can't repeat within a day")
+	case option.Count < 0 || option.Interval < 0:
+		return nil, errors.New("recurrence COUNT and INTERVAL must be positive")
+	}
+
+	option.Dtstart = g.ScheduledAt.In(loc)
+	return rrule.NewRRule(*option)
+}
+
+// HasOccurrence reports whether the recurrence rule gives an occurrence starting at occurrenceAt
+func (g *Gathering) HasOccurrence(occurrenceAt time.Time) bool {
+	if !g.IsRecurring() {
+		return false
+	}
+	rule, err := g.RecurrenceRule()
+	if err != nil {
+		return false
+	}
+	return len(rule.Between(occurrenceAt, occurrenceAt, true)) > 0
+}
+
+// Occurrences expands the gathering into the occurrences whose rule start is between from and to, at most limit of
+// them, with the overrides applied. An override moving an occurrence out of the window keeps it listed
+func (g *Gathering) Occurrences(from, to time.Time, limit int, overrides []*GatheringOccurrenceOverride) ([]*Occurrence, error) {
+	if g.ScheduledAt == nil || limit <= 0 {
+		return nil, nil
+	}
+
+	var starts []time.Time
+	if !g.IsRecurring() {
+		if !g.ScheduledAt.Before(from) && !g.ScheduledAt.After(to) {
+			starts = append(starts, *g.ScheduledAt)
+		}
+	} else {
+		rule, err := g.RecurrenceRule()
+		if err != nil {
+			return nil, err
+		}
+		next := rule.Iterator()
+		for len(starts) < limit {
+			start, ok := next()
+			if !ok || start.After(to) {
+				break
+			}
+			if !start.Before(from) {
+				starts = append(starts, start)
+			}
+		}
+	}
+
+	return g.occurrencesAt(starts, overrides), nil
+}
+
+// Occurrence is the occurrence of the gathering starting at occurrenceAt with its override applied
+func (g *Gathering) Occurrence(occurrenceAt time.Time, overrides []*GatheringOccurrenceOverride) *Occurrence {
+	return g.occurrencesAt([]time.Time{occurrenceAt}, overrides)[0]
+}
+
+func (g *Gathering) occurrencesAt(starts []time.Time, overrides []*GatheringOccurrenceOverride) []*Occurrence {
+	overridesByStart := make(map[int64]*GatheringOccurrenceOverride, len(overrides))
+	for _, override := range overrides {
+		if override.GatheringID == g.ID {
+			overridesByStart[override.OccurrenceAt.Unix()] = override
+		}
+	}
+
+	var duration time.Duration
+	if g.ScheduledAt != nil && g.EndsAt != nil {
+		duration = g.EndsAt.Sub(*g.ScheduledAt)
+	}
+
+	loc := g.TimeLocation()
+	occurrences := make([]*Occurrence, 0, len(starts))
+	for _, start := range starts {
+		occurrence := &Occurrence{
+			GatheringID:  g.ID,
+			OccurrenceAt: start.In(loc),
+			ScheduledAt:  start.In(loc),
+			Cancelled:    g.DeletedAt.Valid,
+			Gathering:    g,
+		}
+
+		override, ok := overridesByStart[start.Unix()]
+		if ok {
+			occurrence.Overridden = true
+			occurrence.Cancelled = occurrence.Cancelled || override.Cancelled
+			if override.ScheduledAt != nil {
+				occurrence.ScheduledAt = override.ScheduledAt.In(loc)
+			}
+		}
+
+		switch {
+		case ok && override.EndsAt != nil:
+			occurrence.EndsAt = inLocation(override.EndsAt, loc)
+		case duration > 0:
+			endsAt := occurrence.ScheduledAt.Add(duration)
+			occurrence.EndsAt = &endsAt
+		}
+
+		occurrences = append(occurrences, occurrence)
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].ScheduledAt.Before(occurrences[j].ScheduledAt)
+	})
+	return occurrences
+}
+
+// NextOccurrence is the first occurrence of the gathering that is not cancelled and starts after after, nil when there
+// is none. At most MaxOccurrencesLimit starts of a recurring gathering are looked at
+func (g *Gathering) NextOccurrence(after time.Time, overrides []*GatheringOccurrenceOverride) (*Occurrence, error) {
+	if g.ScheduledAt == nil || g.DeletedAt.Valid {
+		return nil, nil
+	}
+	if !g.IsRecurring() {
+		if !g.ScheduledAt.After(after) {
+			return nil, nil
+		}
+		return g.Occurrence(*g.ScheduledAt, nil), nil
+	}
+
+	rule, err := g.RecurrenceRule()
+	if err != nil {
+		return nil, err
+	}
+
+	// an overridden occurrence may start after after whatever its rule start, the others start at their rule start so
+	// the first of them that starts after after ends the search
+	overridden := make(map[int64]bool, len(overrides))
+	var starts []time.Time
+	for _, override := range overrides {
+		if override.GatheringID != g.ID {
+			continue
+		}
+		overridden[override.OccurrenceAt.Unix()] = true
+		if override.ScheduledAt != nil && override.ScheduledAt.After(after) && !override.OccurrenceAt.After(after) {
+			starts = append(starts, override.OccurrenceAt)
+		}
+	}
+
+	start := after
+	for i := 0; i < MaxOccurrencesLimit; i++ {
+		start = rule.After(start, false)
+		if start.IsZero() {
+			break
+		}
+		starts = append(starts, start)
+		if !overridden[start.Unix()] {
+			break
+		}
+	}
+
+	for _, occurrence := range g.occurrencesAt(starts, overrides) {
+		if !occurrence.Cancelled && occurrence.ScheduledAt.After(after) {
+			return occurrence, nil
+		}
+	}
+	return nil, nil
+}
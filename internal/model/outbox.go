@@ -0,0 +1,72 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// OutboxMessage an event stored in the same transaction as the change it describes, waiting to be relayed to the subscribers.
+	// A message that ran out of attempts is dead-lettered and is not relayed again until it is replayed.
+	OutboxMessage struct {
+		ID             int64      `json:"id"`
+		EventType      EventType  `json:"event_type"`
+		Payload        []byte     `json:"payload"`
+		OccurredAt     time.Time  `json:"occurred_at"`
+		Attempts       int        `json:"attempts"`
+		LastError      string     `json:"last_error"`
+		NextAttemptAt  time.Time  `json:"next_attempt_at"`
+		DeliveredAt    *time.Time `json:"delivered_at"`
+		DeadLetteredAt *time.Time `json:"dead_lettered_at"`
+		CreatedAt      time.Time  `json:"created_at"`
+		UpdatedAt      time.Time  `json:"updated_at"`
+	}
+
+	OutboxRepository interface {
+		Create(ctx context.Context, messages []*OutboxMessage) error
+		FindByID(ctx context.Context, messageID int64) (*OutboxMessage, error)
+		FindDeadLettered(ctx context.Context, limit int) ([]*OutboxMessage, error)
+		ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*OutboxMessage, error)
+		MarkDelivered(ctx context.Context, messageID int64, deliveredAt time.Time) error
+		MarkFailed(ctx context.Context, messageID int64, lastError string, nextAttemptAt time.Time) error
+		MarkDeadLettered(ctx context.Context, messageID int64, lastError string, deadLetteredAt time.Time) error
+		Replay(ctx context.Context, messageID int64, now time.Time) error
+	}
+
+	OutboxUsecase interface {
+		FindDeadLetteredMessages(ctx context.Context, limit int) ([]*OutboxMessage, error)
+		ReplayMessageByID(ctx context.Context, messageID int64) (*OutboxMessage, error)
+	}
+)
+
+const (
+	// DefaultDeadLetteredLimit how many dead-lettered messages are listed when no limit is given
+	DefaultDeadLetteredLimit = 50
+	// MaxDeadLetteredLimit how many dead-lettered messages can be listed at once
+	MaxDeadLetteredLimit = 500
+)
+
+func (m *OutboxMessage) TableName() string {
+	return "outbox"
+}
+
+// NewOutboxMessage wraps the event into a message due right away
+func NewOutboxMessage(event *Event) *OutboxMessage {
+	return &OutboxMessage{
+		ID:            event.ID,
+		EventType:     event.Type,
+		Payload:       event.Payload,
+		OccurredAt:    event.OccurredAt,
+		NextAttemptAt: event.OccurredAt,
+	}
+}
+
+// Event returns the event carried by the message
+func (m *OutboxMessage) Event() *Event {
+	return &Event{
+		ID:         m.ID,
+		Type:       m.EventType,
+		OccurredAt: m.OccurredAt,
+		Payload:    m.Payload,
+	}
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// GatheringReminder reminds the attendees OffsetMinutes before the gathering starts,
+	// RemindAt is nil while the gathering is not scheduled
+	GatheringReminder struct {
+		ID            int64      `json:"id"`
+		GatheringID   int64      `json:"gathering_id"`
+		OffsetMinutes int        `json:"offset_minutes"`
+		RemindAt      *time.Time `json:"remind_at"`
+		SentAt        *time.Time `json:"sent_at"`
+		CreatedAt     time.Time  `json:"created_at"`
+		UpdatedAt     time.Time  `json:"updated_at"`
+	}
+
+	GatheringReminderRepository interface {
+		FindByGatheringID(ctx context.Context, gatheringID int64) ([]*GatheringReminder, error)
+		Save(ctx context.Context, gatheringID int64, reminders []*GatheringReminder) error
+		LockDue(ctx context.Context, now time.Time, limit int) ([]*GatheringReminder, error)
+		MarkSent(ctx context.Context, reminderIDs []int64, sentAt time.Time) error
+		Reschedule(ctx context.Context, reminderID int64, remindAt time.Time) error
+	}
+
+	ReminderUsecase interface {
+		FindRemindersByGatheringID(ctx context.Context, gatheringID int64) ([]*GatheringReminder, error)
+		UpdateRemindersByGatheringID(ctx context.Context, gatheringID int64, offsetMinutes []int) ([]*GatheringReminder, error)
+		DispatchDue(ctx context.Context) (int, error)
+	}
+)
+
+// MaxReminderOffsetMinutes how long before a gathering its earliest reminder can be sent, 30 days
+const MaxReminderOffsetMinutes = 30 * 24 * 60
+
+// NewGatheringReminder creates a reminder OffsetMinutes before the gathering
+func NewGatheringReminder(gathering *Gathering, offsetMinutes int, now time.Time) *GatheringReminder {
+	reminder := &GatheringReminder{
+		ID:            GenerateID(),
+		GatheringID:   gathering.ID,
+		OffsetMinutes: offsetMinutes,
+	}
+	reminder.Schedule(gathering.ScheduledAt, now)
+	return reminder
+}
+
+// Schedule computes when the reminder is due for a gathering starting at scheduledAt,
+// a reminder already sent is sent again when it becomes due in the future
+func (r *GatheringReminder) Schedule(scheduledAt *time.Time, now time.Time) {
+	if scheduledAt == nil {
+		r.RemindAt = nil
+		return
+	}
+
+	remindAt := scheduledAt.Add(-time.Duration(r.OffsetMinutes) * time.Minute)
+	r.RemindAt = &remindAt
+	if remindAt.After(now) {
+		r.SentAt = nil
+	}
+}
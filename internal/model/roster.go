@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// RosterEntry is a row of the roster of a gathering, one per invited member
+type RosterEntry struct {
+	MemberID  int64
+	FirstName string
+	LastName  string
+	Email     string
+	Status    InvitationStatus
+	Guests    int
+	// Waitlisted is set when the member waits for a seat of the full gathering
+	Waitlisted bool
+	// CheckedInAt is when the member checked in, nil until they do
+	CheckedInAt *time.Time
+}
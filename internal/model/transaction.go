@@ -0,0 +1,11 @@
+package model
+
+import "context"
+
+type (
+	TransactionManager interface {
+		// WithTransaction runs fn in a single database transaction, repositories called with the ctx passed to fn join it.
+		// The transaction is rolled back when fn returns an error and committed otherwise.
+		WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+)
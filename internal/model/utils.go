@@ -1,6 +1,8 @@
 package model
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
 	"math/rand"
 	"time"
 )
@@ -8,3 +10,12 @@ import (
 func GenerateID() int64 {
 	return time.Now().UnixNano() + int64(rand.Intn(10000))
 }
+
+// GenerateSecret returns a random hex encoded 256 bit secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
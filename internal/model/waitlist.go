@@ -0,0 +1,34 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// WaitlistEntry holds the place of a member invited to a full gathering, the entries of a gathering are
+	// promoted in ID order
+	WaitlistEntry struct {
+		ID          int64 `json:"id"`
+		GatheringID int64 `json:"gathering_id"`
+		MemberID    int64 `json:"member_id"`
+		// Position on the waitlist of the gathering starting at 1, it is computed when the entries are read
+		Position  int64     `json:"position" gorm:"->"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	WaitlistRepository interface {
+		Create(ctx context.Context, entry *WaitlistEntry) error
+		FindByGatheringIDAndMemberID(ctx context.Context, gatheringID int64, memberID int64) (*WaitlistEntry, error)
+		FindByGatheringID(ctx context.Context, gatheringID int64) ([]*WaitlistEntry, error)
+		FindByMemberID(ctx context.Context, memberID int64) ([]*WaitlistEntry, error)
+		FindFirstByGatheringID(ctx context.Context, gatheringID int64) (*WaitlistEntry, error)
+		DeleteByGatheringIDAndMemberID(ctx context.Context, gatheringID int64, memberID int64) error
+		DeleteByGatheringID(ctx context.Context, gatheringID int64) error
+	}
+
+	WaitlistUsecase interface {
+		FindWaitlistByGatheringID(ctx context.Context, gatheringID int64) ([]*WaitlistEntry, error)
+		FindWaitlistByMemberID(ctx context.Context, memberID int64) ([]*WaitlistEntry, error)
+	}
+)
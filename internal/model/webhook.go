@@ -0,0 +1,105 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type (
+	WebhookDeliveryStatus string
+
+	WebhookSubscription struct {
+		ID         int64          `json:"id"`
+		Owner      int64          `json:"owner"`
+		URL        string         `json:"url"`
+		EventTypes []EventType    `json:"event_types" gorm:"serializer:json"`
+		Secret     string         `json:"-"`
+		CreatedAt  time.Time      `json:"created_at"`
+		UpdatedAt  time.Time      `json:"updated_at"`
+		DeletedAt  gorm.DeletedAt `json:"deleted_at"`
+	}
+
+	// WebhookDelivery one event sent to one subscription, Payload is the JSON body posted to the subscription URL
+	WebhookDelivery struct {
+		ID             int64                 `json:"id"`
+		SubscriptionID int64                 `json:"subscription_id"`
+		EventID        int64                 `json:"event_id"`
+		EventType      EventType             `json:"event_type"`
+		Payload        json.RawMessage       `json:"payload"`
+		Status         WebhookDeliveryStatus `json:"status"`
+		Attempts       int                   `json:"attempts"`
+		ResponseCode   int                   `json:"response_code"`
+		LastError      string                `json:"last_error"`
+		NextAttemptAt  time.Time             `json:"next_attempt_at"`
+		DeliveredAt    *time.Time            `json:"delivered_at"`
+		CreatedAt      time.Time             `json:"created_at"`
+		UpdatedAt      time.Time             `json:"updated_at"`
+	}
+
+	WebhookSubscriptionRepository interface {
+		Create(ctx context.Context, subscription *WebhookSubscription) error
+		FindByID(ctx context.Context, subscriptionID int64) (*WebhookSubscription, error)
+		FindByOwner(ctx context.Context, owner int64) ([]*WebhookSubscription, error)
+		FindByEventType(ctx context.Context, eventType EventType) ([]*WebhookSubscription, error)
+		DeleteByID(ctx context.Context, subscriptionID int64) (*WebhookSubscription, error)
+	}
+
+	WebhookDeliveryRepository interface {
+		Create(ctx context.Context, deliveries []*WebhookDelivery) error
+		FindByID(ctx context.Context, deliveryID int64) (*WebhookDelivery, error)
+		FindBySubscriptionID(ctx context.Context, subscriptionID int64) ([]*WebhookDelivery, error)
+		ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error)
+		Update(ctx context.Context, delivery *WebhookDelivery) error
+	}
+
+	// WebhookSender posts a delivery to the subscription URL and returns the response status code, CheckURL returns
+	// the error the sender would refuse the URL with
+	WebhookSender interface {
+		CheckURL(ctx context.Context, rawURL string) error
+		Send(ctx context.Context, subscription *WebhookSubscription, delivery *WebhookDelivery) (int, error)
+	}
+
+	WebhookUsecase interface {
+		CreateSubscription(ctx context.Context, subscription *WebhookSubscription) error
+		FindSubscriptionByID(ctx context.Context, subscriptionID, owner int64) (*WebhookSubscription, error)
+		FindSubscriptionsByOwner(ctx context.Context, owner int64) ([]*WebhookSubscription, error)
+		DeleteSubscriptionByID(ctx context.Context, subscriptionID, owner int64) (*WebhookSubscription, error)
+		FindDeliveriesBySubscriptionID(ctx context.Context, subscriptionID, owner int64) ([]*WebhookDelivery, error)
+		ReplayDeliveryByID(ctx context.Context, deliveryID, owner int64) (*WebhookDelivery, error)
+		EnqueueDeliveries(ctx context.Context, event *Event) error
+		DeliverDue(ctx context.Context) (int, error)
+	}
+)
+
+const (
+	WebhookDeliveryPending   = WebhookDeliveryStatus("pending")
+	WebhookDeliverySucceeded = WebhookDeliveryStatus("succeeded")
+	WebhookDeliveryFailed    = WebhookDeliveryStatus("failed")
+)
+
+func (w *WebhookSubscription) ImmutableColumns() []string {
+	return []string{"created_at", "deleted_at"}
+}
+
+// Subscribes reports whether the subscription wants to receive events of the given type
+func (w *WebhookSubscription) Subscribes(eventType EventType) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEventType reports whether eventType is published by the usecases
+func IsEventType(eventType EventType) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
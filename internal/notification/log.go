@@ -0,0 +1,29 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier writes the emails to the log instead of sending them, it is used when no SMTP server is configured
+func NewLogNotifier() model.Notifier {
+	return &logNotifier{}
+}
+
+func (l *logNotifier) Notify(ctx context.Context, template model.EmailTemplate, data *model.EmailData) error {
+	email, err := Render(template, data)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"to":       email.To,
+		"subject":  email.Subject,
+		"template": template,
+	}).Info("email not sent, no SMTP server configured")
+	return nil
+}
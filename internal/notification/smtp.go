@@ -0,0 +1,159 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+type smtpNotifier struct {
+	host string
+	addr string
+	auth smtp.Auth
+	from string
+	// sender is the bare address of from, used as the envelope sender
+	sender  string
+	timeout time.Duration
+}
+
+// NewSMTPNotifier sends the emails through the SMTP server at host:port, it authenticates only when username is set
+func NewSMTPNotifier(host string, port int, username, password, from string, timeout time.Duration) model.Notifier {
+	notifier := &smtpNotifier{
+		host:    host,
+		addr:    net.JoinHostPort(host, strconv.Itoa(port)),
+		from:    from,
+		sender:  from,
+		timeout: timeout,
+	}
+	if address, err := mail.ParseAddress(from); err == nil {
+		notifier.sender = address.Address
+	}
+	if username != "" {
+		notifier.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return notifier
+}
+
+func (s *smtpNotifier) Notify(ctx context.Context, template model.EmailTemplate, data *model.EmailData) error {
+	email, err := Render(template, data)
+	if err != nil {
+		return err
+	}
+
+	msg, err := buildMessage(s.from, email)
+	if err != nil {
+		return err
+	}
+
+	return s.send(ctx, email.To, msg)
+}
+
+func (s *smtpNotifier) send(ctx context.Context, to string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if err = client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(s.sender); err != nil {
+		return err
+	}
+	if err = client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// buildMessage encodes the email as a multipart/alternative message with a text and an HTML part
+func buildMessage(from string, email *Email) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", email.Text},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err = qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err = qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", email.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n", parts.Boundary())
+	fmt.Fprintf(&msg, "\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,154 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTPMessage is what the fake SMTP server received in one session
+type fakeSMTPMessage struct {
+	auth string
+	from string
+	to   string
+	data []byte
+}
+
+// startFakeSMTPServer accepts a single SMTP session on localhost and hands over what it received,
+// it answers MAIL with rejectMail when it is set
+func startFakeSMTPServer(t *testing.T, rejectMail string) (int, <-chan fakeSMTPMessage) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan fakeSMTPMessage, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		msg := fakeSMTPMessage{}
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch verb {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250-localhost")
+				tp.PrintfLine("250 AUTH PLAIN")
+			case "AUTH":
+				msg.auth = strings.TrimPrefix(line, "AUTH PLAIN ")
+				tp.PrintfLine("235 2.7.0 Authentication successful")
+			case "MAIL":
+				if rejectMail != "" {
+					tp.PrintfLine(rejectMail)
+					continue
+				}
+				msg.from = line
+				tp.PrintfLine("250 OK")
+			case "RCPT":
+				msg.to = line
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				msg.data, err = tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 Bye")
+				received <- msg
+				return
+			default:
+				tp.PrintfLine("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSMTPNotify(t *testing.T) {
+	data := &model.EmailData{
+		Member:    &model.Member{FirstName: "John", Email: "john@doe.com"},
+		Gathering: &model.Gathering{Name: "Board games", Location: "locc"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		port, received := startFakeSMTPServer(t, "")
+		notifier := NewSMTPNotifier("127.0.0.1", port, "user", "pass", "Gathering App <no-reply@gathering.app>", time.Second)
+
+		err := notifier.Notify(context.TODO(), model.InvitationEmail, data)
+		assert.NoError(t, err)
+
+		msg := <-received
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("\x00user\x00pass")), msg.auth)
+		assert.Equal(t, "MAIL FROM:<no-reply@gathering.app>", strings.SplitN(msg.from, " BODY", 2)[0])
+		assert.Equal(t, "RCPT TO:<john@doe.com>", msg.to)
+
+		parsed, err := mail.ReadMessage(bytes.NewReader(msg.data))
+		assert.NoError(t, err)
+		assert.Equal(t, "You are invited to Board games", parsed.Header.Get("Subject"))
+		assert.Equal(t, "john@doe.com", parsed.Header.Get("To"))
+
+		mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", mediaType)
+
+		var contentTypes []string
+		parts := multipart.NewReader(parsed.Body, params["boundary"])
+		for {
+			part, err := parts.NextPart()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+
+			body, err := io.ReadAll(part)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), "Board games")
+			contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		}
+		assert.Equal(t, []string{"text/plain; charset=utf-8", "text/html; charset=utf-8"}, contentTypes)
+	})
+
+	t.Run("rejected sender", func(t *testing.T) {
+		port, _ := startFakeSMTPServer(t, "550 5.7.1 Sender rejected")
+		notifier := NewSMTPNotifier("127.0.0.1", port, "", "", "no-reply@gathering.app", time.Second)
+
+		err := notifier.Notify(context.TODO(), model.InvitationEmail, data)
+		assert.Error(t, err)
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		port := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+
+		notifier := NewSMTPNotifier("127.0.0.1", port, "", "", "no-reply@gathering.app", time.Second)
+
+		err = notifier.Notify(context.TODO(), model.InvitationEmail, data)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// templates are parsed once, every model.EmailTemplate has a "<name>.txt" defining the "subject" and a "<name>.html"
+var (
+	textTemplates = map[model.EmailTemplate]*texttemplate.Template{}
+	htmlTemplates = map[model.EmailTemplate]*htmltemplate.Template{}
+)
+
+func init() {
+	for _, name := range model.EmailTemplates {
+		textTemplates[name] = texttemplate.Must(texttemplate.ParseFS(templateFS, fmt.Sprintf("templates/%s.txt", name)))
+		htmlTemplates[name] = htmltemplate.Must(htmltemplate.ParseFS(templateFS, fmt.Sprintf("templates/%s.html", name)))
+	}
+}
+
+// Email is a rendered email, ready to be sent
+type Email struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Render renders the text, HTML and subject of the template for data.Member
+func Render(name model.EmailTemplate, data *model.EmailData) (*Email, error) {
+	textTemplate, ok := textTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := textTemplate.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := htmlTemplates[name].Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	return &Email{
+		To:      data.Member.Email,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
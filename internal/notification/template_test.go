@@ -0,0 +1,111 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	scheduledAt := time.Date(2021, 11, 22, 19, 30, 0, 0, time.UTC)
+
+	data := &model.EmailData{
+		Member: &model.Member{
+			FirstName: "John",
+			Email:     "john@doe.com",
+		},
+		Gathering: &model.Gathering{
+			Name:        "Board <games>",
+			Location:    "locc",
+			ScheduledAt: &scheduledAt,
+		},
+	}
+
+	t.Run("every template renders", func(t *testing.T) {
+		for _, template := range model.EmailTemplates {
+			email, err := Render(template, data)
+			assert.NoError(t, err)
+			assert.Equal(t, "john@doe.com", email.To)
+			assert.Contains(t, email.Subject, "Board <games>")
+			assert.Contains(t, email.Text, "Hi John,")
+			assert.Contains(t, email.HTML, "Board &lt;games&gt;")
+		}
+	})
+
+	t.Run("invitation", func(t *testing.T) {
+		email, err := Render(model.InvitationEmail, data)
+		assert.NoError(t, err)
+		assert.Equal(t, "You are invited to Board <games>", email.Subject)
+		assert.Contains(t, email.Text, "on Monday, 22 November 2021 at 19:30 UTC at locc.")
+	})
+
+	t.Run("waitlisted invitation", func(t *testing.T) {
+		email, err := Render(model.InvitationEmail, &model.EmailData{
+			Member:     data.Member,
+			Gathering:  data.Gathering,
+			Invitation: &model.Invitation{WaitlistPosition: 3},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, email.Text, "you are number 3 on its waitlist")
+		assert.Contains(t, email.HTML, "you are number 3 on its waitlist")
+	})
+
+	t.Run("invitation with guests", func(t *testing.T) {
+		email, err := Render(model.InvitationEmail, &model.EmailData{
+			Member:     data.Member,
+			Gathering:  data.Gathering,
+			Invitation: &model.Invitation{AllowedGuests: 2},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, email.Text, "You can bring up to 2 guests")
+		assert.Contains(t, email.HTML, "You can bring up to 2 guests")
+		assert.NotContains(t, email.Text, "waitlist")
+	})
+
+	t.Run("promotion", func(t *testing.T) {
+		email, err := Render(model.PromotionEmail, data)
+		assert.NoError(t, err)
+		assert.Equal(t, "A seat is yours at Board <games>", email.Subject)
+		assert.Contains(t, email.Text, "you are off the waitlist of Board <games>")
+	})
+
+	t.Run("email invitation", func(t *testing.T) {
+		email, err := Render(model.EmailInvitationEmail, &model.EmailData{
+			Member:    &model.Member{Email: "jane@doe.com"},
+			Gathering: data.Gathering,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "jane@doe.com", email.To)
+		assert.Contains(t, email.Text, "Hi,")
+		assert.Contains(t, email.HTML, "Hi,")
+	})
+
+	t.Run("gathering time zone", func(t *testing.T) {
+		email, err := Render(model.ReminderEmail, &model.EmailData{
+			Member: data.Member,
+			Gathering: &model.Gathering{
+				Name:        "Board games",
+				ScheduledAt: &scheduledAt,
+				TimeZone:    "Asia/Jakarta",
+			},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, email.Text, "on Tuesday, 23 November 2021 at 02:30 WIB.")
+	})
+
+	t.Run("unscheduled gathering", func(t *testing.T) {
+		email, err := Render(model.CancellationEmail, &model.EmailData{
+			Member:    data.Member,
+			Gathering: &model.Gathering{Name: "Board games"},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, email.Text, "that Board games has been cancelled.")
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, err := Render("unknown", data)
+		assert.Error(t, err)
+	})
+}
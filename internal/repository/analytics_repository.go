@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) model.AnalyticsRepository {
+	return &analyticsRepository{
+		db: db,
+	}
+}
+
+// CountInvitationFunnel counts the invitations sent within the window of the scope by their status, and averages
+// how long the accepted ones took to be accepted
+func (a *analyticsRepository) CountInvitationFunnel(ctx context.Context, scope *model.AnalyticsScope) (*model.InvitationFunnel, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"scope": scope,
+	})
+
+	condition, arg := analyticsScopeCondition(scope)
+
+	var funnel model.InvitationFunnel
+	err := connFromContext(ctx, a.db).Model(&model.Invitation{}).
+		Select("COUNT(*) AS sent, "+
+			"COALESCE(SUM(invitations.status = ?), 0) AS pending, "+
+			"COALESCE(SUM(invitations.status = ?), 0) AS accepted, "+
+			"COALESCE(SUM(invitations.status = ?), 0) AS declined, "+
+			"COALESCE(SUM(invitations.status = ?), 0) AS expired, "+
+			"AVG(TIMESTAMPDIFF(SECOND, invitations.invited_at, invitations.accepted_at)) AS average_acceptance_seconds",
+			model.Pending, model.Active, model.Declined, model.Expired).
+		Joins(joinAnalyticsGatherings("invitations")).
+		Where(condition, arg).
+		Where("invitations.invited_at >= ? AND invitations.invited_at < ?", scope.From, scope.To).
+		Scan(&funnel).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &funnel, nil
+}
+
+// CountSeatUsage sums the seats taken and the capacity of the capacity-limited gatherings of the scope. A creator
+// scope only counts the gatherings scheduled within its window, a gathering scope counts the gathering regardless
+func (a *analyticsRepository) CountSeatUsage(ctx context.Context, scope *model.AnalyticsScope) (*model.SeatUsage, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"scope": scope,
+	})
+
+	condition, arg := analyticsScopeCondition(scope)
+
+	query := connFromContext(ctx, a.db).Model(&model.Gathering{}).
+		Select("COALESCE(SUM((SELECT COALESCE(SUM(1 + attendees.guests), 0) FROM attendees "+
+			"WHERE attendees.gathering_id = gatherings.id AND attendees.deleted_at IS NULL)), 0) AS taken, "+
+			"COALESCE(SUM(gatherings.capacity), 0) AS capacity").
+		Where("gatherings.type = ? AND gatherings.capacity > 0", model.WithFixedNumberOfAttendees).
+		Where(condition, arg)
+	if scope.GatheringID == 0 {
+		query = query.Where("gatherings.scheduled_at >= ? AND gatherings.scheduled_at < ?", scope.From, scope.To)
+	}
+
+	var usage model.SeatUsage
+	if err := query.Scan(&usage).Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+// CountDaily counts by UTC day the invitations sent, the invitations accepted and the attendees checked in within
+// the window of the scope, the days without any are left out
+func (a *analyticsRepository) CountDaily(ctx context.Context, scope *model.AnalyticsScope) ([]*model.DailyAnalytics, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"scope": scope,
+	})
+
+	condition, arg := analyticsScopeCondition(scope)
+
+	var daily []*model.DailyAnalytics
+	err := connFromContext(ctx, a.db).Raw("SELECT day, SUM(sent) AS sent, SUM(accepted) AS accepted, SUM(checked_in) AS checked_in FROM ("+
+		"SELECT DATE_FORMAT(invitations.invited_at, '%Y-%m-%d') AS day, 1 AS sent, 0 AS accepted, 0 AS checked_in "+
+		"FROM invitations "+joinAnalyticsGatherings("invitations")+" "+
+		"WHERE "+condition+" AND invitations.deleted_at IS NULL AND invitations.invited_at >= ? AND invitations.invited_at < ? "+
+		"UNION ALL "+
+		"SELECT DATE_FORMAT(invitations.accepted_at, '%Y-%m-%d'), 0, 1, 0 "+
+		"FROM invitations "+joinAnalyticsGatherings("invitations")+" "+
+		"WHERE "+condition+" AND invitations.deleted_at IS NULL AND invitations.accepted_at >= ? AND invitations.accepted_at < ? "+
+		"UNION ALL "+
+		"SELECT DATE_FORMAT(attendees.checked_in_at, '%Y-%m-%d'), 0, 0, 1 "+
+		"FROM attendees "+joinAnalyticsGatherings("attendees")+" "+
+		"WHERE "+condition+" AND attendees.deleted_at IS NULL AND attendees.checked_in_at >= ? AND attendees.checked_in_at < ?"+
+		") AS daily GROUP BY day ORDER BY day",
+		arg, scope.From, scope.To,
+		arg, scope.From, scope.To,
+		arg, scope.From, scope.To).
+		Scan(&daily).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return daily, nil
+}
+
+// analyticsScopeCondition selects the gathering of the scope, or every gathering of its creator
+func analyticsScopeCondition(scope *model.AnalyticsScope) (string, int64) {
+	if scope.GatheringID != 0 {
+		return "gatherings.id = ?", scope.GatheringID
+	}
+	return "gatherings.creator = ?", scope.Creator
+}
+
+// joinAnalyticsGatherings joins the table to the gatherings its rows belong to, the deleted gatherings are left out
+func joinAnalyticsGatherings(table string) string {
+	return "JOIN gatherings ON gatherings.id = " + table + ".gathering_id AND gatherings.deleted_at IS NULL"
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeAnalyticsRepositoryWithMock(mockDB *gorm.DB) *analyticsRepository {
+	return &analyticsRepository{
+		db: mockDB,
+	}
+}
+
+func TestCountInvitationFunnelAnalyticsRepo(t *testing.T) {
+	from := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success, gathering", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAnalyticsRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT COUNT\\(\\*\\) AS sent, (.*) AS average_acceptance_seconds FROM `invitations` "+
+			"JOIN gatherings ON gatherings.id = invitations.gathering_id AND gatherings.deleted_at IS NULL "+
+			"WHERE gatherings.id = \\? AND \\(invitations.invited_at >= \\? AND invitations.invited_at < \\?\\) AND `invitations`.`deleted_at` IS NULL").
+			WithArgs(model.Pending, model.Active, model.Declined, model.Expired, int64(123), from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"sent", "pending", "accepted", "declined", "expired", "average_acceptance_seconds"}).
+				AddRow(10, 2, 5, 2, 1, "3600.5000"))
+
+		res, err := repo.CountInvitationFunnel(context.TODO(), &model.AnalyticsScope{GatheringID: 123, From: from, To: to})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), res.Sent)
+		assert.Equal(t, int64(2), res.Pending)
+		assert.Equal(t, int64(5), res.Accepted)
+		assert.Equal(t, int64(2), res.Declined)
+		assert.Equal(t, int64(1), res.Expired)
+		assert.Equal(t, 3600.5, *res.AverageAcceptanceSeconds)
+	})
+
+	t.Run("success, creator without accepted invitations", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAnalyticsRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT (.*) WHERE gatherings.creator = \\?").
+			WithArgs(model.Pending, model.Active, model.Declined, model.Expired, int64(111), from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"sent", "pending", "accepted", "declined", "expired", "average_acceptance_seconds"}).
+				AddRow(3, 3, 0, 0, 0, nil))
+
+		res, err := repo.CountInvitationFunnel(context.TODO(), &model.AnalyticsScope{Creator: 111, From: from, To: to})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res.Pending)
+		assert.Nil(t, res.AverageAcceptanceSeconds)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAnalyticsRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.CountInvitationFunnel(context.TODO(), &model.AnalyticsScope{GatheringID: 123, From: from, To: to})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCountSeatUsageAnalyticsRepo(t *testing.T) {
+	from := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success, gathering", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAnalyticsRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT (.*) AS taken, COALESCE\\(SUM\\(gatherings.capacity\\), 0\\) AS capacity FROM `gatherings` "+
+			"WHERE \\(gatherings.type = \\? AND gatherings.capacity > 0\\) AND gatherings.id = \\? AND `gatherings`.`deleted_at` IS NULL$").
+			WithArgs(model.WithFixedNumberOfAttendees, int64(123)).
+			WillReturnRows(sqlmock.NewRows([]string{"taken", "capacity"}).AddRow(15, 20))
+
+		res, err := repo.CountSeatUsage(context.TODO(), &model.AnalyticsScope{GatheringID: 123, From: from, To: to})
+		assert.NoError(t, err)
+		assert.Equal(t, &model.SeatUsage{Taken: 15, Capacity: 20}, res)
+	})
+
+	t.Run("success, creator counts the gatherings scheduled within the window", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAnalyticsRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT (.*) FROM `gatherings` WHERE (.*) AND gatherings.creator = \\? "+
+			"AND \\(gatherings.scheduled_at >= \\? AND gatherings.scheduled_at < \\?\\)").
+			WithArgs(model.WithFixedNumberOfAttendees, int64(111), from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"taken", "capacity"}).AddRow(0, 0))
+
+		res, err := repo.CountSeatUsage(context.TODO(), &model.AnalyticsScope{Creator: 111, From: from, To: to})
+		assert.NoError(t, err)
+		assert.Equal(t, &model.SeatUsage{}, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAnalyticsRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.CountSeatUsage(context.TODO(), &model.AnalyticsScope{GatheringID: 123, From: from, To: to})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCountDailyAnalyticsRepo(t *testing.T) {
+	from := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAnalyticsRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT day, SUM\\(sent\\) AS sent, SUM\\(accepted\\) AS accepted, SUM\\(checked_in\\) AS checked_in FROM \\("+
+			"SELECT DATE_FORMAT\\(invitations.invited_at, '%Y-%m-%d'\\) (.*) "+
+			"UNION ALL SELECT DATE_FORMAT\\(invitations.accepted_at, '%Y-%m-%d'\\)(.*) "+
+			"UNION ALL SELECT DATE_FORMAT\\(attendees.checked_in_at, '%Y-%m-%d'\\)(.*)"+
+			"\\) AS daily GROUP BY day ORDER BY day").
+			WithArgs(int64(123), from, to, int64(123), from, to, int64(123), from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"day", "sent", "accepted", "checked_in"}).
+				AddRow("2021-11-20", 4, 1, 0).
+				AddRow("2021-11-22", 0, 2, 3))
+
+		res, err := repo.CountDaily(context.TODO(), &model.AnalyticsScope{GatheringID: 123, From: from, To: to})
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.DailyAnalytics{
+			{Day: "2021-11-20", Sent: 4, Accepted: 1},
+			{Day: "2021-11-22", Accepted: 2, CheckedIn: 3},
+		}, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAnalyticsRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.CountDaily(context.TODO(), &model.AnalyticsScope{Creator: 111, From: from, To: to})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type attendeeRepository struct {
@@ -25,8 +27,39 @@ func (a *attendeeRepository) Create(ctx context.Context, attendee *model.Attende
 		"attendee": attendee,
 	})
 
-	tx := a.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, a.db)
 	err := tx.Create(attendee).Error
+	if err != nil {
+		tx.Rollback()
+		if isDuplicateEntryError(err) {
+			return model.ErrDuplicateEntry
+		}
+		logger.Error(err)
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Revive creates the attendee, or restores it when a soft-deleted row already exists, the restored attendee has
+// not checked in yet
+func (a *attendeeRepository) Revive(ctx context.Context, attendee *model.Attendee) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"attendee": attendee,
+	})
+
+	tx := beginTx(ctx, a.db)
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"occurrence_at": attendee.OccurrenceAt,
+			"guests":        attendee.Guests,
+			"checked_in_at": nil,
+			"cancelled_at":  nil,
+			"deleted_at":    nil,
+			"updated_at":    time.Now(),
+		}),
+	}).Create(attendee).Error
 	if err != nil {
 		logger.Error(err)
 		tx.Rollback()
@@ -44,7 +77,7 @@ func (a *attendeeRepository) FindByMemberID(ctx context.Context, memberID int64)
 
 	var attendees []*model.Attendee
 
-	err := a.db.Where(&model.Attendee{MemberID: memberID}).Find(&attendees).Error
+	err := connFromContext(ctx, a.db).Where(&model.Attendee{MemberID: memberID}).Find(&attendees).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -64,7 +97,7 @@ func (a *attendeeRepository) FindByGatheringID(ctx context.Context, gatheringID
 
 	var attendees []*model.Attendee
 
-	err := a.db.Where(&model.Attendee{GatheringID: gatheringID}).Find(&attendees).Error
+	err := connFromContext(ctx, a.db).Where(&model.Attendee{GatheringID: gatheringID}).Find(&attendees).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -76,6 +109,159 @@ func (a *attendeeRepository) FindByGatheringID(ctx context.Context, gatheringID
 	return attendees, nil
 }
 
+// CountSeatsByGatheringID counts the seats taken on the gathering, every attending member with their guests
+func (a *attendeeRepository) CountSeatsByGatheringID(ctx context.Context, gatheringID int64) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var seats int64
+	err := connFromContext(ctx, a.db).Model(&model.Attendee{}).
+		Select("COALESCE(SUM(1 + guests), 0)").
+		Where(&model.Attendee{GatheringID: gatheringID}).
+		Scan(&seats).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return seats, nil
+}
+
+func (a *attendeeRepository) UpdateGuestsByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64, guests int) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"memberID":    memberID,
+		"gatheringID": gatheringID,
+		"guests":      guests,
+	})
+
+	tx := beginTx(ctx, a.db)
+	err := tx.Model(&model.Attendee{}).
+		Where("member_id = ? AND gathering_id = ?", memberID, gatheringID).
+		Update("guests", guests).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (a *attendeeRepository) FindByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64) (*model.Attendee, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"memberID":    memberID,
+		"gatheringID": gatheringID,
+	})
+
+	var attendee model.Attendee
+	err := connFromContext(ctx, a.db).
+		Where("member_id = ? AND gathering_id = ?", memberID, gatheringID).
+		Take(&attendee).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &attendee, nil
+}
+
+func (a *attendeeRepository) FindByCheckInCode(ctx context.Context, code string) (*model.Attendee, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	var attendee model.Attendee
+	err := connFromContext(ctx, a.db).
+		Where("check_in_code = ?", code).
+		Take(&attendee).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &attendee, nil
+}
+
+// UpdateCheckInCode gives the attendee the check-in code unless they already have one, so two requests racing to
+// create the code never replace the code the other one handed out
+func (a *attendeeRepository) UpdateCheckInCode(ctx context.Context, memberID int64, gatheringID int64, code string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"memberID":    memberID,
+		"gatheringID": gatheringID,
+	})
+
+	tx := beginTx(ctx, a.db)
+	err := tx.Model(&model.Attendee{}).
+		Where("member_id = ? AND gathering_id = ? AND check_in_code IS NULL", memberID, gatheringID).
+		Update("check_in_code", code).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// UpdateCheckedInAt records when the attendee checked in, nil undoes the check-in. It reports false when the
+// attendee was already checked in, or not checked in for an undo, so of two concurrent scans only one succeeds
+func (a *attendeeRepository) UpdateCheckedInAt(ctx context.Context, memberID int64, gatheringID int64, checkedInAt *time.Time) (bool, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"memberID":    memberID,
+		"gatheringID": gatheringID,
+		"checkedInAt": checkedInAt,
+	})
+
+	state := "checked_in_at IS NULL"
+	if checkedInAt == nil {
+		state = "checked_in_at IS NOT NULL"
+	}
+
+	tx := beginTx(ctx, a.db)
+	res := tx.Model(&model.Attendee{}).
+		Where("member_id = ? AND gathering_id = ? AND "+state, memberID, gatheringID).
+		Update("checked_in_at", checkedInAt)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, tx.Commit().Error
+}
+
+// FindAttendanceByGatheringID counts the people expected at the gathering and those who checked in, a checked in
+// member brings their guests along
+func (a *attendeeRepository) FindAttendanceByGatheringID(ctx context.Context, gatheringID int64) (*model.Attendance, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	attendance := model.Attendance{GatheringID: gatheringID}
+	err := connFromContext(ctx, a.db).Model(&model.Attendee{}).
+		Select("COALESCE(SUM(1 + guests), 0) AS expected, " +
+			"COALESCE(SUM(CASE WHEN checked_in_at IS NULL THEN 0 ELSE 1 + guests END), 0) AS checked_in").
+		Where(&model.Attendee{GatheringID: gatheringID}).
+		Scan(&attendance).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &attendance, nil
+}
+
 func (a *attendeeRepository) DeleteByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64) (*model.Attendee, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":         ctx,
@@ -85,7 +271,7 @@ func (a *attendeeRepository) DeleteByMemberIDAndGatheringID(ctx context.Context,
 
 	var attendee model.Attendee
 
-	tx := a.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, a.db)
 	err := tx.Where(&model.Attendee{MemberID: memberID, GatheringID: gatheringID}).
 		Delete(&model.Attendee{}).Error
 	if err != nil {
@@ -100,7 +286,7 @@ func (a *attendeeRepository) DeleteByMemberIDAndGatheringID(ctx context.Context,
 		return nil, err
 	}
 
-	err = a.db.WithContext(ctx).Unscoped().
+	err = connFromContext(ctx, a.db).Unscoped().
 		Where(&model.Attendee{MemberID: memberID, GatheringID: gatheringID}).
 		Find(&attendee).Error
 	if err != nil {
@@ -110,3 +296,112 @@ func (a *attendeeRepository) DeleteByMemberIDAndGatheringID(ctx context.Context,
 
 	return &attendee, nil
 }
+
+// DeleteByMemberID soft deletes every attendee row of the member
+func (a *attendeeRepository) DeleteByMemberID(ctx context.Context, memberID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	tx := beginTx(ctx, a.db)
+	err := tx.Where(&model.Attendee{MemberID: memberID}).Delete(&model.Attendee{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteByGatheringID soft deletes every attendee row of the gathering
+// DeleteByGatheringID deletes the attendees of the cancelled gathering and marks them cancelled, the rows of the
+// members who left before are kept as they were
+func (a *attendeeRepository) DeleteByGatheringID(ctx context.Context, gatheringID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	now := time.Now()
+	tx := beginTx(ctx, a.db)
+	err := tx.Model(&model.Attendee{}).
+		Where(&model.Attendee{GatheringID: gatheringID}).
+		Updates(map[string]interface{}{
+			"cancelled_at": now,
+			"deleted_at":   now,
+		}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// RestoreByMemberIDAndGatheringID undoes the soft delete and the cancellation, it returns nil when there is no
+// deleted attendee to restore
+func (a *attendeeRepository) RestoreByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64) (*model.Attendee, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"memberID":    memberID,
+		"gatheringID": gatheringID,
+	})
+
+	tx := beginTx(ctx, a.db)
+	res := tx.Unscoped().Model(&model.Attendee{}).
+		Where("member_id = ? AND gathering_id = ? AND deleted_at IS NOT NULL", memberID, gatheringID).
+		Updates(map[string]interface{}{
+			"cancelled_at": nil,
+			"deleted_at":   nil,
+		})
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return nil, res.Error
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var attendee model.Attendee
+	err := connFromContext(ctx, a.db).
+		Where(&model.Attendee{MemberID: memberID, GatheringID: gatheringID}).
+		Take(&attendee).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &attendee, nil
+}
+
+// FindCancelledByMemberID finds the attendee rows of the member that were deleted along with their cancelled
+// gathering, the rows of members who left before the cancellation are not marked cancelled
+func (a *attendeeRepository) FindCancelledByMemberID(ctx context.Context, memberID int64) ([]*model.Attendee, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	var attendees []*model.Attendee
+
+	err := connFromContext(ctx, a.db).Unscoped().
+		Joins("JOIN gatherings ON gatherings.id = attendees.gathering_id").
+		Where("attendees.member_id = ? AND attendees.cancelled_at IS NOT NULL AND gatherings.deleted_at IS NOT NULL", memberID).
+		Find(&attendees).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return attendees, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -36,7 +37,7 @@ func TestCreateAttendeeRepo(t *testing.T) {
 		mockQuery.ExpectBegin()
 		mockQuery.ExpectExec("INSERT INTO `attendees`").
 			WithArgs(attendee.MemberID,
-				attendee.GatheringID, attendee.CreatedAt, attendee.UpdatedAt, attendee.DeletedAt).
+				attendee.GatheringID, attendee.OccurrenceAt, attendee.Guests, attendee.CheckInCode, attendee.CheckedInAt, attendee.CancelledAt, attendee.CreatedAt, attendee.UpdatedAt, attendee.DeletedAt).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 		mockQuery.ExpectCommit()
 
@@ -61,6 +62,19 @@ func TestCreateAttendeeRepo(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("duplicate attendee", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `attendees`").
+			WillReturnError(&mysql.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry"})
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), attendee)
+		assert.ErrorIs(t, err, model.ErrDuplicateEntry)
+	})
+
 	t.Run("error commit", func(t *testing.T) {
 		dbMock, mockQuery := initializeMySQLMockConn()
 		repo := initializeAttendeeRepositoryWithMock(dbMock)
@@ -270,3 +284,343 @@ func TestDeleteAttendeeByMemberIDAndGatheringIDRepo(t *testing.T) {
 		assert.Nil(t, attendeeRes)
 	})
 }
+
+func TestReviveAttendeeRepo(t *testing.T) {
+	attendee := &model.Attendee{
+		MemberID:    321,
+		GatheringID: 222,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `attendees` (.*) ON DUPLICATE KEY UPDATE `cancelled_at`=\\?,`checked_in_at`=\\?,`deleted_at`=\\?,`guests`=\\?,`occurrence_at`=\\?,`updated_at`=\\?").
+			WithArgs(attendee.MemberID, attendee.GatheringID, nil, 0, nil, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil, nil, nil, 0, nil, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.Revive(context.TODO(), attendee)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `attendees`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Revive(context.TODO(), attendee)
+		assert.Error(t, err)
+	})
+}
+
+func TestRestoreAttendeeByMemberIDAndGatheringIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees` SET `cancelled_at`=\\?,`deleted_at`=\\?,`updated_at`=\\? WHERE member_id = \\? AND gathering_id = \\? AND deleted_at IS NOT NULL").
+			WithArgs(nil, nil, sqlmock.AnyArg(), int64(321), int64(222)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnRows(sqlmock.NewRows([]string{"member_id", "gathering_id"}).AddRow(321, 222))
+
+		res, err := repo.RestoreByMemberIDAndGatheringID(context.TODO(), 321, 222)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(321), res.MemberID)
+	})
+
+	t.Run("nothing to restore", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.RestoreByMemberIDAndGatheringID(context.TODO(), 321, 222)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		res, err := repo.RestoreByMemberIDAndGatheringID(context.TODO(), 321, 222)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestDeleteAttendeeByMemberIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees` SET `deleted_at`=\\? WHERE `attendees`.`member_id` = \\? AND `attendees`.`deleted_at` IS NULL").
+			WithArgs(sqlmock.AnyArg(), int64(321)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.DeleteByMemberID(context.TODO(), 321)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on delete error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.DeleteByMemberID(context.TODO(), 321)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteAttendeeByGatheringIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees` SET `cancelled_at`=\\?,`deleted_at`=\\?,`updated_at`=\\? WHERE `attendees`.`gathering_id` = \\? AND `attendees`.`deleted_at` IS NULL").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(222)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.DeleteByGatheringID(context.TODO(), 222)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on delete error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.DeleteByGatheringID(context.TODO(), 222)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindCancelledAttendeesByMemberIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT `attendees`.`member_id`,(.*) FROM `attendees` JOIN gatherings ON gatherings.id = attendees.gathering_id " +
+			"WHERE attendees.member_id = \\? AND attendees.cancelled_at IS NOT NULL AND gatherings.deleted_at IS NOT NULL$").
+			WithArgs(int64(321)).
+			WillReturnRows(sqlmock.NewRows([]string{"member_id", "gathering_id", "deleted_at"}).
+				AddRow(321, 222, time.Now()))
+
+		res, err := repo.FindCancelledByMemberID(context.TODO(), 321)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, int64(222), res[0].GatheringID)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.FindCancelledByMemberID(context.TODO(), 321)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCountSeatsByGatheringIDAttendeeRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT COALESCE\\(SUM\\(1 \\+ guests\\), 0\\) FROM `attendees` WHERE `attendees`.`gathering_id` = \\? AND `attendees`.`deleted_at` IS NULL").
+			WithArgs(int64(222)).
+			WillReturnRows(sqlmock.NewRows([]string{"seats"}).AddRow(5))
+
+		res, err := repo.CountSeatsByGatheringID(context.TODO(), 222)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		_, err := repo.CountSeatsByGatheringID(context.TODO(), 222)
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateGuestsByMemberIDAndGatheringIDAttendeeRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees` SET `guests`=\\?,`updated_at`=\\? WHERE \\(member_id = \\? AND gathering_id = \\?\\) AND `attendees`.`deleted_at` IS NULL").
+			WithArgs(2, sqlmock.AnyArg(), int64(321), int64(222)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.UpdateGuestsByMemberIDAndGatheringID(context.TODO(), 321, 222, 2)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.UpdateGuestsByMemberIDAndGatheringID(context.TODO(), 321, 222, 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindByCheckInCodeAttendeeRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `attendees` WHERE check_in_code = \\? AND `attendees`.`deleted_at` IS NULL LIMIT 1").
+			WithArgs("code").
+			WillReturnRows(sqlmock.NewRows([]string{"member_id", "gathering_id", "check_in_code"}).
+				AddRow(321, 222, "code"))
+
+		res, err := repo.FindByCheckInCode(context.TODO(), "code")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(321), res.MemberID)
+		assert.Equal(t, "code", *res.CheckInCode)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs("code").
+			WillReturnRows(sqlmock.NewRows([]string{"member_id"}))
+
+		res, err := repo.FindByCheckInCode(context.TODO(), "code")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUpdateCheckInCodeAttendeeRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("UPDATE `attendees` SET `check_in_code`=\\?,`updated_at`=\\? WHERE \\(member_id = \\? AND gathering_id = \\? AND check_in_code IS NULL\\) AND `attendees`.`deleted_at` IS NULL").
+		WithArgs("code", sqlmock.AnyArg(), int64(321), int64(222)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockQuery.ExpectCommit()
+
+	err := repo.UpdateCheckInCode(context.TODO(), 321, 222, "code")
+	assert.NoError(t, err)
+}
+
+func TestUpdateCheckedInAtAttendeeRepo(t *testing.T) {
+	checkedInAt := time.Date(2021, 11, 22, 19, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees` SET `checked_in_at`=\\?,`updated_at`=\\? WHERE \\(member_id = \\? AND gathering_id = \\? AND checked_in_at IS NULL\\) AND `attendees`.`deleted_at` IS NULL").
+			WithArgs(&checkedInAt, sqlmock.AnyArg(), int64(321), int64(222)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		updated, err := repo.UpdateCheckedInAt(context.TODO(), 321, 222, &checkedInAt)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+	})
+
+	t.Run("already checked in by another scan", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees`").
+			WithArgs(&checkedInAt, sqlmock.AnyArg(), int64(321), int64(222)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		updated, err := repo.UpdateCheckedInAt(context.TODO(), 321, 222, &checkedInAt)
+		assert.NoError(t, err)
+		assert.False(t, updated)
+	})
+
+	t.Run("undo", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees` SET `checked_in_at`=\\?,`updated_at`=\\? WHERE \\(member_id = \\? AND gathering_id = \\? AND checked_in_at IS NOT NULL\\)").
+			WithArgs(nil, sqlmock.AnyArg(), int64(321), int64(222)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		updated, err := repo.UpdateCheckedInAt(context.TODO(), 321, 222, nil)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `attendees`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		_, err := repo.UpdateCheckedInAt(context.TODO(), 321, 222, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindAttendanceByGatheringIDAttendeeRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeAttendeeRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectQuery("SELECT COALESCE\\(SUM\\(1 \\+ guests\\), 0\\) AS expected, COALESCE\\(SUM\\(CASE WHEN checked_in_at IS NULL THEN 0 ELSE 1 \\+ guests END\\), 0\\) AS checked_in FROM `attendees`").
+		WithArgs(int64(222)).
+		WillReturnRows(sqlmock.NewRows([]string{"expected", "checked_in"}).AddRow(7, 3))
+
+	res, err := repo.FindAttendanceByGatheringID(context.TODO(), 222)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Attendance{GatheringID: 222, Expected: 7, CheckedIn: 3}, res)
+}
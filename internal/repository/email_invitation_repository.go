@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type emailInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailInvitationRepository(db *gorm.DB) model.EmailInvitationRepository {
+	return &emailInvitationRepository{
+		db: db,
+	}
+}
+
+func (e *emailInvitationRepository) Create(ctx context.Context, invitation *model.EmailInvitation) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        ctx,
+		"invitation": invitation,
+	})
+
+	tx := beginTx(ctx, e.db)
+	err := tx.Create(invitation).Error
+	if err != nil {
+		tx.Rollback()
+		if isDuplicateEntryError(err) {
+			return model.ErrDuplicateEntry
+		}
+		logger.Error(err)
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (e *emailInvitationRepository) FindByID(ctx context.Context, invitationID int64) (*model.EmailInvitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"invitationID": invitationID,
+	})
+
+	var invitation model.EmailInvitation
+	err := connFromContext(ctx, e.db).Take(&invitation, invitationID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindByEmail finds the invitations addressed to the normalized email, oldest first
+func (e *emailInvitationRepository) FindByEmail(ctx context.Context, email string) ([]*model.EmailInvitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"email": email,
+	})
+
+	var invitations []*model.EmailInvitation
+	err := connFromContext(ctx, e.db).Where("email = ?", email).Order("created_at").Find(&invitations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (e *emailInvitationRepository) FindByGatheringID(ctx context.Context, gatheringID int64) ([]*model.EmailInvitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var invitations []*model.EmailInvitation
+	err := connFromContext(ctx, e.db).Where("gathering_id = ?", gatheringID).Order("created_at").Find(&invitations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (e *emailInvitationRepository) DeleteByID(ctx context.Context, invitationID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"invitationID": invitationID,
+	})
+
+	tx := beginTx(ctx, e.db)
+	err := tx.Delete(&model.EmailInvitation{}, invitationID).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeEmailInvitationRepositoryWithMock(mockDB *gorm.DB) *emailInvitationRepository {
+	return &emailInvitationRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateEmailInvitationRepo(t *testing.T) {
+	invitation := &model.EmailInvitation{ID: 123, GatheringID: 444, Email: "john@doe.com", Status: model.Pending}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeEmailInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `email_invitations` \\(`gathering_id`,`email`,`status`,`occurrence_at`,`created_at`,`updated_at`,`id`\\)").
+			WillReturnResult(sqlmock.NewResult(123, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), invitation)
+		assert.NoError(t, err)
+	})
+
+	t.Run("duplicate email invitation", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeEmailInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `email_invitations`").
+			WillReturnError(&mysql.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry"})
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), invitation)
+		assert.ErrorIs(t, err, model.ErrDuplicateEntry)
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeEmailInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `email_invitations`").WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), invitation)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, model.ErrDuplicateEntry)
+	})
+}
+
+func TestFindByEmailEmailInvitationRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeEmailInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `email_invitations` WHERE email = \\? ORDER BY created_at").
+			WithArgs("john@doe.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "gathering_id", "email"}).
+				AddRow(1, 444, "john@doe.com").
+				AddRow(2, 555, "john@doe.com"))
+
+		res, err := repo.FindByEmail(context.TODO(), "john@doe.com")
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeEmailInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByEmail(context.TODO(), "john@doe.com")
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindByIDEmailInvitationRepo(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeEmailInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `email_invitations` WHERE `email_invitations`.`id` = \\? LIMIT 1").
+			WithArgs(int64(123)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestDeleteByIDEmailInvitationRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeEmailInvitationRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("DELETE FROM `email_invitations` WHERE `email_invitations`.`id` = \\?").
+		WithArgs(int64(123)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockQuery.ExpectCommit()
+
+	err := repo.DeleteByID(context.TODO(), 123)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDuplicateEntry is the MySQL error number for a unique constraint violation
+const mysqlErrDuplicateEntry = 1062
+
+func isDuplicateEntryError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
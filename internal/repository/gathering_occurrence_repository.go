@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gatheringOccurrenceRepository struct {
+	db *gorm.DB
+}
+
+func NewGatheringOccurrenceRepository(db *gorm.DB) model.GatheringOccurrenceRepository {
+	return &gatheringOccurrenceRepository{
+		db: db,
+	}
+}
+
+func (g *gatheringOccurrenceRepository) FindByGatheringID(ctx context.Context, gatheringID int64) ([]*model.GatheringOccurrenceOverride, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var overrides []*model.GatheringOccurrenceOverride
+	err := connFromContext(ctx, g.db).
+		Where("gathering_id = ?", gatheringID).
+		Order("occurrence_at").
+		Find(&overrides).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return overrides, nil
+}
+
+func (g *gatheringOccurrenceRepository) FindByGatheringIDs(ctx context.Context, gatheringIDs []int64) ([]*model.GatheringOccurrenceOverride, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"gatheringIDs": gatheringIDs,
+	})
+
+	var overrides []*model.GatheringOccurrenceOverride
+	if len(gatheringIDs) == 0 {
+		return overrides, nil
+	}
+
+	err := connFromContext(ctx, g.db).
+		Where("gathering_id IN ?", gatheringIDs).
+		Order("occurrence_at").
+		Find(&overrides).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// Save creates the override of the occurrence, or replaces the existing one
+func (g *gatheringOccurrenceRepository) Save(ctx context.Context, override *model.GatheringOccurrenceOverride) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"override": override,
+	})
+
+	tx := beginTx(ctx, g.db)
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"scheduled_at", "ends_at", "cancelled", "updated_at"}),
+	}).Create(override).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (g *gatheringOccurrenceRepository) DeleteByGatheringIDAndOccurrenceAt(ctx context.Context, gatheringID int64, occurrenceAt time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"gatheringID":  gatheringID,
+		"occurrenceAt": occurrenceAt,
+	})
+
+	tx := beginTx(ctx, g.db)
+	err := tx.Where("gathering_id = ? AND occurrence_at = ?", gatheringID, occurrenceAt).
+		Delete(&model.GatheringOccurrenceOverride{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
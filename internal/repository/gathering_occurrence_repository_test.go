@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeGatheringOccurrenceRepositoryWithMock(mockDB *gorm.DB) *gatheringOccurrenceRepository {
+	return &gatheringOccurrenceRepository{
+		db: mockDB,
+	}
+}
+
+func TestFindByGatheringIDsGatheringOccurrenceRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringOccurrenceRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `gathering_occurrence_overrides` WHERE gathering_id IN \\(\\?,\\?\\) ORDER BY occurrence_at").
+			WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "gathering_id", "cancelled"}).
+				AddRow(10, 1, true))
+
+		res, err := repo.FindByGatheringIDs(context.TODO(), []int64{1, 2})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.True(t, res[0].Cancelled)
+	})
+
+	t.Run("no gathering, no query", func(t *testing.T) {
+		dbMock, _ := initializeMySQLMockConn()
+		repo := initializeGatheringOccurrenceRepositoryWithMock(dbMock)
+
+		res, err := repo.FindByGatheringIDs(context.TODO(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestSaveGatheringOccurrenceRepo(t *testing.T) {
+	occurrenceAt := time.Now().UTC()
+	override := &model.GatheringOccurrenceOverride{ID: 10, GatheringID: 1, OccurrenceAt: occurrenceAt, Cancelled: true}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringOccurrenceRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `gathering_occurrence_overrides` .* ON DUPLICATE KEY UPDATE `scheduled_at`=VALUES\\(`scheduled_at`\\),`ends_at`=VALUES\\(`ends_at`\\),`cancelled`=VALUES\\(`cancelled`\\),`updated_at`=VALUES\\(`updated_at`\\)").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Save(context.TODO(), override)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringOccurrenceRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `gathering_occurrence_overrides`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Save(context.TODO(), override)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteByGatheringIDAndOccurrenceAtGatheringOccurrenceRepo(t *testing.T) {
+	occurrenceAt := time.Now().UTC()
+
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeGatheringOccurrenceRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("DELETE FROM `gathering_occurrence_overrides` WHERE gathering_id = \\? AND occurrence_at = \\?").
+		WithArgs(int64(1), occurrenceAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockQuery.ExpectCommit()
+
+	err := repo.DeleteByGatheringIDAndOccurrenceAt(context.TODO(), 1, occurrenceAt)
+	assert.NoError(t, err)
+}
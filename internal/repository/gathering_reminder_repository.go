@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gatheringReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewGatheringReminderRepository(db *gorm.DB) model.GatheringReminderRepository {
+	return &gatheringReminderRepository{
+		db: db,
+	}
+}
+
+func (g *gatheringReminderRepository) FindByGatheringID(ctx context.Context, gatheringID int64) ([]*model.GatheringReminder, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var reminders []*model.GatheringReminder
+	err := connFromContext(ctx, g.db).
+		Where("gathering_id = ?", gatheringID).
+		Order("offset_minutes DESC").
+		Find(&reminders).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// Save replaces the reminder schedule of the gathering, the reminders that are not given are deleted
+func (g *gatheringReminderRepository) Save(ctx context.Context, gatheringID int64, reminders []*model.GatheringReminder) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"reminders":   reminders,
+	})
+
+	reminderIDs := make([]int64, 0, len(reminders))
+	for _, reminder := range reminders {
+		reminderIDs = append(reminderIDs, reminder.ID)
+	}
+
+	tx := beginTx(ctx, g.db)
+	query := tx.Where("gathering_id = ?", gatheringID)
+	if len(reminderIDs) > 0 {
+		query = query.Where("id NOT IN ?", reminderIDs)
+	}
+	err := query.Delete(&model.GatheringReminder{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if len(reminders) > 0 {
+		err = tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"remind_at", "sent_at", "updated_at"}),
+		}).Create(reminders).Error
+		if err != nil {
+			logger.Error(err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// LockDue locks the unsent reminders that are due, skipping the ones locked by another dispatcher.
+// The locks are held until the transaction carried by ctx ends, so it has to be called inside one
+func (g *gatheringReminderRepository) LockDue(ctx context.Context, now time.Time, limit int) ([]*model.GatheringReminder, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"now":   now,
+		"limit": limit,
+	})
+
+	var reminders []*model.GatheringReminder
+	err := connFromContext(ctx, g.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("sent_at IS NULL AND remind_at <= ?", now).
+		Order("remind_at").
+		Limit(limit).
+		Find(&reminders).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (g *gatheringReminderRepository) MarkSent(ctx context.Context, reminderIDs []int64, sentAt time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"reminderIDs": reminderIDs,
+	})
+
+	if len(reminderIDs) == 0 {
+		return nil
+	}
+
+	tx := beginTx(ctx, g.db)
+	err := tx.Model(&model.GatheringReminder{}).
+		Where("id IN ?", reminderIDs).
+		Update("sent_at", sentAt).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Reschedule makes the reminder due again at remindAt, for the next occurrence of a recurring gathering
+func (g *gatheringReminderRepository) Reschedule(ctx context.Context, reminderID int64, remindAt time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        ctx,
+		"reminderID": reminderID,
+		"remindAt":   remindAt,
+	})
+
+	tx := beginTx(ctx, g.db)
+	err := tx.Model(&model.GatheringReminder{}).
+		Where("id = ?", reminderID).
+		Updates(map[string]interface{}{
+			"remind_at": remindAt,
+			"sent_at":   nil,
+		}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeGatheringReminderRepositoryWithMock(mockDB *gorm.DB) *gatheringReminderRepository {
+	return &gatheringReminderRepository{
+		db: mockDB,
+	}
+}
+
+func TestSaveGatheringReminderRepo(t *testing.T) {
+	remindAt := time.Now()
+	reminder := &model.GatheringReminder{ID: 10, GatheringID: 1, OffsetMinutes: 60, RemindAt: &remindAt}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringReminderRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("DELETE FROM `gathering_reminders` WHERE gathering_id = \\? AND id NOT IN \\(\\?\\)").
+			WithArgs(int64(1), int64(10)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectExec("INSERT INTO `gathering_reminders` .* ON DUPLICATE KEY UPDATE `remind_at`=VALUES\\(`remind_at`\\),`sent_at`=VALUES\\(`sent_at`\\),`updated_at`=VALUES\\(`updated_at`\\)").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Save(context.TODO(), 1, []*model.GatheringReminder{reminder})
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty schedule deletes every reminder", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringReminderRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("DELETE FROM `gathering_reminders` WHERE gathering_id = \\?$").
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.Save(context.TODO(), 1, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringReminderRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("DELETE FROM `gathering_reminders`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectExec("INSERT INTO `gathering_reminders`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Save(context.TODO(), 1, []*model.GatheringReminder{reminder})
+		assert.Error(t, err)
+	})
+}
+
+func TestLockDueGatheringReminderRepo(t *testing.T) {
+	now := time.Now()
+
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeGatheringReminderRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectQuery("SELECT \\* FROM `gathering_reminders` WHERE sent_at IS NULL AND remind_at <= \\? ORDER BY remind_at LIMIT 10 FOR UPDATE SKIP LOCKED").
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "gathering_id", "offset_minutes"}).
+			AddRow(10, 1, 60))
+
+	res, err := repo.LockDue(context.TODO(), now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, 60, res[0].OffsetMinutes)
+}
+
+func TestMarkSentGatheringReminderRepo(t *testing.T) {
+	now := time.Now()
+
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeGatheringReminderRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("UPDATE `gathering_reminders` SET `sent_at`=\\?,`updated_at`=\\? WHERE id IN \\(\\?,\\?\\)").
+		WithArgs(now, sqlmock.AnyArg(), int64(10), int64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mockQuery.ExpectCommit()
+
+	err := repo.MarkSent(context.TODO(), []int64{10, 11}, now)
+	assert.NoError(t, err)
+}
+
+func TestRescheduleGatheringReminderRepo(t *testing.T) {
+	remindAt := time.Now()
+
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeGatheringReminderRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("UPDATE `gathering_reminders` SET `remind_at`=\\?,`sent_at`=\\?,`updated_at`=\\? WHERE id = \\?").
+		WithArgs(remindAt, nil, sqlmock.AnyArg(), int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockQuery.ExpectCommit()
+
+	err := repo.Reschedule(context.TODO(), 10, remindAt)
+	assert.NoError(t, err)
+}
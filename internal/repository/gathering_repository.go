@@ -7,6 +7,7 @@ import (
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type gatheringRepository struct {
@@ -25,7 +26,7 @@ func (g *gatheringRepository) Create(ctx context.Context, gathering *model.Gathe
 		"gathering": gathering,
 	})
 
-	tx := g.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, g.db)
 	err := tx.Create(gathering).Error
 	if err != nil {
 		logger.Error(err)
@@ -43,7 +44,7 @@ func (g *gatheringRepository) FindByID(ctx context.Context, gatheringID int64) (
 	})
 
 	var gathering model.Gathering
-	err := g.db.WithContext(ctx).Take(&gathering, gatheringID).Error
+	err := connFromContext(ctx, g.db).Take(&gathering, gatheringID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -54,6 +55,51 @@ func (g *gatheringRepository) FindByID(ctx context.Context, gatheringID int64) (
 	return &gathering, err
 }
 
+// FindByIDsWithDeleted finds the gatherings, the soft deleted ones included, ordered by schedule
+func (g *gatheringRepository) FindByIDsWithDeleted(ctx context.Context, gatheringIDs []int64) ([]*model.Gathering, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"gatheringIDs": gatheringIDs,
+	})
+
+	var gatherings []*model.Gathering
+	if len(gatheringIDs) == 0 {
+		return gatherings, nil
+	}
+
+	err := connFromContext(ctx, g.db).Unscoped().
+		Where("id IN ?", gatheringIDs).
+		Order("scheduled_at").
+		Find(&gatherings).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return gatherings, nil
+}
+
+// LockByID finds the gathering and locks it until the transaction of ctx ends, so the changes to its seats
+// are serialized
+func (g *gatheringRepository) LockByID(ctx context.Context, gatheringID int64) (*model.Gathering, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var gathering model.Gathering
+	err := connFromContext(ctx, g.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&gathering, gatheringID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &gathering, nil
+}
+
 func (g *gatheringRepository) UpdateByID(ctx context.Context, gathering *model.Gathering) (*model.Gathering, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":       ctx,
@@ -70,7 +116,7 @@ func (g *gatheringRepository) UpdateByID(ctx context.Context, gathering *model.G
 		return nil, nil
 	}
 
-	tx := g.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, g.db)
 	err = tx.Model(gathering).Omit(gathering.ImmutableColumns()...).Save(gathering).Error
 	if err != nil {
 		tx.Rollback()
@@ -93,7 +139,7 @@ func (g *gatheringRepository) DeleteByID(ctx context.Context, gatheringID int64)
 	})
 
 	var gathering model.Gathering
-	tx := g.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, g.db)
 	err := tx.Find(&gathering, gatheringID).Delete(&gathering).Error
 	if err != nil {
 		logger.Error(err)
@@ -107,7 +153,7 @@ func (g *gatheringRepository) DeleteByID(ctx context.Context, gatheringID int64)
 		return nil, err
 	}
 
-	err = g.db.WithContext(ctx).Unscoped().Find(&gathering, gatheringID).Error
+	err = connFromContext(ctx, g.db).Unscoped().Find(&gathering, gatheringID).Error
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -115,3 +161,50 @@ func (g *gatheringRepository) DeleteByID(ctx context.Context, gatheringID int64)
 
 	return &gathering, nil
 }
+
+func (g *gatheringRepository) FindAllDeleted(ctx context.Context) ([]*model.Gathering, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	var gatherings []*model.Gathering
+	err := connFromContext(ctx, g.db).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&gatherings).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return gatherings, nil
+}
+
+// RestoreByID undoes the soft delete, it returns nil when there is no deleted gathering with the given id
+func (g *gatheringRepository) RestoreByID(ctx context.Context, gatheringID int64) (*model.Gathering, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	tx := beginTx(ctx, g.db)
+	res := tx.Unscoped().Model(&model.Gathering{}).
+		Where("id = ? AND deleted_at IS NOT NULL", gatheringID).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return nil, res.Error
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return g.FindByID(ctx, gatheringID)
+}
@@ -1 +1,130 @@
 package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeGatheringRepositoryWithMock(mockDB *gorm.DB) *gatheringRepository {
+	return &gatheringRepository{
+		db: mockDB,
+	}
+}
+
+func TestFindAllDeletedGatheringsRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `gatherings` WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).
+				AddRow(1, time.Now()))
+
+		res, err := repo.FindAllDeleted(context.TODO())
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.True(t, res[0].DeletedAt.Valid)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.FindAllDeleted(context.TODO())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRestoreGatheringByIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `gatherings` SET `deleted_at`=\\?,`updated_at`=\\? WHERE id = \\? AND deleted_at IS NOT NULL").
+			WithArgs(nil, sqlmock.AnyArg(), int64(123)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs(int64(123)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(123))
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(123), res.ID)
+	})
+
+	t.Run("nothing to restore", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `gatherings`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `gatherings`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindGatheringsByIDsWithDeletedRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `gatherings` WHERE id IN \\(\\?,\\?\\) ORDER BY scheduled_at$").
+			WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).
+				AddRow(1, nil).
+				AddRow(2, time.Now()))
+
+		res, err := repo.FindByIDsWithDeleted(context.TODO(), []int64{1, 2})
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.True(t, res[1].DeletedAt.Valid)
+	})
+
+	t.Run("empty ids", func(t *testing.T) {
+		dbMock, _ := initializeMySQLMockConn()
+		repo := initializeGatheringRepositoryWithMock(dbMock)
+
+		res, err := repo.FindByIDsWithDeleted(context.TODO(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByIDsWithDeleted(context.TODO(), []int64{1, 2})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gatheringRoleRepository struct {
+	db *gorm.DB
+}
+
+func NewGatheringRoleRepository(db *gorm.DB) model.GatheringRoleRepository {
+	return &gatheringRoleRepository{
+		db: db,
+	}
+}
+
+// Save gives the member their role on the gathering, it replaces the role they already had
+func (g *gatheringRoleRepository) Save(ctx context.Context, role *model.GatheringRole) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  ctx,
+		"role": role,
+	})
+
+	tx := beginTx(ctx, g.db)
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(role).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// FindByGatheringID finds the roles on the gathering, the owner first
+func (g *gatheringRoleRepository) FindByGatheringID(ctx context.Context, gatheringID int64) ([]*model.GatheringRole, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var roles []*model.GatheringRole
+	err := connFromContext(ctx, g.db).
+		Where("gathering_id = ?", gatheringID).
+		Clauses(clause.OrderBy{Expression: clause.Expr{SQL: "FIELD(role, ?, ?, ?), created_at", Vars: []interface{}{
+			model.GatheringOwner, model.GatheringCoHost, model.GatheringModerator,
+		}}}).
+		Find(&roles).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (g *gatheringRoleRepository) FindByGatheringIDAndMemberID(ctx context.Context, gatheringID int64, memberID int64) (*model.GatheringRole, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"memberID":    memberID,
+	})
+
+	var role model.GatheringRole
+	err := connFromContext(ctx, g.db).
+		Where("gathering_id = ? AND member_id = ?", gatheringID, memberID).
+		Take(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (g *gatheringRoleRepository) DeleteByGatheringIDAndMemberID(ctx context.Context, gatheringID int64, memberID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"memberID":    memberID,
+	})
+
+	tx := beginTx(ctx, g.db)
+	err := tx.Where("gathering_id = ? AND member_id = ?", gatheringID, memberID).
+		Delete(&model.GatheringRole{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeGatheringRoleRepositoryWithMock(mockDB *gorm.DB) *gatheringRoleRepository {
+	return &gatheringRoleRepository{
+		db: mockDB,
+	}
+}
+
+func TestSaveGatheringRoleRepo(t *testing.T) {
+	role := &model.GatheringRole{GatheringID: 1, MemberID: 10, Role: model.GatheringCoHost}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRoleRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `gathering_roles` (.*) ON DUPLICATE KEY UPDATE `role`=VALUES\\(`role`\\),`updated_at`=VALUES\\(`updated_at`\\)").
+			WithArgs(int64(1), int64(10), model.GatheringCoHost, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Save(context.TODO(), role)
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRoleRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `gathering_roles`").
+			WillReturnError(errors.New("some error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Save(context.TODO(), role)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindGatheringRolesByGatheringIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRoleRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `gathering_roles` WHERE gathering_id = \\? ORDER BY FIELD\\(role, \\?, \\?, \\?\\), created_at").
+			WithArgs(int64(1), model.GatheringOwner, model.GatheringCoHost, model.GatheringModerator).
+			WillReturnRows(sqlmock.NewRows([]string{"gathering_id", "member_id", "role"}).
+				AddRow(1, 10, "owner").
+				AddRow(1, 11, "co_host"))
+
+		res, err := repo.FindByGatheringID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, model.GatheringOwner, res[0].Role)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRoleRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByGatheringID(context.TODO(), 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindGatheringRoleByGatheringIDAndMemberIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRoleRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `gathering_roles` WHERE gathering_id = \\? AND member_id = \\? LIMIT 1").
+			WithArgs(int64(1), int64(10)).
+			WillReturnRows(sqlmock.NewRows([]string{"gathering_id", "member_id", "role"}).AddRow(1, 10, "moderator"))
+
+		res, err := repo.FindByGatheringIDAndMemberID(context.TODO(), 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, model.GatheringModerator, res.Role)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRoleRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `gathering_roles`").
+			WillReturnRows(sqlmock.NewRows([]string{"gathering_id"}))
+
+		res, err := repo.FindByGatheringIDAndMemberID(context.TODO(), 1, 10)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestDeleteGatheringRoleRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeGatheringRoleRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("DELETE FROM `gathering_roles` WHERE gathering_id = \\? AND member_id = \\?").
+			WithArgs(int64(1), int64(10)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.DeleteByGatheringIDAndMemberID(context.TODO(), 1, 10)
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+}
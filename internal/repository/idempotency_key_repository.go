@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) model.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		db: db,
+	}
+}
+
+func (i *idempotencyKeyRepository) Create(ctx context.Context, idempotencyKey *model.IdempotencyKey) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"idempotencyKey": idempotencyKey,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Create(idempotencyKey).Error
+	if err != nil {
+		tx.Rollback()
+		if isDuplicateEntryError(err) {
+			return model.ErrDuplicateEntry
+		}
+		logger.Error(err)
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (i *idempotencyKeyRepository) FindByKey(ctx context.Context, key string) (*model.IdempotencyKey, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+		"key": key,
+	})
+
+	var idempotencyKey model.IdempotencyKey
+	err := connFromContext(ctx, i.db).Where("`key` = ?", key).Take(&idempotencyKey).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
+
+// Reclaim takes over a key whose request was abandoned without storing a response once its lock passed,
+// it reports false when the key was completed or locked again in the meantime
+func (i *idempotencyKeyRepository) Reclaim(ctx context.Context, idempotencyKey *model.IdempotencyKey, now time.Time) (bool, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"idempotencyKey": idempotencyKey,
+	})
+
+	tx := beginTx(ctx, i.db)
+	res := tx.Model(&model.IdempotencyKey{}).
+		Where("`key` = ? AND response_code = 0 AND locked_until <= ?", idempotencyKey.Key, now).
+		Updates(map[string]interface{}{
+			"request_hash": idempotencyKey.RequestHash,
+			"locked_until": idempotencyKey.LockedUntil,
+			"expires_at":   idempotencyKey.ExpiresAt,
+		})
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, tx.Commit().Error
+}
+
+func (i *idempotencyKeyRepository) UpdateResponse(ctx context.Context, idempotencyKey *model.IdempotencyKey) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"idempotencyKey": idempotencyKey,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Model(idempotencyKey).
+		Select("response_code", "response_body", "updated_at").
+		Updates(idempotencyKey).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (i *idempotencyKeyRepository) DeleteByKey(ctx context.Context, key string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+		"key": key,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Where("`key` = ?", key).Delete(&model.IdempotencyKey{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteExpired deletes the keys that can no longer be replayed at the given time
+func (i *idempotencyKeyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+		"now": now,
+	})
+
+	tx := beginTx(ctx, i.db)
+	res := tx.Where("expires_at <= ?", now).Delete(&model.IdempotencyKey{})
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, tx.Commit().Error
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeIdempotencyKeyRepositoryWithMock(mockDB *gorm.DB) *idempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateIdempotencyKeyRepo(t *testing.T) {
+	dateString := "2021-11-22"
+	date, _ := time.Parse("2006-01-02", dateString)
+
+	idempotencyKey := &model.IdempotencyKey{
+		Key:         "key-1",
+		RequestHash: "hash",
+		LockedUntil: date.Add(time.Minute),
+		ExpiresAt:   date.Add(24 * time.Hour),
+		CreatedAt:   date,
+		UpdatedAt:   date,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `idempotency_keys`").
+			WithArgs(idempotencyKey.Key, idempotencyKey.RequestHash, idempotencyKey.ResponseCode,
+				idempotencyKey.ResponseBody, idempotencyKey.LockedUntil, idempotencyKey.ExpiresAt, idempotencyKey.CreatedAt, idempotencyKey.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), idempotencyKey)
+		assert.NoError(t, err)
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `idempotency_keys`").
+			WillReturnError(&mysql.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry"})
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), idempotencyKey)
+		assert.ErrorIs(t, err, model.ErrDuplicateEntry)
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `idempotency_keys`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), idempotencyKey)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, model.ErrDuplicateEntry)
+	})
+}
+
+func TestFindIdempotencyKeyByKeyRepo(t *testing.T) {
+	dateString := "2021-11-22"
+	date, _ := time.Parse("2006-01-02", dateString)
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs("key-1").
+			WillReturnRows(sqlmock.NewRows(
+				[]string{"key", "request_hash", "response_code", "response_body", "expires_at"},
+			).AddRow("key-1", "hash", 201, []byte(`{}`), date))
+
+		res, err := repo.FindByKey(context.TODO(), "key-1")
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, 201, res.ResponseCode)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs("key-1").
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByKey(context.TODO(), "key-1")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs("key-1").
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByKey(context.TODO(), "key-1")
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReclaimIdempotencyKeyRepo(t *testing.T) {
+	now := time.Now()
+	idempotencyKey := &model.IdempotencyKey{
+		Key:         "key-1",
+		RequestHash: "hash",
+		LockedUntil: now.Add(time.Minute),
+		ExpiresAt:   now.Add(24 * time.Hour),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `idempotency_keys` SET (.*) WHERE `key` = \\? AND response_code = 0 AND locked_until <= \\?").
+			WithArgs(idempotencyKey.ExpiresAt, idempotencyKey.LockedUntil, idempotencyKey.RequestHash, sqlmock.AnyArg(), idempotencyKey.Key, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		reclaimed, err := repo.Reclaim(context.TODO(), idempotencyKey, now)
+		assert.NoError(t, err)
+		assert.True(t, reclaimed)
+	})
+
+	t.Run("completed or locked again", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `idempotency_keys`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		reclaimed, err := repo.Reclaim(context.TODO(), idempotencyKey, now)
+		assert.NoError(t, err)
+		assert.False(t, reclaimed)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `idempotency_keys`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		reclaimed, err := repo.Reclaim(context.TODO(), idempotencyKey, now)
+		assert.Error(t, err)
+		assert.False(t, reclaimed)
+	})
+}
+
+func TestUpdateIdempotencyKeyResponseRepo(t *testing.T) {
+	idempotencyKey := &model.IdempotencyKey{
+		Key:          "key-1",
+		ResponseCode: 201,
+		ResponseBody: []byte(`{"id":1}`),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `idempotency_keys`").
+			WithArgs(idempotencyKey.ResponseCode, idempotencyKey.ResponseBody, sqlmock.AnyArg(), idempotencyKey.Key).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.UpdateResponse(context.TODO(), idempotencyKey)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `idempotency_keys`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.UpdateResponse(context.TODO(), idempotencyKey)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteIdempotencyKeyByKeyRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("DELETE FROM `idempotency_keys`").WithArgs("key-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.DeleteByKey(context.TODO(), "key-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on delete error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("DELETE FROM `idempotency_keys`").WithArgs("key-1").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.DeleteByKey(context.TODO(), "key-1")
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteExpiredIdempotencyKeyRepo(t *testing.T) {
+	now := time.Now()
+
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeIdempotencyKeyRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("DELETE FROM `idempotency_keys` WHERE expires_at <= \\?").
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mockQuery.ExpectCommit()
+
+	deleted, err := repo.DeleteExpired(context.TODO(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
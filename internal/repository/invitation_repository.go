@@ -3,11 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
-	"fmt"
+	"time"
 
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type invitationRepository struct {
@@ -26,11 +27,14 @@ func (i *invitationRepository) Create(ctx context.Context, invitation *model.Inv
 		"invitation": invitation,
 	})
 
-	tx := i.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, i.db)
 	err := tx.Create(invitation).Error
 	if err != nil {
-		logger.Error(err)
 		tx.Rollback()
+		if isDuplicateEntryError(err) {
+			return model.ErrDuplicateEntry
+		}
+		logger.Error(err)
 		return err
 	}
 
@@ -44,7 +48,7 @@ func (i *invitationRepository) FindByID(ctx context.Context, invitationID int64)
 	})
 
 	var invitation model.Invitation
-	err := i.db.WithContext(ctx).Take(&invitation, invitationID).Error
+	err := connFromContext(ctx, i.db).Take(&invitation, invitationID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -55,6 +59,83 @@ func (i *invitationRepository) FindByID(ctx context.Context, invitationID int64)
 	return &invitation, err
 }
 
+// FindByMemberIDAndGatheringID also returns soft-deleted invitations so they can be revived
+func (i *invitationRepository) FindByMemberIDAndGatheringID(ctx context.Context, memberID int64, gatheringID int64) (*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"memberID":    memberID,
+		"gatheringID": gatheringID,
+	})
+
+	var invitation model.Invitation
+	err := connFromContext(ctx, i.db).Unscoped().
+		Where("member_id = ? AND gathering_id = ?", memberID, gatheringID).
+		Take(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindByGatheringIDAndMemberIDs also returns soft-deleted invitations so they can be revived
+func (i *invitationRepository) FindByGatheringIDAndMemberIDs(ctx context.Context, gatheringID int64, memberIDs []int64) ([]*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"memberIDs":   memberIDs,
+	})
+
+	var invitations []*model.Invitation
+	if len(memberIDs) == 0 {
+		return invitations, nil
+	}
+
+	err := connFromContext(ctx, i.db).Unscoped().
+		Where("gathering_id = ? AND member_id IN ?", gatheringID, memberIDs).
+		Find(&invitations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (i *invitationRepository) FindByMemberID(ctx context.Context, memberID int64) ([]*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	var invitations []*model.Invitation
+
+	err := connFromContext(ctx, i.db).Where(&model.Invitation{MemberID: memberID}).Find(&invitations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (i *invitationRepository) FindByGatheringID(ctx context.Context, gatheringID int64) ([]*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var invitations []*model.Invitation
+
+	err := connFromContext(ctx, i.db).Where(&model.Invitation{GatheringID: gatheringID}).Find(&invitations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
 func (i *invitationRepository) UpdateByID(ctx context.Context, invitation *model.Invitation) (*model.Invitation, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":        ctx,
@@ -71,7 +152,7 @@ func (i *invitationRepository) UpdateByID(ctx context.Context, invitation *model
 		return nil, nil
 	}
 
-	tx := i.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, i.db)
 	err = tx.Model(invitation).Omit(invitation.ImmutableColumns()...).Save(invitation).Error
 	if err != nil {
 		tx.Rollback()
@@ -80,7 +161,6 @@ func (i *invitationRepository) UpdateByID(ctx context.Context, invitation *model
 	}
 
 	if err = tx.Commit().Error; err != nil {
-		fmt.Println("ASASASAS")
 		logger.Error(err)
 		return nil, err
 	}
@@ -88,6 +168,26 @@ func (i *invitationRepository) UpdateByID(ctx context.Context, invitation *model
 	return i.FindByID(ctx, invitation.ID)
 }
 
+func (i *invitationRepository) UpdateAllowedGuestsByID(ctx context.Context, invitationID int64, allowedGuests int) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":           ctx,
+		"invitationID":  invitationID,
+		"allowedGuests": allowedGuests,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Model(&model.Invitation{}).
+		Where("id = ?", invitationID).
+		Update("allowed_guests", allowedGuests).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
 func (i *invitationRepository) DeleteByID(ctx context.Context, invitationID int64) (*model.Invitation, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":          ctx,
@@ -95,7 +195,7 @@ func (i *invitationRepository) DeleteByID(ctx context.Context, invitationID int6
 	})
 
 	var invitation model.Invitation
-	tx := i.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, i.db)
 	err := tx.Find(&invitation, invitationID).Delete(&invitation).Error
 	if err != nil {
 		logger.Error(err)
@@ -109,7 +209,7 @@ func (i *invitationRepository) DeleteByID(ctx context.Context, invitationID int6
 		return nil, err
 	}
 
-	err = i.db.WithContext(ctx).Unscoped().Find(&invitation, invitationID).Error
+	err = connFromContext(ctx, i.db).Unscoped().Find(&invitation, invitationID).Error
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -117,3 +217,257 @@ func (i *invitationRepository) DeleteByID(ctx context.Context, invitationID int6
 
 	return &invitation, nil
 }
+
+// DeleteByMemberID soft deletes every live invitation of the member
+func (i *invitationRepository) DeleteByMemberID(ctx context.Context, memberID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Where(&model.Invitation{MemberID: memberID}).Delete(&model.Invitation{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteByGatheringID soft deletes every live invitation to the gathering
+func (i *invitationRepository) DeleteByGatheringID(ctx context.Context, gatheringID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Where(&model.Invitation{GatheringID: gatheringID}).Delete(&model.Invitation{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Revive restores a soft-deleted or expired invitation with the given status
+func (i *invitationRepository) Revive(ctx context.Context, invitation *model.Invitation) (*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        ctx,
+		"invitation": invitation,
+	})
+
+	// the guests came with the previous invitation, the member brings them again when accepting
+	tx := beginTx(ctx, i.db)
+	err := tx.Unscoped().Model(&model.Invitation{}).
+		Where("id = ?", invitation.ID).
+		Updates(map[string]interface{}{
+			"status":         invitation.Status,
+			"occurrence_at":  invitation.OccurrenceAt,
+			"allowed_guests": invitation.AllowedGuests,
+			"guests":         0,
+			"guest_names":    nil,
+			"invited_at":     time.Now(),
+			"accepted_at":    nil,
+			"deleted_at":     nil,
+			"updated_at":     time.Now(),
+		}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return i.FindByID(ctx, invitation.ID)
+}
+
+func (i *invitationRepository) FindAllDeleted(ctx context.Context) ([]*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	var invitations []*model.Invitation
+	err := connFromContext(ctx, i.db).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// RestoreByID undoes the soft delete, it returns nil when there is no deleted invitation with the given id
+func (i *invitationRepository) RestoreByID(ctx context.Context, invitationID int64) (*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"invitationID": invitationID,
+	})
+
+	tx := beginTx(ctx, i.db)
+	res := tx.Unscoped().Model(&model.Invitation{}).
+		Where("id = ? AND deleted_at IS NOT NULL", invitationID).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return nil, res.Error
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return i.FindByID(ctx, invitationID)
+}
+
+// LockPendingOfStartedGatherings locks the pending invitations of the gatherings with expiring invitations that
+// started before now, a recurring gathering starts with its first occurrence. The locks are held until the transaction
+// carried by ctx ends, so it has to be called inside one
+func (i *invitationRepository) LockPendingOfStartedGatherings(ctx context.Context, now time.Time) ([]*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+		"now": now,
+	})
+
+	startedGatherings := connFromContext(ctx, i.db).Model(&model.Gathering{}).
+		Select("id").
+		Where("type = ? AND scheduled_at <= ?", model.WithExpirationForInvitations, now)
+
+	var invitations []*model.Invitation
+	err := connFromContext(ctx, i.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status = ? AND gathering_id IN (?)", model.Pending, startedGatherings).
+		Find(&invitations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (i *invitationRepository) ExpireByIDs(ctx context.Context, invitationIDs []int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":           ctx,
+		"invitationIDs": invitationIDs,
+	})
+
+	if len(invitationIDs) == 0 {
+		return nil
+	}
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Model(&model.Invitation{}).
+		Where("id IN ?", invitationIDs).
+		Update("status", model.Expired).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// invitationStartColumn is when the occurrence an invitation is for starts: the start its override moved it to,
+// the occurrence it targets, or the start of the gathering for an invitation to the whole gathering
+const invitationStartColumn = "COALESCE(gathering_occurrence_overrides.scheduled_at, invitations.occurrence_at, gatherings.scheduled_at)"
+
+// CountStatsByMemberID counts the invitations of the member to the occurrences starting within [from, to), the
+// accepted ones, the occurrences the member checked in to, and the no-shows: accepted seats of the occurrences started
+// before now, that checked attendees in, without a check-in of the member. Each invitation counts for the occurrence
+// it targets and cancelled occurrences don't count, an invitation to every occurrence of a recurring gathering counts
+// once at the gathering start and never as attended or no-show since check-in is per occurrence
+func (i *invitationRepository) CountStatsByMemberID(ctx context.Context, memberID int64, from, to, now time.Time) (*model.MemberStats, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+		"from":     from,
+		"to":       to,
+	})
+
+	stats := model.MemberStats{MemberID: memberID, From: from, To: to}
+	err := connFromContext(ctx, i.db).Model(&model.Invitation{}).
+		Select("COUNT(*) AS invited, "+
+			"COALESCE(SUM(invitations.status = ?), 0) AS accepted, "+
+			"COALESCE(SUM(attendees.checked_in_at IS NOT NULL), 0) AS attended, "+
+			"COALESCE(SUM(invitations.status = ? AND attendees.member_id IS NOT NULL AND attendees.checked_in_at IS NULL "+
+			"AND "+invitationStartColumn+" <= ? AND EXISTS (SELECT 1 FROM attendees checked_in "+
+			"WHERE checked_in.gathering_id = invitations.gathering_id AND checked_in.occurrence_at <=> invitations.occurrence_at "+
+			"AND checked_in.checked_in_at IS NOT NULL AND checked_in.deleted_at IS NULL)), 0) AS no_shows",
+			model.Active, model.Active, now).
+		Joins("JOIN gatherings ON gatherings.id = invitations.gathering_id AND gatherings.deleted_at IS NULL").
+		Joins("LEFT JOIN gathering_occurrence_overrides ON gathering_occurrence_overrides.gathering_id = invitations.gathering_id "+
+			"AND gathering_occurrence_overrides.occurrence_at = invitations.occurrence_at").
+		Joins("LEFT JOIN attendees ON attendees.member_id = invitations.member_id "+
+			"AND attendees.gathering_id = invitations.gathering_id AND attendees.deleted_at IS NULL").
+		Where("invitations.member_id = ? AND "+invitationStartColumn+" >= ? AND "+invitationStartColumn+" < ? "+
+			"AND NOT COALESCE(gathering_occurrence_overrides.cancelled, FALSE)", memberID, from, to).
+		Scan(&stats).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// StreamRosterByGatheringID calls fn with the roster entry of every member invited to the gathering, ordered by name.
+// The entries are read one at a time so a large roster is never held in memory, fn returning an error stops it
+func (i *invitationRepository) StreamRosterByGatheringID(ctx context.Context, gatheringID int64, fn func(entry *model.RosterEntry) error) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	db := connFromContext(ctx, i.db)
+	rows, err := db.Model(&model.Invitation{}).
+		Select("members.id AS member_id, members.first_name, members.last_name, members.email, "+
+			"invitations.status, invitations.guests, waitlist_entries.id IS NOT NULL AS waitlisted, attendees.checked_in_at").
+		Joins("JOIN members ON members.id = invitations.member_id AND members.deleted_at IS NULL").
+		Joins("LEFT JOIN attendees ON attendees.member_id = invitations.member_id "+
+			"AND attendees.gathering_id = invitations.gathering_id AND attendees.deleted_at IS NULL").
+		Joins("LEFT JOIN waitlist_entries ON waitlist_entries.member_id = invitations.member_id "+
+			"AND waitlist_entries.gathering_id = invitations.gathering_id").
+		Where("invitations.gathering_id = ?", gatheringID).
+		Order("members.last_name, members.first_name, members.id").
+		Rows()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry model.RosterEntry
+		if err := db.ScanRows(rows, &entry); err != nil {
+			logger.Error(err)
+			return err
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Error(err)
+		return err
+	}
+	return nil
+}
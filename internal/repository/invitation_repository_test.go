@@ -39,7 +39,7 @@ func TestCreateInvitationRepo(t *testing.T) {
 		mockQuery.ExpectBegin()
 		mockQuery.ExpectExec("INSERT INTO `invitations`").
 			WithArgs(invitation.MemberID,
-				invitation.GatheringID, invitation.Status, invitation.CreatedAt, invitation.UpdatedAt, invitation.DeletedAt, invitation.ID).
+				invitation.GatheringID, invitation.Status, invitation.AllowedGuests, invitation.Guests, sqlmock.AnyArg(), invitation.OccurrenceAt, sqlmock.AnyArg(), invitation.AcceptedAt, invitation.CreatedAt, invitation.UpdatedAt, invitation.DeletedAt, invitation.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 		mockQuery.ExpectCommit()
 
@@ -168,9 +168,10 @@ func TestUpdateInvitationByIDRepo(t *testing.T) {
 		mockQuery.ExpectBegin()
 		mockQuery.ExpectExec("UPDATE(.*)").
 			WithArgs(
-				invitation.MemberID,
-				invitation.GatheringID,
 				invitation.Status,
+				invitation.Guests,
+				sqlmock.AnyArg(),
+				nil,
 				sqlmock.AnyArg(),
 				invitation.ID,
 			).
@@ -225,9 +226,10 @@ func TestUpdateInvitationByIDRepo(t *testing.T) {
 		mockQuery.ExpectBegin()
 		mockQuery.ExpectExec("UPDATE(.*)").
 			WithArgs(
-				invitation.MemberID,
-				invitation.GatheringID,
 				invitation.Status,
+				invitation.Guests,
+				sqlmock.AnyArg(),
+				nil,
 				sqlmock.AnyArg(),
 				invitation.ID,
 			).
@@ -259,9 +261,10 @@ func TestUpdateInvitationByIDRepo(t *testing.T) {
 		mockQuery.ExpectBegin()
 		mockQuery.ExpectExec("UPDATE(.*)").
 			WithArgs(
-				invitation.MemberID,
-				invitation.GatheringID,
 				invitation.Status,
+				invitation.Guests,
+				sqlmock.AnyArg(),
+				nil,
 				sqlmock.AnyArg(),
 				invitation.ID,
 			).
@@ -274,6 +277,35 @@ func TestUpdateInvitationByIDRepo(t *testing.T) {
 	})
 }
 
+func TestUpdateAllowedGuestsByIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations` SET `allowed_guests`(.*)").
+			WithArgs(3, sqlmock.AnyArg(), int64(123)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.UpdateAllowedGuestsByID(context.TODO(), 123, 3)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations` SET `allowed_guests`(.*)").
+			WillReturnError(errors.New("db error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.UpdateAllowedGuestsByID(context.TODO(), 123, 3)
+		assert.Error(t, err)
+	})
+}
+
 func TestDeleteInvitationByIDRepo(t *testing.T) {
 	dateString := "2021-11-22"
 	date, _ := time.Parse("2006-01-02", dateString)
@@ -403,3 +435,415 @@ func TestDeleteInvitationByIDRepo(t *testing.T) {
 		assert.Nil(t, invitationRes)
 	})
 }
+
+func TestFindInvitationByMemberIDAndGatheringIDRepo(t *testing.T) {
+	dateString := "2021-11-22"
+	date, _ := time.Parse("2006-01-02", dateString)
+
+	invitation := &model.Invitation{
+		ID:          123,
+		MemberID:    321,
+		GatheringID: 222,
+		Status:      model.Expired,
+		CreatedAt:   date,
+		UpdatedAt:   date,
+	}
+
+	t.Run("success, includes soft-deleted", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `invitations` WHERE member_id = \\? AND gathering_id = \\? LIMIT 1").
+			WithArgs(invitation.MemberID, invitation.GatheringID).
+			WillReturnRows(sqlmock.NewRows(
+				[]string{"id", "member_id", "gathering_id", "status", "created_at", "updated_at", "deleted_at"},
+			).AddRow(invitation.ID, invitation.MemberID, invitation.GatheringID, invitation.Status, date, date, date))
+
+		res, err := repo.FindByMemberIDAndGatheringID(context.TODO(), invitation.MemberID, invitation.GatheringID)
+		assert.NoError(t, err)
+		assert.Equal(t, invitation.ID, res.ID)
+		assert.True(t, res.DeletedAt.Valid)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WithArgs(invitation.MemberID, invitation.GatheringID).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByMemberIDAndGatheringID(context.TODO(), invitation.MemberID, invitation.GatheringID)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WithArgs(invitation.MemberID, invitation.GatheringID).
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByMemberIDAndGatheringID(context.TODO(), invitation.MemberID, invitation.GatheringID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviveInvitationRepo(t *testing.T) {
+	invitation := &model.Invitation{
+		ID:          123,
+		MemberID:    321,
+		GatheringID: 222,
+		Status:      model.Pending,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations` SET `accepted_at`=\\?,`allowed_guests`=\\?,`deleted_at`=\\?,`guest_names`=\\?,`guests`=\\?,`invited_at`=\\?,`occurrence_at`=\\?,`status`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs(nil, invitation.AllowedGuests, nil, nil, 0, sqlmock.AnyArg(), nil, invitation.Status, sqlmock.AnyArg(), invitation.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs(invitation.ID).WillReturnRows(sqlmock.NewRows(
+			[]string{"id", "member_id", "gathering_id", "status"},
+		).AddRow(invitation.ID, invitation.MemberID, invitation.GatheringID, invitation.Status))
+
+		res, err := repo.Revive(context.TODO(), invitation)
+		assert.NoError(t, err)
+		assert.Equal(t, invitation.Status, res.Status)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		res, err := repo.Revive(context.TODO(), invitation)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindInvitationsByGatheringIDAndMemberIDsRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `invitations` WHERE gathering_id = \\? AND member_id IN \\(\\?,\\?\\)").
+			WithArgs(int64(222), int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "member_id", "gathering_id", "status"}).
+				AddRow(10, 1, 222, model.Pending))
+
+		res, err := repo.FindByGatheringIDAndMemberIDs(context.TODO(), 222, []int64{1, 2})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByGatheringIDAndMemberIDs(context.TODO(), 222, []int64{1, 2})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindAllDeletedInvitationsRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `invitations` WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).
+				AddRow(1, time.Now()))
+
+		res, err := repo.FindAllDeleted(context.TODO())
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.True(t, res[0].DeletedAt.Valid)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.FindAllDeleted(context.TODO())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRestoreInvitationByIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations` SET `deleted_at`=\\?,`updated_at`=\\? WHERE id = \\? AND deleted_at IS NOT NULL").
+			WithArgs(nil, sqlmock.AnyArg(), int64(123)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs(int64(123)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(123))
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(123), res.ID)
+	})
+
+	t.Run("nothing to restore", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindInvitationByGatheringIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `invitations` WHERE `invitations`.`gathering_id` = \\? AND `invitations`.`deleted_at` IS NULL").
+			WithArgs(int64(222)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "member_id", "gathering_id"}).
+				AddRow(1, 321, 222).
+				AddRow(2, 322, 222))
+
+		res, err := repo.FindByGatheringID(context.TODO(), 222)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByGatheringID(context.TODO(), 222)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestDeleteInvitationByGatheringIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations` SET `deleted_at`=\\? WHERE `invitations`.`gathering_id` = \\? AND `invitations`.`deleted_at` IS NULL").
+			WithArgs(sqlmock.AnyArg(), int64(222)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.DeleteByGatheringID(context.TODO(), 222)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on delete error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.DeleteByGatheringID(context.TODO(), 222)
+		assert.Error(t, err)
+	})
+}
+
+func TestLockPendingOfStartedGatheringsInvitationRepo(t *testing.T) {
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `invitations` WHERE \\(status = \\? AND gathering_id IN \\(SELECT `id` FROM `gatherings` WHERE \\(type = \\? AND scheduled_at <= \\?\\) AND `gatherings`.`deleted_at` IS NULL\\)\\) AND `invitations`.`deleted_at` IS NULL FOR UPDATE").
+			WithArgs(model.Pending, model.WithExpirationForInvitations, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "member_id", "gathering_id", "status"}).
+				AddRow(1, 10, 100, model.Pending).
+				AddRow(2, 11, 100, model.Pending))
+
+		res, err := repo.LockPendingOfStartedGatherings(context.TODO(), now)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.LockPendingOfStartedGatherings(context.TODO(), now)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestExpireByIDsInvitationRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `invitations` SET `status`=\\?,`updated_at`=\\? WHERE id IN \\(\\?,\\?\\) AND `invitations`.`deleted_at` IS NULL").
+			WithArgs(model.Expired, sqlmock.AnyArg(), int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.ExpireByIDs(context.TODO(), []int64{1, 2})
+		assert.NoError(t, err)
+	})
+
+	t.Run("nothing to expire", func(t *testing.T) {
+		dbMock, _ := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		err := repo.ExpireByIDs(context.TODO(), nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCountStatsByMemberIDInvitationRepo(t *testing.T) {
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2021, 11, 22, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT COUNT\\(\\*\\) AS invited, (.*) AS no_shows FROM `invitations` "+
+			"JOIN gatherings ON (.*) LEFT JOIN gathering_occurrence_overrides ON (.*) LEFT JOIN attendees ON (.*) "+
+			"WHERE \\(invitations.member_id = \\? AND COALESCE\\(gathering_occurrence_overrides.scheduled_at, invitations.occurrence_at, gatherings.scheduled_at\\) >= \\? "+
+			"AND (.*) < \\? AND NOT COALESCE\\(gathering_occurrence_overrides.cancelled, FALSE\\)\\) AND `invitations`.`deleted_at` IS NULL").
+			WithArgs(model.Active, model.Active, now, int64(321), from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"invited", "accepted", "attended", "no_shows"}).AddRow(10, 8, 5, 2))
+
+		res, err := repo.CountStatsByMemberID(context.TODO(), 321, from, to, now)
+		assert.NoError(t, err)
+		assert.Equal(t, &model.MemberStats{
+			MemberID: 321,
+			From:     from,
+			To:       to,
+			Invited:  10,
+			Accepted: 8,
+			Attended: 5,
+			NoShows:  2,
+		}, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.CountStatsByMemberID(context.TODO(), 321, from, to, now)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStreamRosterByGatheringIDInvitationRepo(t *testing.T) {
+	checkedInAt := time.Date(2021, 11, 22, 19, 35, 0, 0, time.UTC)
+	columns := []string{"member_id", "first_name", "last_name", "email", "status", "guests", "waitlisted", "checked_in_at"}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT members.id AS member_id, (.*) FROM `invitations` " +
+			"JOIN members ON (.*) LEFT JOIN attendees ON (.*) LEFT JOIN waitlist_entries ON (.*) " +
+			"WHERE invitations.gathering_id = \\? AND `invitations`.`deleted_at` IS NULL " +
+			"ORDER BY members.last_name, members.first_name, members.id").
+			WithArgs(int64(222)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "John", "Doe", "john@doe.com", model.Active, 2, false, checkedInAt).
+				AddRow(2, "Jane", "Roe", "jane@roe.com", model.Pending, 0, true, nil))
+
+		var entries []*model.RosterEntry
+		err := repo.StreamRosterByGatheringID(context.TODO(), 222, func(entry *model.RosterEntry) error {
+			entries = append(entries, entry)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.RosterEntry{
+			{MemberID: 1, FirstName: "John", LastName: "Doe", Email: "john@doe.com", Status: model.Active, Guests: 2, CheckedInAt: &checkedInAt},
+			{MemberID: 2, FirstName: "Jane", LastName: "Roe", Email: "jane@roe.com", Status: model.Pending, Waitlisted: true},
+		}, entries)
+	})
+
+	t.Run("an error of fn stops the stream", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs(int64(222)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "John", "Doe", "john@doe.com", model.Active, 0, false, nil).
+				AddRow(2, "Jane", "Roe", "jane@roe.com", model.Active, 0, false, nil))
+
+		calls := 0
+		err := repo.StreamRosterByGatheringID(context.TODO(), 222, func(entry *model.RosterEntry) error {
+			calls++
+			return errors.New("client gone")
+		})
+		assert.EqualError(t, err, "client gone")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInvitationRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		err := repo.StreamRosterByGatheringID(context.TODO(), 222, func(entry *model.RosterEntry) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type inviteLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewInviteLinkRepository(db *gorm.DB) model.InviteLinkRepository {
+	return &inviteLinkRepository{
+		db: db,
+	}
+}
+
+func (i *inviteLinkRepository) Create(ctx context.Context, link *model.InviteLink) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  ctx,
+		"link": link,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Create(link).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (i *inviteLinkRepository) FindByID(ctx context.Context, linkID int64) (*model.InviteLink, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"linkID": linkID,
+	})
+
+	var link model.InviteLink
+	err := connFromContext(ctx, i.db).Take(&link, linkID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &link, nil
+}
+
+// LockByID finds the link and locks it until the transaction carried by ctx ends, so concurrent redemptions
+// can't exceed its max uses
+func (i *inviteLinkRepository) LockByID(ctx context.Context, linkID int64) (*model.InviteLink, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"linkID": linkID,
+	})
+
+	var link model.InviteLink
+	err := connFromContext(ctx, i.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&link, linkID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (i *inviteLinkRepository) FindByGatheringID(ctx context.Context, gatheringID int64) ([]*model.InviteLink, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var links []*model.InviteLink
+	err := connFromContext(ctx, i.db).Where("gathering_id = ?", gatheringID).Order("created_at").Find(&links).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return links, nil
+}
+
+func (i *inviteLinkRepository) IncrementUses(ctx context.Context, linkID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"linkID": linkID,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Model(&model.InviteLink{}).Where("id = ?", linkID).
+		Update("uses", gorm.Expr("uses + 1")).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// RevokeByID stops the link from being redeemed, revoking a revoked link keeps its first revocation time
+func (i *inviteLinkRepository) RevokeByID(ctx context.Context, linkID int64, revokedAt time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"linkID": linkID,
+	})
+
+	tx := beginTx(ctx, i.db)
+	err := tx.Model(&model.InviteLink{}).Where("id = ? AND revoked_at IS NULL", linkID).
+		Update("revoked_at", revokedAt).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeInviteLinkRepositoryWithMock(mockDB *gorm.DB) *inviteLinkRepository {
+	return &inviteLinkRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateInviteLinkRepo(t *testing.T) {
+	link := &model.InviteLink{ID: 123, GatheringID: 444, MaxUses: 1, ExpiresAt: time.Now()}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInviteLinkRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `invite_links` \\(`gathering_id`,`max_uses`,`uses`,`expires_at`,`revoked_at`,`created_at`,`updated_at`,`id`\\)").
+			WillReturnResult(sqlmock.NewResult(123, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), link)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInviteLinkRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `invite_links`").WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), link)
+		assert.Error(t, err)
+	})
+}
+
+func TestLockByIDInviteLinkRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInviteLinkRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `invite_links` WHERE `invite_links`.`id` = \\? LIMIT 1 FOR UPDATE").
+			WithArgs(int64(123)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "gathering_id", "max_uses", "uses"}).
+				AddRow(123, 444, 2, 1))
+
+		res, err := repo.LockByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res.Uses)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeInviteLinkRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs(int64(123)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.LockByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindByGatheringIDInviteLinkRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeInviteLinkRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectQuery("SELECT \\* FROM `invite_links` WHERE gathering_id = \\? ORDER BY created_at").
+		WithArgs(int64(444)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "gathering_id"}).
+			AddRow(123, 444).
+			AddRow(124, 444))
+
+	res, err := repo.FindByGatheringID(context.TODO(), 444)
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+}
+
+func TestIncrementUsesInviteLinkRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeInviteLinkRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("UPDATE `invite_links` SET `uses`=uses \\+ 1,`updated_at`=\\? WHERE id = \\?").
+		WithArgs(sqlmock.AnyArg(), int64(123)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockQuery.ExpectCommit()
+
+	err := repo.IncrementUses(context.TODO(), 123)
+	assert.NoError(t, err)
+}
+
+func TestRevokeByIDInviteLinkRepo(t *testing.T) {
+	revokedAt := time.Now()
+
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeInviteLinkRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("UPDATE `invite_links` SET `revoked_at`=\\?,`updated_at`=\\? WHERE id = \\? AND revoked_at IS NULL").
+		WithArgs(revokedAt, sqlmock.AnyArg(), int64(123)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockQuery.ExpectCommit()
+
+	err := repo.RevokeByID(context.TODO(), 123, revokedAt)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) model.JobRepository {
+	return &jobRepository{
+		db: db,
+	}
+}
+
+// Create inserts the job, a job whose unique key is already taken is skipped
+func (j *jobRepository) Create(ctx context.Context, job *model.Job) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+		"job": job,
+	})
+
+	tx := beginTx(ctx, j.db)
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(job).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (j *jobRepository) FindByID(ctx context.Context, jobID int64) (*model.Job, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"jobID": jobID,
+	})
+
+	var job model.Job
+	err := connFromContext(ctx, j.db).Take(&job, jobID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindRuns lists the latest job runs first
+func (j *jobRepository) FindRuns(ctx context.Context, filter *model.JobFilter) ([]*model.Job, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"filter": filter,
+	})
+
+	query := connFromContext(ctx, j.db)
+	if filter.Name != "" {
+		query = query.Where("name = ?", filter.Name)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var jobs []*model.Job
+	err := query.Order("created_at DESC").Limit(filter.Limit).Find(&jobs).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// ClaimDue locks the pending jobs that are due and the running jobs whose lease expired, because their worker died,
+// and leases them for lease so concurrent workers skip them while they run
+func (j *jobRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.Job, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"now":   now,
+		"limit": limit,
+	})
+
+	var jobs []*model.Job
+
+	tx := beginTx(ctx, j.db)
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("(status = ? AND run_at <= ?) OR (status = ? AND lease_until <= ?)", model.JobPending, now, model.JobRunning, now).
+		Order("run_at").
+		Limit(limit).
+		Find(&jobs).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(jobs) == 0 {
+		return jobs, tx.Commit().Error
+	}
+
+	leaseUntil := now.Add(lease)
+	jobIDs := make([]int64, 0, len(jobs))
+	for _, job := range jobs {
+		jobIDs = append(jobIDs, job.ID)
+		job.Status = model.JobRunning
+		job.Attempts++
+		job.LeaseUntil = &leaseUntil
+		job.StartedAt = &now
+	}
+
+	err = tx.Model(&model.Job{}).
+		Where("id IN ?", jobIDs).
+		Updates(map[string]interface{}{
+			"status":      model.JobRunning,
+			"attempts":    gorm.Expr("attempts + 1"),
+			"lease_until": leaseUntil,
+			"started_at":  now,
+		}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Update saves the outcome of a job run
+func (j *jobRepository) Update(ctx context.Context, job *model.Job) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+		"job": job,
+	})
+
+	tx := beginTx(ctx, j.db)
+	err := tx.Model(job).
+		Select("status", "attempts", "run_at", "lease_until", "last_error", "finished_at", "updated_at").
+		Updates(job).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// UpdateClaimed saves the outcome of a job run as long as the job is still held by the claim of the given attempt,
+// it reports false when the lease expired and another worker claimed the job again. The attempt identifies the claim
+// because every claim increments it
+func (j *jobRepository) UpdateClaimed(ctx context.Context, job *model.Job, attempts int) (bool, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"job":      job,
+		"attempts": attempts,
+	})
+
+	tx := beginTx(ctx, j.db)
+	res := tx.Model(job).
+		Where("status = ? AND attempts = ?", model.JobRunning, attempts).
+		Select("status", "attempts", "run_at", "lease_until", "last_error", "finished_at", "updated_at").
+		Updates(job)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, tx.Commit().Error
+}
+
+// DeleteFinishedBefore deletes the succeeded and dead job runs finished before the given time
+func (j *jobRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"before": before,
+	})
+
+	tx := beginTx(ctx, j.db)
+	res := tx.Where("status IN ? AND finished_at < ?", []model.JobStatus{model.JobSucceeded, model.JobDead}, before).
+		Delete(&model.Job{})
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, tx.Commit().Error
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeJobRepositoryWithMock(mockDB *gorm.DB) *jobRepository {
+	return &jobRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateJobRepo(t *testing.T) {
+	uniqueKey := "cleanup@2021-11-22T00:00:00Z"
+	job := &model.Job{ID: 1, Name: "cleanup", Status: model.JobPending, MaxAttempts: 3, UniqueKey: &uniqueKey}
+
+	t.Run("taken unique key is skipped", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeJobRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `jobs` .* ON DUPLICATE KEY UPDATE `id`=`id`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), job)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeJobRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `jobs`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), job)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindRunsJobRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeJobRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectQuery("SELECT \\* FROM `jobs` WHERE name = \\? AND status = \\? ORDER BY created_at DESC LIMIT 50").
+		WithArgs("cleanup", model.JobDead).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status"}).
+			AddRow(1, "cleanup", model.JobDead))
+
+	res, err := repo.FindRuns(context.TODO(), &model.JobFilter{Name: "cleanup", Status: model.JobDead, Limit: 50})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
+
+func TestClaimDueJobRepo(t *testing.T) {
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeJobRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectQuery("SELECT \\* FROM `jobs` WHERE \\(status = \\? AND run_at <= \\?\\) OR \\(status = \\? AND lease_until <= \\?\\) ORDER BY run_at LIMIT 10 FOR UPDATE SKIP LOCKED").
+			WithArgs(model.JobPending, now, model.JobRunning, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status", "attempts"}).
+				AddRow(1, "cleanup", model.JobPending, 0))
+		mockQuery.ExpectExec("UPDATE `jobs` SET `attempts`=attempts \\+ 1,`lease_until`=\\?,`started_at`=\\?,`status`=\\?,`updated_at`=\\? WHERE id IN \\(\\?\\)").
+			WithArgs(now.Add(time.Minute), now, model.JobRunning, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.ClaimDue(context.TODO(), now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, model.JobRunning, res[0].Status)
+		assert.Equal(t, 1, res[0].Attempts)
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeJobRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectQuery("SELECT \\* FROM `jobs`").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.ClaimDue(context.TODO(), now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestUpdateClaimedJobRepo(t *testing.T) {
+	job := &model.Job{ID: 1, Name: "cleanup", Status: model.JobSucceeded, Attempts: 2}
+
+	t.Run("claim still held", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeJobRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `jobs` SET .* WHERE \\(status = \\? AND attempts = \\?\\) AND `id` = \\?").
+			WithArgs(model.JobSucceeded, 2, sqlmock.AnyArg(), nil, "", nil, sqlmock.AnyArg(), model.JobRunning, 2, int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		saved, err := repo.UpdateClaimed(context.TODO(), job, 2)
+		assert.NoError(t, err)
+		assert.True(t, saved)
+	})
+
+	t.Run("claimed again by another worker", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeJobRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `jobs`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		saved, err := repo.UpdateClaimed(context.TODO(), job, 2)
+		assert.NoError(t, err)
+		assert.False(t, saved)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeJobRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `jobs`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		_, err := repo.UpdateClaimed(context.TODO(), job, 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteFinishedBeforeJobRepo(t *testing.T) {
+	before := time.Now()
+
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeJobRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("DELETE FROM `jobs` WHERE status IN \\(\\?,\\?\\) AND finished_at < \\?").
+		WithArgs(model.JobSucceeded, model.JobDead, before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mockQuery.ExpectCommit()
+
+	deleted, err := repo.DeleteFinishedBefore(context.TODO(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type memberGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewMemberGroupRepository(db *gorm.DB) model.MemberGroupRepository {
+	return &memberGroupRepository{
+		db: db,
+	}
+}
+
+func (m *memberGroupRepository) Create(ctx context.Context, group *model.MemberGroup) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"group": group,
+	})
+
+	tx := beginTx(ctx, m.db)
+	err := tx.Create(group).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (m *memberGroupRepository) FindByID(ctx context.Context, groupID int64) (*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"groupID": groupID,
+	})
+
+	var group model.MemberGroup
+	err := connFromContext(ctx, m.db).Take(&group, groupID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &group, nil
+}
+
+// LockByID finds the group and locks it until the transaction of ctx ends, so the changes to its members are
+// serialized
+func (m *memberGroupRepository) LockByID(ctx context.Context, groupID int64) (*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"groupID": groupID,
+	})
+
+	var group model.MemberGroup
+	err := connFromContext(ctx, m.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&group, groupID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (m *memberGroupRepository) FindByOwner(ctx context.Context, owner int64) ([]*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"owner": owner,
+	})
+
+	var groups []*model.MemberGroup
+	err := connFromContext(ctx, m.db).Where("owner = ?", owner).Order("name").Find(&groups).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (m *memberGroupRepository) UpdateByID(ctx context.Context, group *model.MemberGroup) (*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"group": group,
+	})
+
+	oldGroup, err := m.FindByID(ctx, group.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if oldGroup == nil {
+		return nil, nil
+	}
+
+	tx := beginTx(ctx, m.db)
+	err = tx.Model(group).Omit(group.ImmutableColumns()...).Save(group).Error
+	if err != nil {
+		tx.Rollback()
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return m.FindByID(ctx, group.ID)
+}
+
+// DeleteByID soft deletes the group, its members are kept
+func (m *memberGroupRepository) DeleteByID(ctx context.Context, groupID int64) (*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"groupID": groupID,
+	})
+
+	var group model.MemberGroup
+	tx := beginTx(ctx, m.db)
+	err := tx.Find(&group, groupID).Delete(&group).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = connFromContext(ctx, m.db).Unscoped().Find(&group, groupID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// AddMembers adds the members to the group, the members already in it are left as they are
+func (m *memberGroupRepository) AddMembers(ctx context.Context, groupID int64, memberIDs []int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"groupID":   groupID,
+		"memberIDs": memberIDs,
+	})
+
+	if len(memberIDs) == 0 {
+		return nil
+	}
+
+	groupMembers := make([]*model.MemberGroupMember, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		groupMembers = append(groupMembers, &model.MemberGroupMember{GroupID: groupID, MemberID: memberID})
+	}
+
+	tx := beginTx(ctx, m.db)
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(groupMembers).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (m *memberGroupRepository) RemoveMembers(ctx context.Context, groupID int64, memberIDs []int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"groupID":   groupID,
+		"memberIDs": memberIDs,
+	})
+
+	if len(memberIDs) == 0 {
+		return nil
+	}
+
+	tx := beginTx(ctx, m.db)
+	err := tx.Where("group_id = ? AND member_id IN ?", groupID, memberIDs).Delete(&model.MemberGroupMember{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// FindMemberIDs finds the members of the group in the order they were added, the deleted members are left out
+func (m *memberGroupRepository) FindMemberIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"groupID": groupID,
+	})
+
+	var memberIDs []int64
+	err := connFromContext(ctx, m.db).Model(&model.MemberGroupMember{}).
+		Joins("JOIN members ON members.id = member_group_members.member_id AND members.deleted_at IS NULL").
+		Where("member_group_members.group_id = ?", groupID).
+		Order("member_group_members.created_at, member_group_members.member_id").
+		Pluck("member_group_members.member_id", &memberIDs).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return memberIDs, nil
+}
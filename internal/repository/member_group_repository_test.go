@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeMemberGroupRepositoryWithMock(mockDB *gorm.DB) *memberGroupRepository {
+	return &memberGroupRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateMemberGroupRepo(t *testing.T) {
+	group := &model.MemberGroup{ID: 1, Name: "Hikers", Owner: 123}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `member_groups`").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), group)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `member_groups`").
+			WillReturnError(errors.New("some error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), group)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindMemberGroupByIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `member_groups` WHERE `member_groups`.`id` = \\? AND `member_groups`.`deleted_at` IS NULL").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "owner"}).AddRow(1, "Hikers", 123))
+
+		res, err := repo.FindByID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hikers", res.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `member_groups`").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindByID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindMemberGroupsByOwnerRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `member_groups` WHERE owner = \\? (.*) ORDER BY name").
+			WithArgs(int64(123)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "owner"}).
+				AddRow(1, "Hikers", 123).
+				AddRow(2, "Readers", 123))
+
+		res, err := repo.FindByOwner(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByOwner(context.TODO(), 123)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAddMemberGroupMembersRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `member_group_members` (.*) VALUES \\((.*)\\),\\((.*)\\) ON DUPLICATE KEY UPDATE").
+			WithArgs(int64(1), int64(10), sqlmock.AnyArg(), int64(1), int64(11), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.AddMembers(context.TODO(), 1, []int64{10, 11})
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("no member", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		err := repo.AddMembers(context.TODO(), 1, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `member_group_members`").
+			WillReturnError(errors.New("some error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.AddMembers(context.TODO(), 1, []int64{10})
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoveMemberGroupMembersRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("DELETE FROM `member_group_members` WHERE group_id = \\? AND member_id IN \\(\\?,\\?\\)").
+			WithArgs(int64(1), int64(10), int64(11)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.RemoveMembers(context.TODO(), 1, []int64{10, 11})
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on delete error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("DELETE FROM `member_group_members`").
+			WillReturnError(errors.New("some error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.RemoveMembers(context.TODO(), 1, []int64{10})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindMemberGroupMemberIDsRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT `member_group_members`.`member_id` FROM `member_group_members` " +
+			"JOIN members ON members.id = member_group_members.member_id AND members.deleted_at IS NULL " +
+			"WHERE member_group_members.group_id = \\? " +
+			"ORDER BY member_group_members.created_at, member_group_members.member_id").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"member_id"}).AddRow(10).AddRow(11))
+
+		res, err := repo.FindMemberIDs(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{10, 11}, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberGroupRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindMemberIDs(context.TODO(), 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -25,7 +25,7 @@ func (m *memberRepository) Create(ctx context.Context, member *model.Member) err
 		"member": member,
 	})
 
-	tx := m.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, m.db)
 	err := tx.Create(member).Error
 	if err != nil {
 		logger.Error(err)
@@ -43,7 +43,7 @@ func (m *memberRepository) FindByID(ctx context.Context, memberID int64) (*model
 	})
 
 	var member model.Member
-	err := m.db.WithContext(ctx).Take(&member, memberID).Error
+	err := connFromContext(ctx, m.db).Take(&member, memberID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -54,6 +54,25 @@ func (m *memberRepository) FindByID(ctx context.Context, memberID int64) (*model
 	return &member, err
 }
 
+func (m *memberRepository) FindByIDs(ctx context.Context, memberIDs []int64) ([]*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"memberIDs": memberIDs,
+	})
+
+	var members []*model.Member
+	if len(memberIDs) == 0 {
+		return members, nil
+	}
+
+	err := connFromContext(ctx, m.db).Where("id IN ?", memberIDs).Find(&members).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return members, nil
+}
+
 func (m *memberRepository) FindAll(ctx context.Context) ([]int64, error) {
 	return nil, nil
 }
@@ -74,7 +93,7 @@ func (m *memberRepository) UpdateByID(ctx context.Context, member *model.Member)
 		return nil, nil
 	}
 
-	tx := m.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, m.db)
 	err = tx.Model(member).Omit(member.ImmutableColumns()...).Save(member).Error
 	if err != nil {
 		tx.Rollback()
@@ -97,7 +116,7 @@ func (m *memberRepository) DeleteByID(ctx context.Context, memberID int64) (*mod
 	})
 
 	var member model.Member
-	tx := m.db.WithContext(ctx).Begin()
+	tx := beginTx(ctx, m.db)
 	err := tx.Find(&member, memberID).Delete(&member).Error
 	if err != nil {
 		logger.Error(err)
@@ -111,11 +130,154 @@ func (m *memberRepository) DeleteByID(ctx context.Context, memberID int64) (*mod
 		return nil, err
 	}
 
-	err = m.db.WithContext(ctx).Unscoped().Find(&member, memberID).Error
+	err = connFromContext(ctx, m.db).Unscoped().Find(&member, memberID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (m *memberRepository) FindAllDeleted(ctx context.Context) ([]*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	var members []*model.Member
+	err := connFromContext(ctx, m.db).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&members).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// RestoreByID undoes the soft delete, it returns nil when there is no deleted member with the given id
+func (m *memberRepository) RestoreByID(ctx context.Context, memberID int64) (*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	tx := beginTx(ctx, m.db)
+	res := tx.Unscoped().Model(&model.Member{}).
+		Where("id = ? AND deleted_at IS NOT NULL", memberID).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		tx.Rollback()
+		return nil, res.Error
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return m.FindByID(ctx, memberID)
+}
+
+// FindByEmail finds the oldest member registered with the email
+func (m *memberRepository) FindByEmail(ctx context.Context, email string) (*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"email": email,
+	})
+
+	var member model.Member
+	err := connFromContext(ctx, m.db).Where("email = ?", email).Order("created_at").Take(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindByEmails finds the members registered with the emails, the oldest first
+func (m *memberRepository) FindByEmails(ctx context.Context, emails []string) ([]*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"emails": emails,
+	})
+
+	var members []*model.Member
+	if len(emails) == 0 {
+		return members, nil
+	}
+
+	err := connFromContext(ctx, m.db).Where("email IN ?", emails).Order("created_at").Find(&members).Error
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
+	return members, nil
+}
 
+// CreateBatch creates the members with a single insert
+func (m *memberRepository) CreateBatch(ctx context.Context, members []*model.Member) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"members": len(members),
+	})
+
+	if len(members) == 0 {
+		return nil
+	}
+
+	tx := beginTx(ctx, m.db)
+	err := tx.Create(&members).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (m *memberRepository) FindByCalendarToken(ctx context.Context, token string) (*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	var member model.Member
+	err := connFromContext(ctx, m.db).Where("calendar_token = ?", token).Take(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
 	return &member, nil
 }
+
+// UpdateCalendarToken replaces the calendar token of the member, the feed URL holding the previous token stops working
+func (m *memberRepository) UpdateCalendarToken(ctx context.Context, memberID int64, token string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	tx := beginTx(ctx, m.db)
+	err := tx.Model(&model.Member{}).Where("id = ?", memberID).Update("calendar_token", token).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
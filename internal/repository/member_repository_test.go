@@ -39,7 +39,7 @@ func TestCreateMemberRepo(t *testing.T) {
 		mockQuery.ExpectBegin()
 		mockQuery.ExpectExec("INSERT INTO `members`").
 			WithArgs(member.FirstName,
-				member.LastName, member.Email, member.CreatedAt, member.UpdatedAt, member.DeletedAt, member.ID).
+				member.LastName, member.Email, member.CalendarToken, member.CreatedAt, member.UpdatedAt, member.DeletedAt, member.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 		mockQuery.ExpectCommit()
 
@@ -370,3 +370,264 @@ func TestDeleteMemberByIDRepo(t *testing.T) {
 		assert.Nil(t, memberResult)
 	})
 }
+
+func TestFindMembersByIDsRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `members` WHERE id IN \\(\\?,\\?\\)").
+			WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).
+				AddRow(1, "one").
+				AddRow(2, "two"))
+
+		res, err := repo.FindByIDs(context.TODO(), []int64{1, 2})
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("empty ids", func(t *testing.T) {
+		dbMock, _ := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		res, err := repo.FindByIDs(context.TODO(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByIDs(context.TODO(), []int64{1, 2})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindMembersByEmailsRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `members` WHERE email IN \\(\\?,\\?\\)(.*)ORDER BY created_at").
+			WithArgs("john@doe.com", "jane@doe.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).
+				AddRow(1, "john@doe.com").
+				AddRow(2, "jane@doe.com"))
+
+		res, err := repo.FindByEmails(context.TODO(), []string{"john@doe.com", "jane@doe.com"})
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("empty emails", func(t *testing.T) {
+		dbMock, _ := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		res, err := repo.FindByEmails(context.TODO(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByEmails(context.TODO(), []string{"john@doe.com"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCreateMembersBatchRepo(t *testing.T) {
+	members := []*model.Member{
+		{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@doe.com"},
+		{ID: 2, FirstName: "Jane", LastName: "Doe", Email: "jane@doe.com"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `members` (.*) VALUES \\((.*)\\),\\((.*)\\)").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		err := repo.CreateBatch(context.TODO(), members)
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("empty members", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		err := repo.CreateBatch(context.TODO(), nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `members`").
+			WillReturnError(errors.New("some error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.CreateBatch(context.TODO(), members)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindAllDeletedMembersRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `members` WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).
+				AddRow(1, time.Now()))
+
+		res, err := repo.FindAllDeleted(context.TODO())
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.True(t, res[0].DeletedAt.Valid)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.FindAllDeleted(context.TODO())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRestoreMemberByIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `members` SET `deleted_at`=\\?,`updated_at`=\\? WHERE id = \\? AND deleted_at IS NOT NULL").
+			WithArgs(nil, sqlmock.AnyArg(), int64(123)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs(int64(123)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(123))
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(123), res.ID)
+	})
+
+	t.Run("nothing to restore", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `members`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `members`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		res, err := repo.RestoreByID(context.TODO(), 123)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindMemberByCalendarTokenRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `members` WHERE calendar_token = \\? AND `members`.`deleted_at` IS NULL LIMIT 1").
+			WithArgs("token").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "calendar_token"}).
+				AddRow(1, "token"))
+
+		res, err := repo.FindByCalendarToken(context.TODO(), "token")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.ID)
+		assert.Equal(t, "token", *res.CalendarToken)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindByCalendarToken(context.TODO(), "token")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WillReturnError(errors.New("error"))
+
+		res, err := repo.FindByCalendarToken(context.TODO(), "token")
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUpdateMemberCalendarTokenRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `members` SET `calendar_token`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs("token", sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.UpdateCalendarToken(context.TODO(), 1, "token")
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on update error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeMemberRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `members`").WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.UpdateCalendarToken(context.TODO(), 1, "token")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationDeliveryRepository(db *gorm.DB) model.NotificationDeliveryRepository {
+	return &notificationDeliveryRepository{
+		db: db,
+	}
+}
+
+// Create records the delivery, recording it twice is a no-op
+func (n *notificationDeliveryRepository) Create(ctx context.Context, delivery *model.NotificationDelivery) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"delivery": delivery,
+	})
+
+	tx := beginTx(ctx, n.db)
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// FindMemberIDsByEventID finds the members the email of the event already reached
+func (n *notificationDeliveryRepository) FindMemberIDsByEventID(ctx context.Context, eventID int64) ([]int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"eventID": eventID,
+	})
+
+	var memberIDs []int64
+	err := connFromContext(ctx, n.db).Model(&model.NotificationDelivery{}).
+		Where("event_id = ?", eventID).
+		Pluck("member_id", &memberIDs).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return memberIDs, nil
+}
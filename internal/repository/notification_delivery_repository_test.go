@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeNotificationDeliveryRepositoryWithMock(mockDB *gorm.DB) *notificationDeliveryRepository {
+	return &notificationDeliveryRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateNotificationDeliveryRepo(t *testing.T) {
+	delivery := &model.NotificationDelivery{EventID: 1, MemberID: 10}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeNotificationDeliveryRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `notification_deliveries` .* ON DUPLICATE KEY UPDATE `event_id`=`event_id`").
+			WithArgs(int64(1), int64(10), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), delivery)
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeNotificationDeliveryRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `notification_deliveries`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), delivery)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindNotificationDeliveryMemberIDsByEventIDRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeNotificationDeliveryRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT `member_id` FROM `notification_deliveries` WHERE event_id = \\?").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"member_id"}).AddRow(10).AddRow(11))
+
+		res, err := repo.FindMemberIDsByEventID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{10, 11}, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeNotificationDeliveryRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnError(errors.New("error"))
+
+		res, err := repo.FindMemberIDsByEventID(context.TODO(), 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
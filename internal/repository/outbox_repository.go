@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) model.OutboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+func (o *outboxRepository) Create(ctx context.Context, messages []*model.OutboxMessage) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"messages": messages,
+	})
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	tx := beginTx(ctx, o.db)
+	err := tx.Create(messages).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (o *outboxRepository) FindByID(ctx context.Context, messageID int64) (*model.OutboxMessage, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"messageID": messageID,
+	})
+
+	var message model.OutboxMessage
+	err := connFromContext(ctx, o.db).Take(&message, messageID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &message, nil
+}
+
+// FindDeadLettered lists the latest dead-lettered messages first
+func (o *outboxRepository) FindDeadLettered(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"limit": limit,
+	})
+
+	var messages []*model.OutboxMessage
+	err := connFromContext(ctx, o.db).
+		Where("dead_lettered_at IS NOT NULL").
+		Order("dead_lettered_at DESC").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ClaimDue locks the undelivered messages that are due and pushes their next attempt by lease,
+// so concurrent relays skip them while they are being dispatched. Dead-lettered messages are never claimed.
+func (o *outboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.OutboxMessage, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"now":   now,
+		"limit": limit,
+	})
+
+	var messages []*model.OutboxMessage
+
+	tx := beginTx(ctx, o.db)
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("delivered_at IS NULL AND dead_lettered_at IS NULL AND next_attempt_at <= ?", now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(messages) == 0 {
+		return messages, tx.Commit().Error
+	}
+
+	messageIDs := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		messageIDs = append(messageIDs, message.ID)
+	}
+
+	err = tx.Model(&model.OutboxMessage{}).
+		Where("id IN ?", messageIDs).
+		Update("next_attempt_at", now.Add(lease)).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (o *outboxRepository) MarkDelivered(ctx context.Context, messageID int64, deliveredAt time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"messageID": messageID,
+	})
+
+	tx := beginTx(ctx, o.db)
+	err := tx.Model(&model.OutboxMessage{}).
+		Where("id = ?", messageID).
+		Update("delivered_at", deliveredAt).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// MarkFailed records a failed delivery and schedules the next attempt
+func (o *outboxRepository) MarkFailed(ctx context.Context, messageID int64, lastError string, nextAttemptAt time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"messageID": messageID,
+	})
+
+	tx := beginTx(ctx, o.db)
+	err := tx.Model(&model.OutboxMessage{}).
+		Where("id = ?", messageID).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// MarkDeadLettered records the last failed delivery of a message that ran out of attempts and sets it aside
+func (o *outboxRepository) MarkDeadLettered(ctx context.Context, messageID int64, lastError string, deadLetteredAt time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"messageID": messageID,
+	})
+
+	tx := beginTx(ctx, o.db)
+	err := tx.Model(&model.OutboxMessage{}).
+		Where("id = ?", messageID).
+		Updates(map[string]interface{}{
+			"attempts":         gorm.Expr("attempts + 1"),
+			"last_error":       lastError,
+			"dead_lettered_at": deadLetteredAt,
+		}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Replay makes a dead-lettered message due right away with a fresh set of attempts
+func (o *outboxRepository) Replay(ctx context.Context, messageID int64, now time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"messageID": messageID,
+	})
+
+	tx := beginTx(ctx, o.db)
+	err := tx.Model(&model.OutboxMessage{}).
+		Where("id = ?", messageID).
+		Updates(map[string]interface{}{
+			"attempts":         0,
+			"next_attempt_at":  now,
+			"dead_lettered_at": nil,
+		}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeOutboxRepositoryWithMock(mockDB *gorm.DB) *outboxRepository {
+	return &outboxRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateOutboxRepo(t *testing.T) {
+	dateString := "2021-11-22"
+	date, _ := time.Parse("2006-01-02", dateString)
+
+	message := &model.OutboxMessage{
+		ID:            123,
+		EventType:     model.InvitationSent,
+		Payload:       []byte(`{"id":1}`),
+		OccurredAt:    date,
+		NextAttemptAt: date,
+		CreatedAt:     date,
+		UpdatedAt:     date,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `outbox`").
+			WithArgs(message.EventType, message.Payload, message.OccurredAt, message.Attempts, message.LastError,
+				message.NextAttemptAt, message.DeliveredAt, message.DeadLetteredAt, message.CreatedAt, message.UpdatedAt, message.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), []*model.OutboxMessage{message})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `outbox`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), []*model.OutboxMessage{message})
+		assert.Error(t, err)
+	})
+}
+
+func TestClaimDueOutboxRepo(t *testing.T) {
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectQuery("SELECT \\* FROM `outbox` WHERE delivered_at IS NULL AND dead_lettered_at IS NULL AND next_attempt_at <= \\? ORDER BY next_attempt_at LIMIT 10 FOR UPDATE SKIP LOCKED").
+			WithArgs(now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_type"}).
+				AddRow(1, model.InvitationSent).
+				AddRow(2, model.InvitationSent))
+		mockQuery.ExpectExec("UPDATE `outbox` SET `next_attempt_at`=\\?,`updated_at`=\\? WHERE id IN \\(\\?,\\?\\)").
+			WithArgs(now.Add(time.Minute), sqlmock.AnyArg(), int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.ClaimDue(context.TODO(), now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.ClaimDue(context.TODO(), now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("rollback on claim error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectQuery("SELECT(.*)").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockQuery.ExpectExec("UPDATE `outbox`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		res, err := repo.ClaimDue(context.TODO(), now, time.Minute, 10)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestMarkOutboxRepo(t *testing.T) {
+	now := time.Now()
+
+	t.Run("delivered", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `outbox` SET `delivered_at`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs(now, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.MarkDelivered(context.TODO(), 1, now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `outbox` SET `attempts`=attempts \\+ 1,`last_error`=\\?,`next_attempt_at`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs("error", now, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.MarkFailed(context.TODO(), 1, "error", now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("dead-lettered", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `outbox` SET `attempts`=attempts \\+ 1,`dead_lettered_at`=\\?,`last_error`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs(now, "error", sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.MarkDeadLettered(context.TODO(), 1, "error", now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `outbox` SET `attempts`=\\?,`dead_lettered_at`=\\?,`next_attempt_at`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs(0, nil, now, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Replay(context.TODO(), 1, now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on mark error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeOutboxRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("UPDATE `outbox`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.MarkDelivered(context.TODO(), 1, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindDeadLetteredOutboxRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeOutboxRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectQuery("SELECT \\* FROM `outbox` WHERE dead_lettered_at IS NOT NULL ORDER BY dead_lettered_at DESC LIMIT 50").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type"}).AddRow(1, model.InvitationSent))
+
+	res, err := repo.FindDeadLettered(context.TODO(), 50)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
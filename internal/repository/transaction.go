@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type txContextKey struct{}
+
+type transactionManager struct {
+	db *gorm.DB
+}
+
+func NewTransactionManager(db *gorm.DB) model.TransactionManager {
+	return &transactionManager{
+		db: db,
+	}
+}
+
+func (t *transactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	tx := beginTx(ctx, t.db)
+	if tx.Error != nil {
+		logger.Error(tx.Error)
+		return tx.Error
+	}
+
+	err := fn(context.WithValue(ctx, txContextKey{}, tx.DB))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// transaction wraps a gorm transaction that may be owned by an outer TransactionManager.WithTransaction call,
+// in that case Commit and Rollback are left to the owner.
+type transaction struct {
+	*gorm.DB
+	joined bool
+}
+
+func (t *transaction) Commit() *gorm.DB {
+	if t.joined {
+		return t.DB
+	}
+	return t.DB.Commit()
+}
+
+func (t *transaction) Rollback() *gorm.DB {
+	if t.joined {
+		return t.DB
+	}
+	return t.DB.Rollback()
+}
+
+// beginTx begins a new transaction or joins the one carried by ctx
+func beginTx(ctx context.Context, db *gorm.DB) *transaction {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return &transaction{DB: tx, joined: true}
+	}
+	return &transaction{DB: db.WithContext(ctx).Begin()}
+}
+
+// connFromContext returns the transaction carried by ctx so reads see its uncommitted writes, or db otherwise
+func connFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTransaction(t *testing.T) {
+	invitation := &model.Invitation{
+		ID:          123,
+		MemberID:    321,
+		GatheringID: 222,
+		Status:      model.Pending,
+	}
+	attendee := &model.Attendee{
+		MemberID:    invitation.MemberID,
+		GatheringID: invitation.GatheringID,
+	}
+
+	t.Run("repositories join the transaction", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		transactionManager := NewTransactionManager(dbMock)
+		invitationRepo := initializeInvitationRepositoryWithMock(dbMock)
+		attendeeRepo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `invitations`").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectExec("INSERT INTO `attendees`").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		err := transactionManager.WithTransaction(context.TODO(), func(ctx context.Context) error {
+			if err := invitationRepo.Create(ctx, invitation); err != nil {
+				return err
+			}
+			return attendeeRepo.Create(ctx, attendee)
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("rollback when a repository fails", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		transactionManager := NewTransactionManager(dbMock)
+		invitationRepo := initializeInvitationRepositoryWithMock(dbMock)
+		attendeeRepo := initializeAttendeeRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `invitations`").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectExec("INSERT INTO `attendees`").WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := transactionManager.WithTransaction(context.TODO(), func(ctx context.Context) error {
+			if err := invitationRepo.Create(ctx, invitation); err != nil {
+				return err
+			}
+			return attendeeRepo.Create(ctx, attendee)
+		})
+		assert.Error(t, err)
+		assert.NoError(t, mockQuery.ExpectationsWereMet())
+	})
+
+	t.Run("error commit", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		transactionManager := NewTransactionManager(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectCommit().WillReturnError(errors.New("error"))
+
+		err := transactionManager.WithTransaction(context.TODO(), func(ctx context.Context) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// waitlistPositionColumn computes the position of an entry on the waitlist of its gathering
+const waitlistPositionColumn = "(SELECT COUNT(*) FROM waitlist_entries ahead " +
+	"WHERE ahead.gathering_id = waitlist_entries.gathering_id AND ahead.id <= waitlist_entries.id) AS position"
+
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) model.WaitlistRepository {
+	return &waitlistRepository{
+		db: db,
+	}
+}
+
+func (w *waitlistRepository) Create(ctx context.Context, entry *model.WaitlistEntry) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"entry": entry,
+	})
+
+	tx := beginTx(ctx, w.db)
+	err := tx.Create(entry).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (w *waitlistRepository) FindByGatheringIDAndMemberID(ctx context.Context, gatheringID int64, memberID int64) (*model.WaitlistEntry, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"memberID":    memberID,
+	})
+
+	var entry model.WaitlistEntry
+	err := connFromContext(ctx, w.db).
+		Select("waitlist_entries.*, "+waitlistPositionColumn).
+		Where("gathering_id = ? AND member_id = ?", gatheringID, memberID).
+		Take(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindByGatheringID finds the waitlist of the gathering in promotion order
+func (w *waitlistRepository) FindByGatheringID(ctx context.Context, gatheringID int64) ([]*model.WaitlistEntry, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var entries []*model.WaitlistEntry
+	err := connFromContext(ctx, w.db).
+		Where("gathering_id = ?", gatheringID).
+		Order("id").
+		Find(&entries).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	for i, entry := range entries {
+		entry.Position = int64(i + 1)
+	}
+	return entries, nil
+}
+
+func (w *waitlistRepository) FindByMemberID(ctx context.Context, memberID int64) ([]*model.WaitlistEntry, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	var entries []*model.WaitlistEntry
+	err := connFromContext(ctx, w.db).
+		Select("waitlist_entries.*, "+waitlistPositionColumn).
+		Where("member_id = ?", memberID).
+		Order("id").
+		Find(&entries).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindFirstByGatheringID finds the entry promoted next, nil when the waitlist is empty
+func (w *waitlistRepository) FindFirstByGatheringID(ctx context.Context, gatheringID int64) (*model.WaitlistEntry, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	var entry model.WaitlistEntry
+	err := connFromContext(ctx, w.db).
+		Where("gathering_id = ?", gatheringID).
+		Order("id").
+		Take(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+
+	entry.Position = 1
+	return &entry, nil
+}
+
+func (w *waitlistRepository) DeleteByGatheringIDAndMemberID(ctx context.Context, gatheringID int64, memberID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"memberID":    memberID,
+	})
+
+	tx := beginTx(ctx, w.db)
+	err := tx.Where("gathering_id = ? AND member_id = ?", gatheringID, memberID).
+		Delete(&model.WaitlistEntry{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteByGatheringID empties the waitlist of the gathering
+func (w *waitlistRepository) DeleteByGatheringID(ctx context.Context, gatheringID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	tx := beginTx(ctx, w.db)
+	err := tx.Where("gathering_id = ?", gatheringID).
+		Delete(&model.WaitlistEntry{}).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeWaitlistRepositoryWithMock(mockDB *gorm.DB) *waitlistRepository {
+	return &waitlistRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateWaitlistRepo(t *testing.T) {
+	entry := &model.WaitlistEntry{GatheringID: 1, MemberID: 2}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `waitlist_entries` \\(`gathering_id`,`member_id`,`created_at`\\)").
+			WillReturnResult(sqlmock.NewResult(10, 1))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), entry)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), entry.ID)
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `waitlist_entries`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), &model.WaitlistEntry{GatheringID: 1, MemberID: 2})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindByGatheringIDAndMemberIDWaitlistRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT waitlist_entries.\\*, \\(SELECT COUNT\\(\\*\\) FROM waitlist_entries ahead .*\\) AS position FROM `waitlist_entries` WHERE gathering_id = \\? AND member_id = \\?").
+			WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "gathering_id", "member_id", "position"}).
+				AddRow(10, 1, 2, 3))
+
+		res, err := repo.FindByGatheringIDAndMemberID(context.TODO(), 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res.Position)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindByGatheringIDAndMemberID(context.TODO(), 1, 2)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindByGatheringIDWaitlistRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectQuery("SELECT \\* FROM `waitlist_entries` WHERE gathering_id = \\? ORDER BY id").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "gathering_id", "member_id"}).
+			AddRow(10, 1, 2).
+			AddRow(12, 1, 4))
+
+	res, err := repo.FindByGatheringID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, int64(1), res[0].Position)
+	assert.Equal(t, int64(2), res[1].Position)
+}
+
+func TestFindFirstByGatheringIDWaitlistRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `waitlist_entries` WHERE gathering_id = \\? ORDER BY id LIMIT 1").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "gathering_id", "member_id"}).
+				AddRow(10, 1, 2))
+
+		res, err := repo.FindFirstByGatheringID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res.MemberID)
+		assert.Equal(t, int64(1), res.Position)
+	})
+
+	t.Run("empty waitlist", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT(.*)").WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindFirstByGatheringID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestDeleteByGatheringIDAndMemberIDWaitlistRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("DELETE FROM `waitlist_entries` WHERE gathering_id = \\? AND member_id = \\?").
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockQuery.ExpectCommit()
+
+	err := repo.DeleteByGatheringIDAndMemberID(context.TODO(), 1, 2)
+	assert.NoError(t, err)
+}
+
+func TestDeleteByGatheringIDWaitlistRepo(t *testing.T) {
+	dbMock, mockQuery := initializeMySQLMockConn()
+	repo := initializeWaitlistRepositoryWithMock(dbMock)
+
+	mockQuery.ExpectBegin()
+	mockQuery.ExpectExec("DELETE FROM `waitlist_entries` WHERE gathering_id = \\?").
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mockQuery.ExpectCommit()
+
+	err := repo.DeleteByGatheringID(context.TODO(), 1)
+	assert.NoError(t, err)
+}
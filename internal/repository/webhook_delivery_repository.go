@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) model.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: db,
+	}
+}
+
+// Create inserts the deliveries, a delivery of an event already enqueued for the same subscription is skipped
+func (w *webhookDeliveryRepository) Create(ctx context.Context, deliveries []*model.WebhookDelivery) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        ctx,
+		"deliveries": deliveries,
+	})
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	tx := beginTx(ctx, w.db)
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(deliveries).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (w *webhookDeliveryRepository) FindByID(ctx context.Context, deliveryID int64) (*model.WebhookDelivery, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        ctx,
+		"deliveryID": deliveryID,
+	})
+
+	var delivery model.WebhookDelivery
+	err := connFromContext(ctx, w.db).Take(&delivery, deliveryID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (w *webhookDeliveryRepository) FindBySubscriptionID(ctx context.Context, subscriptionID int64) ([]*model.WebhookDelivery, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"subscriptionID": subscriptionID,
+	})
+
+	var deliveries []*model.WebhookDelivery
+	err := connFromContext(ctx, w.db).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC").
+		Find(&deliveries).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDue locks the pending deliveries that are due and pushes their next attempt by lease,
+// so concurrent dispatchers skip them while they are being sent
+func (w *webhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.WebhookDelivery, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"now":   now,
+		"limit": limit,
+	})
+
+	var deliveries []*model.WebhookDelivery
+
+	tx := beginTx(ctx, w.db)
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryPending, now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return deliveries, tx.Commit().Error
+	}
+
+	deliveryIDs := make([]int64, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryIDs = append(deliveryIDs, delivery.ID)
+	}
+
+	err = tx.Model(&model.WebhookDelivery{}).
+		Where("id IN ?", deliveryIDs).
+		Update("next_attempt_at", now.Add(lease)).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Update saves the outcome of a delivery attempt
+func (w *webhookDeliveryRepository) Update(ctx context.Context, delivery *model.WebhookDelivery) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"delivery": delivery,
+	})
+
+	tx := beginTx(ctx, w.db)
+	err := tx.Model(delivery).
+		Select("status", "attempts", "response_code", "last_error", "next_attempt_at", "delivered_at", "updated_at").
+		Updates(delivery).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeWebhookDeliveryRepositoryWithMock(mockDB *gorm.DB) *webhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: mockDB,
+	}
+}
+
+func TestCreateWebhookDeliveryRepo(t *testing.T) {
+	delivery := &model.WebhookDelivery{
+		ID:             1,
+		SubscriptionID: 2,
+		EventID:        3,
+		EventType:      model.InvitationSent,
+		Payload:        []byte(`{"id":3}`),
+		Status:         model.WebhookDeliveryPending,
+	}
+
+	t.Run("duplicate deliveries are ignored", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWebhookDeliveryRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `webhook_deliveries` .* ON DUPLICATE KEY UPDATE `id`=`id`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockQuery.ExpectCommit()
+
+		err := repo.Create(context.TODO(), []*model.WebhookDelivery{delivery})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on insert error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWebhookDeliveryRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectExec("INSERT INTO `webhook_deliveries`").
+			WillReturnError(errors.New("error"))
+		mockQuery.ExpectRollback()
+
+		err := repo.Create(context.TODO(), []*model.WebhookDelivery{delivery})
+		assert.Error(t, err)
+	})
+}
+
+func TestClaimDueWebhookDeliveryRepo(t *testing.T) {
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWebhookDeliveryRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectQuery("SELECT \\* FROM `webhook_deliveries` WHERE status = \\? AND next_attempt_at <= \\? ORDER BY next_attempt_at LIMIT 10 FOR UPDATE SKIP LOCKED").
+			WithArgs(model.WebhookDeliveryPending, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "payload"}).
+				AddRow(1, []byte(`{"id":3}`)))
+		mockQuery.ExpectExec("UPDATE `webhook_deliveries` SET `next_attempt_at`=\\?,`updated_at`=\\? WHERE id IN \\(\\?\\)").
+			WithArgs(now.Add(time.Minute), sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.ClaimDue(context.TODO(), now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.JSONEq(t, `{"id":3}`, string(res[0].Payload))
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWebhookDeliveryRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectBegin()
+		mockQuery.ExpectQuery("SELECT \\* FROM `webhook_deliveries`").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockQuery.ExpectCommit()
+
+		res, err := repo.ClaimDue(context.TODO(), now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type webhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) model.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{
+		db: db,
+	}
+}
+
+func (w *webhookSubscriptionRepository) Create(ctx context.Context, subscription *model.WebhookSubscription) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"subscription": subscription,
+	})
+
+	tx := beginTx(ctx, w.db)
+	err := tx.Create(subscription).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (w *webhookSubscriptionRepository) FindByID(ctx context.Context, subscriptionID int64) (*model.WebhookSubscription, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"subscriptionID": subscriptionID,
+	})
+
+	var subscription model.WebhookSubscription
+	err := connFromContext(ctx, w.db).Take(&subscription, subscriptionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err)
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (w *webhookSubscriptionRepository) FindByOwner(ctx context.Context, owner int64) ([]*model.WebhookSubscription, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"owner": owner,
+	})
+
+	var subscriptions []*model.WebhookSubscription
+	err := connFromContext(ctx, w.db).Where("owner = ?", owner).Order("created_at").Find(&subscriptions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (w *webhookSubscriptionRepository) FindByEventType(ctx context.Context, eventType model.EventType) ([]*model.WebhookSubscription, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"eventType": eventType,
+	})
+
+	var subscriptions []*model.WebhookSubscription
+	err := connFromContext(ctx, w.db).
+		Where("JSON_CONTAINS(event_types, JSON_QUOTE(?))", string(eventType)).
+		Find(&subscriptions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (w *webhookSubscriptionRepository) DeleteByID(ctx context.Context, subscriptionID int64) (*model.WebhookSubscription, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"subscriptionID": subscriptionID,
+	})
+
+	var subscription model.WebhookSubscription
+	tx := beginTx(ctx, w.db)
+	err := tx.Find(&subscription, subscriptionID).Delete(&subscription).Error
+	if err != nil {
+		logger.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = connFromContext(ctx, w.db).Unscoped().Find(&subscription, subscriptionID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &subscription, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initializeWebhookSubscriptionRepositoryWithMock(mockDB *gorm.DB) *webhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{
+		db: mockDB,
+	}
+}
+
+func TestFindByEventTypeWebhookSubscriptionRepo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWebhookSubscriptionRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `webhook_subscriptions` WHERE JSON_CONTAINS\\(event_types, JSON_QUOTE\\(\\?\\)\\) AND `webhook_subscriptions`.`deleted_at` IS NULL").
+			WithArgs(string(model.InvitationSent)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "event_types"}).
+				AddRow(1, "https://example.com/hook", `["invitation.sent","member.deleted"]`))
+
+		res, err := repo.FindByEventType(context.TODO(), model.InvitationSent)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, []model.EventType{model.InvitationSent, model.MemberDeleted}, res[0].EventTypes)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWebhookSubscriptionRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `webhook_subscriptions`").
+			WillReturnError(errors.New("error"))
+
+		_, err := repo.FindByEventType(context.TODO(), model.InvitationSent)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindByIDWebhookSubscriptionRepo(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		dbMock, mockQuery := initializeMySQLMockConn()
+		repo := initializeWebhookSubscriptionRepositoryWithMock(dbMock)
+
+		mockQuery.ExpectQuery("SELECT \\* FROM `webhook_subscriptions` WHERE `webhook_subscriptions`.`id` = \\?").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindByID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,138 @@
+package roster
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+const (
+	// CSVContentType of the rosters written as CSV
+	CSVContentType = "text/csv"
+	// JSONLinesContentType of the rosters written as JSON Lines, one JSON object per entry
+	JSONLinesContentType = "application/x-ndjson"
+)
+
+var csvHeader = []string{"member_id", "first_name", "last_name", "email", "status", "guests", "waitlisted", "checked_in_at"}
+
+type (
+	// Writer writes the entries of a roster one at a time
+	Writer interface {
+		Write(entry *model.RosterEntry) error
+		// Flush writes what is buffered, an empty roster still gets its CSV header
+		Flush() error
+	}
+
+	csvWriter struct {
+		w             *csv.Writer
+		headerWritten bool
+	}
+
+	jsonLinesWriter struct {
+		encoder *json.Encoder
+	}
+
+	// jsonLine is the JSON Lines form of an entry, it holds the same fields as the CSV columns
+	jsonLine struct {
+		MemberID    int64      `json:"member_id"`
+		FirstName   string     `json:"first_name"`
+		LastName    string     `json:"last_name"`
+		Email       string     `json:"email"`
+		Status      string     `json:"status"`
+		Guests      int        `json:"guests"`
+		Waitlisted  bool       `json:"waitlisted"`
+		CheckedInAt *time.Time `json:"checked_in_at"`
+	}
+)
+
+// NewWriter writes the roster to w in the format of the content type, CSV unless it is JSON Lines
+func NewWriter(contentType string, w io.Writer) Writer {
+	if contentType == JSONLinesContentType {
+		return &jsonLinesWriter{encoder: json.NewEncoder(w)}
+	}
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+// FileExtension of the rosters written in the format of the content type
+func FileExtension(contentType string) string {
+	if contentType == JSONLinesContentType {
+		return "jsonl"
+	}
+	return "csv"
+}
+
+func (c *csvWriter) Write(entry *model.RosterEntry) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	checkedInAt := ""
+	if entry.CheckedInAt != nil {
+		checkedInAt = entry.CheckedInAt.UTC().Format(time.RFC3339)
+	}
+
+	return c.w.Write([]string{
+		strconv.FormatInt(entry.MemberID, 10),
+		escapeFormula(entry.FirstName),
+		escapeFormula(entry.LastName),
+		escapeFormula(entry.Email),
+		entry.Status.String(),
+		strconv.Itoa(entry.Guests),
+		strconv.FormatBool(entry.Waitlisted),
+		checkedInAt,
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.w.Write(csvHeader)
+}
+
+func (j *jsonLinesWriter) Write(entry *model.RosterEntry) error {
+	var checkedInAt *time.Time
+	if entry.CheckedInAt != nil {
+		utc := entry.CheckedInAt.UTC()
+		checkedInAt = &utc
+	}
+
+	return j.encoder.Encode(&jsonLine{
+		MemberID:    entry.MemberID,
+		FirstName:   entry.FirstName,
+		LastName:    entry.LastName,
+		Email:       entry.Email,
+		Status:      entry.Status.String(),
+		Guests:      entry.Guests,
+		Waitlisted:  entry.Waitlisted,
+		CheckedInAt: checkedInAt,
+	})
+}
+
+// Flush has nothing to do, every entry is written as soon as it is encoded
+func (j *jsonLinesWriter) Flush() error {
+	return nil
+}
+
+// escapeFormula keeps spreadsheets from running a member supplied field as a formula, the field is prefixed with a
+// quote when it starts like one
+func escapeFormula(field string) string {
+	if field != "" && strings.ContainsRune("=+-@\t\r", rune(field[0])) {
+		return "'" + field
+	}
+	return field
+}
@@ -0,0 +1,73 @@
+package roster
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	checkedInAt := time.Date(2021, 11, 22, 19, 35, 0, 0, time.FixedZone("WIB", 7*60*60))
+
+	entries := []*model.RosterEntry{
+		{
+			MemberID:    1,
+			FirstName:   "John",
+			LastName:    "Doe, Jr.",
+			Email:       "john@doe.com",
+			Status:      model.Active,
+			Guests:      2,
+			CheckedInAt: &checkedInAt,
+		},
+		{
+			MemberID:   2,
+			FirstName:  "=HYPERLINK(\"http://evil\")",
+			LastName:   "Roe",
+			Email:      "jane@roe.com",
+			Status:     model.Pending,
+			Waitlisted: true,
+		},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := NewWriter(CSVContentType, &buf)
+		for _, entry := range entries {
+			assert.NoError(t, writer.Write(entry))
+		}
+		assert.NoError(t, writer.Flush())
+
+		assert.Equal(t, "member_id,first_name,last_name,email,status,guests,waitlisted,checked_in_at\n"+
+			"1,John,\"Doe, Jr.\",john@doe.com,accepted,2,false,2021-11-22T12:35:00Z\n"+
+			"2,\"'=HYPERLINK(\"\"http://evil\"\")\",Roe,jane@roe.com,pending,0,true,\n", buf.String())
+	})
+
+	t.Run("empty csv has its header", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := NewWriter(CSVContentType, &buf)
+		assert.NoError(t, writer.Flush())
+
+		assert.Equal(t, "member_id,first_name,last_name,email,status,guests,waitlisted,checked_in_at\n", buf.String())
+	})
+
+	t.Run("json lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := NewWriter(JSONLinesContentType, &buf)
+		for _, entry := range entries {
+			assert.NoError(t, writer.Write(entry))
+		}
+		assert.NoError(t, writer.Flush())
+
+		assert.Equal(t, `{"member_id":1,"first_name":"John","last_name":"Doe, Jr.","email":"john@doe.com","status":"accepted","guests":2,"waitlisted":false,"checked_in_at":"2021-11-22T12:35:00Z"}`+"\n"+
+			`{"member_id":2,"first_name":"=HYPERLINK(\"http://evil\")","last_name":"Roe","email":"jane@roe.com","status":"pending","guests":0,"waitlisted":true,"checked_in_at":null}`+"\n",
+			buf.String())
+	})
+
+	t.Run("file extension", func(t *testing.T) {
+		assert.Equal(t, "csv", FileExtension(CSVContentType))
+		assert.Equal(t, "jsonl", FileExtension(JSONLinesContentType))
+	})
+}
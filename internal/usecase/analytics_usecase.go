@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type analyticsUsecase struct {
+	analyticsRepo model.AnalyticsRepository
+	gatheringRepo model.GatheringRepository
+}
+
+func NewAnalyticsUsecase(
+	analyticsRepo model.AnalyticsRepository,
+	gatheringRepo model.GatheringRepository,
+) model.AnalyticsUsecase {
+	return &analyticsUsecase{
+		analyticsRepo: analyticsRepo,
+		gatheringRepo: gatheringRepo,
+	}
+}
+
+// FindGatheringAnalytics aggregates the invitations of the gathering sent within [from, to)
+func (au *analyticsUsecase) FindGatheringAnalytics(ctx context.Context, gatheringID int64, from, to time.Time) (*model.GatheringAnalytics, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"from":        from,
+		"to":          to,
+	})
+
+	if !to.After(from) {
+		return nil, ErrInvalidDateRange
+	}
+
+	gathering, err := au.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	res, err := au.aggregate(ctx, &model.AnalyticsScope{GatheringID: gatheringID, From: from.UTC(), To: to.UTC()})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	res.GatheringID = gatheringID
+	return res, nil
+}
+
+// FindCreatorAnalytics aggregates the invitations of every gathering of the creator sent within [from, to), the
+// fill rate is over the gatherings scheduled within it
+func (au *analyticsUsecase) FindCreatorAnalytics(ctx context.Context, creator int64, from, to time.Time) (*model.GatheringAnalytics, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"creator": creator,
+		"from":    from,
+		"to":      to,
+	})
+
+	if !to.After(from) {
+		return nil, ErrInvalidDateRange
+	}
+
+	res, err := au.aggregate(ctx, &model.AnalyticsScope{Creator: creator, From: from.UTC(), To: to.UTC()})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	res.Creator = creator
+	return res, nil
+}
+
+func (au *analyticsUsecase) aggregate(ctx context.Context, scope *model.AnalyticsScope) (*model.GatheringAnalytics, error) {
+	funnel, err := au.analyticsRepo.CountInvitationFunnel(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	seats, err := au.analyticsRepo.CountSeatUsage(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := au.analyticsRepo.CountDaily(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	if daily == nil {
+		daily = []*model.DailyAnalytics{}
+	}
+
+	return &model.GatheringAnalytics{
+		From:     scope.From,
+		To:       scope.To,
+		Funnel:   funnel,
+		Seats:    seats,
+		FillRate: seats.FillRate(),
+		Daily:    daily,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindGatheringAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	from := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	gathering := &model.Gathering{ID: 123, Creator: 111, Type: model.WithFixedNumberOfAttendees, Capacity: 20}
+	scope := &model.AnalyticsScope{GatheringID: gathering.ID, From: from, To: to}
+
+	t.Run("success", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockAnalyticsRepo := mock.NewMockAnalyticsRepository(ctrl)
+		funnel := &model.InvitationFunnel{Sent: 10, Pending: 2, Accepted: 5, Declined: 2, Expired: 1}
+		mockAnalyticsRepo.EXPECT().CountInvitationFunnel(ctx, scope).Times(1).Return(funnel, nil)
+		mockAnalyticsRepo.EXPECT().CountSeatUsage(ctx, scope).Times(1).Return(&model.SeatUsage{Taken: 15, Capacity: 20}, nil)
+		daily := []*model.DailyAnalytics{{Day: "2021-11-20", Sent: 10, Accepted: 5}}
+		mockAnalyticsRepo.EXPECT().CountDaily(ctx, scope).Times(1).Return(daily, nil)
+
+		analyticsUsecase := NewAnalyticsUsecase(mockAnalyticsRepo, mockGatheringRepo)
+
+		res, err := analyticsUsecase.FindGatheringAnalytics(ctx, gathering.ID, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, gathering.ID, res.GatheringID)
+		assert.Equal(t, funnel, res.Funnel)
+		assert.Equal(t, 0.75, *res.FillRate)
+		assert.Equal(t, daily, res.Daily)
+	})
+
+	t.Run("success, without capacity or activity", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockAnalyticsRepo := mock.NewMockAnalyticsRepository(ctrl)
+		mockAnalyticsRepo.EXPECT().CountInvitationFunnel(ctx, scope).Times(1).Return(&model.InvitationFunnel{}, nil)
+		mockAnalyticsRepo.EXPECT().CountSeatUsage(ctx, scope).Times(1).Return(&model.SeatUsage{}, nil)
+		mockAnalyticsRepo.EXPECT().CountDaily(ctx, scope).Times(1).Return(nil, nil)
+
+		analyticsUsecase := NewAnalyticsUsecase(mockAnalyticsRepo, mockGatheringRepo)
+
+		res, err := analyticsUsecase.FindGatheringAnalytics(ctx, gathering.ID, from, to)
+		assert.NoError(t, err)
+		assert.Nil(t, res.FillRate)
+		assert.NotNil(t, res.Daily)
+		assert.Empty(t, res.Daily)
+	})
+
+	t.Run("failed, invalid date range", func(t *testing.T) {
+		analyticsUsecase := NewAnalyticsUsecase(nil, nil)
+
+		res, err := analyticsUsecase.FindGatheringAnalytics(ctx, gathering.ID, to, from)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvalidDateRange)
+	})
+
+	t.Run("failed, gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+
+		analyticsUsecase := NewAnalyticsUsecase(nil, mockGatheringRepo)
+
+		res, err := analyticsUsecase.FindGatheringAnalytics(ctx, gathering.ID, from, to)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, error count seat usage", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockAnalyticsRepo := mock.NewMockAnalyticsRepository(ctrl)
+		mockAnalyticsRepo.EXPECT().CountInvitationFunnel(ctx, scope).Times(1).Return(&model.InvitationFunnel{}, nil)
+		mockAnalyticsRepo.EXPECT().CountSeatUsage(ctx, scope).Times(1).Return(nil, errors.New("error"))
+
+		analyticsUsecase := NewAnalyticsUsecase(mockAnalyticsRepo, mockGatheringRepo)
+
+		res, err := analyticsUsecase.FindGatheringAnalytics(ctx, gathering.ID, from, to)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindCreatorAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	from := time.Date(2021, 11, 1, 7, 0, 0, 0, jakarta)
+	to := time.Date(2021, 12, 1, 7, 0, 0, 0, jakarta)
+	scope := &model.AnalyticsScope{
+		Creator: 111,
+		From:    time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockAnalyticsRepo := mock.NewMockAnalyticsRepository(ctrl)
+		mockAnalyticsRepo.EXPECT().CountInvitationFunnel(ctx, scope).Times(1).Return(&model.InvitationFunnel{Sent: 3}, nil)
+		mockAnalyticsRepo.EXPECT().CountSeatUsage(ctx, scope).Times(1).Return(&model.SeatUsage{Taken: 5, Capacity: 40}, nil)
+		mockAnalyticsRepo.EXPECT().CountDaily(ctx, scope).Times(1).Return([]*model.DailyAnalytics{}, nil)
+
+		analyticsUsecase := NewAnalyticsUsecase(mockAnalyticsRepo, nil)
+
+		res, err := analyticsUsecase.FindCreatorAnalytics(ctx, 111, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(111), res.Creator)
+		assert.Equal(t, scope.From, res.From)
+		assert.Equal(t, 0.125, *res.FillRate)
+	})
+
+	t.Run("failed, invalid date range", func(t *testing.T) {
+		analyticsUsecase := NewAnalyticsUsecase(nil, nil)
+
+		res, err := analyticsUsecase.FindCreatorAnalytics(ctx, 111, from, from)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvalidDateRange)
+	})
+
+	t.Run("failed, error count invitation funnel", func(t *testing.T) {
+		mockAnalyticsRepo := mock.NewMockAnalyticsRepository(ctrl)
+		mockAnalyticsRepo.EXPECT().CountInvitationFunnel(ctx, scope).Times(1).Return(nil, errors.New("error"))
+
+		analyticsUsecase := NewAnalyticsUsecase(mockAnalyticsRepo, nil)
+
+		res, err := analyticsUsecase.FindCreatorAnalytics(ctx, 111, from, to)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
@@ -1 +1,222 @@
 package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type attendeeUsecase struct {
+	attendeeRepo       model.AttendeeRepository
+	gatheringRepo      model.GatheringRepository
+	transactionManager model.TransactionManager
+	eventPublisher     model.EventPublisher
+}
+
+func NewAttendeeUsecase(
+	attendeeRepo model.AttendeeRepository,
+	gatheringRepo model.GatheringRepository,
+	transactionManager model.TransactionManager,
+	eventPublisher model.EventPublisher,
+) model.AttendeeUsecase {
+	return &attendeeUsecase{
+		attendeeRepo:       attendeeRepo,
+		gatheringRepo:      gatheringRepo,
+		transactionManager: transactionManager,
+		eventPublisher:     eventPublisher,
+	}
+}
+
+// FindCheckInCode returns the code the member checks in to the gathering with, it is created on the first call.
+// Waitlisted members have no seat so they have no code either, and neither do the members invited to every
+// occurrence of a recurring gathering since a member checks in once
+func (au *attendeeUsecase) FindCheckInCode(ctx context.Context, memberID int64, gatheringID int64) (string, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"memberID":    memberID,
+		"gatheringID": gatheringID,
+	})
+
+	attendee, err := au.attendeeRepo.FindByMemberIDAndGatheringID(ctx, memberID, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return "", err
+	case attendee == nil:
+		return "", ErrRecordNotFound
+	case attendee.CheckInCode != nil && *attendee.CheckInCode != "":
+		return *attendee.CheckInCode, nil
+	}
+
+	if err = au.checkInAllowed(ctx, attendee); err != nil {
+		return "", err
+	}
+
+	code, err := model.GenerateSecret()
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	if err = au.attendeeRepo.UpdateCheckInCode(ctx, memberID, gatheringID, code); err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	// a concurrent request may have stored its code first, every request hands out the stored one
+	attendee, err = au.attendeeRepo.FindByMemberIDAndGatheringID(ctx, memberID, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return "", err
+	case attendee == nil || attendee.CheckInCode == nil:
+		return "", ErrRecordNotFound
+	}
+
+	return *attendee.CheckInCode, nil
+}
+
+// CheckIn records that the attendee of the gathering holding the code showed up with their guests
+func (au *attendeeUsecase) CheckIn(ctx context.Context, gatheringID int64, code string) (*model.Attendee, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	attendee, err := au.findAttendeeByCheckInCode(ctx, gatheringID, code)
+	switch {
+	case err != nil:
+		return nil, err
+	case attendee.CheckedInAt != nil:
+		return nil, ErrAlreadyCheckedIn
+	}
+
+	if err = au.checkInAllowed(ctx, attendee); err != nil {
+		return nil, err
+	}
+
+	checkedInAt := time.Now().UTC()
+	attendee.CheckedInAt = &checkedInAt
+	err = au.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		updated, err := au.attendeeRepo.UpdateCheckedInAt(ctx, attendee.MemberID, attendee.GatheringID, attendee.CheckedInAt)
+		switch {
+		case err != nil:
+			return err
+		case !updated:
+			// another scan checked the attendee in first and published the event
+			return ErrAlreadyCheckedIn
+		}
+		return publishEvent(ctx, au.eventPublisher, model.AttendeeCheckedIn, attendee)
+	})
+	switch {
+	case errors.Is(err, ErrAlreadyCheckedIn):
+		return nil, err
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	}
+
+	return attendee, nil
+}
+
+// UndoCheckIn clears the check-in of the attendee of the gathering holding the code, to fix a code scanned by mistake
+func (au *attendeeUsecase) UndoCheckIn(ctx context.Context, gatheringID int64, code string) (*model.Attendee, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	attendee, err := au.findAttendeeByCheckInCode(ctx, gatheringID, code)
+	switch {
+	case err != nil:
+		return nil, err
+	case attendee.CheckedInAt == nil:
+		return nil, ErrNotCheckedIn
+	}
+
+	attendee.CheckedInAt = nil
+	err = au.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		updated, err := au.attendeeRepo.UpdateCheckedInAt(ctx, attendee.MemberID, attendee.GatheringID, nil)
+		switch {
+		case err != nil:
+			return err
+		case !updated:
+			return ErrNotCheckedIn
+		}
+		return publishEvent(ctx, au.eventPublisher, model.AttendeeCheckInUndone, attendee)
+	})
+	switch {
+	case errors.Is(err, ErrNotCheckedIn):
+		return nil, err
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	}
+
+	return attendee, nil
+}
+
+func (au *attendeeUsecase) FindAttendanceByGatheringID(ctx context.Context, gatheringID int64) (*model.Attendance, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gathering, err := au.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	res, err := au.attendeeRepo.FindAttendanceByGatheringID(ctx, gatheringID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// checkInAllowed refuses the check-in of a member invited to every occurrence of a recurring gathering, the attendee
+// records a single check-in so it can't tell the occurrences apart
+func (au *attendeeUsecase) checkInAllowed(ctx context.Context, attendee *model.Attendee) error {
+	gathering, err := au.gatheringRepo.FindByID(ctx, attendee.GatheringID)
+	switch {
+	case err != nil:
+		return err
+	case gathering == nil:
+		return ErrRecordNotFound
+	case gathering.IsRecurring() && attendee.OccurrenceAt == nil:
+		return ErrSeriesCheckIn
+	}
+	return nil
+}
+
+// findAttendeeByCheckInCode finds the attendee of the gathering holding the code, the attendees of a deleted gathering
+// are deleted with it. A code of another gathering is not found, so organizers only check in to their gatherings
+func (au *attendeeUsecase) findAttendeeByCheckInCode(ctx context.Context, gatheringID int64, code string) (*model.Attendee, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	if code == "" {
+		return nil, ErrRecordNotFound
+	}
+
+	attendee, err := au.attendeeRepo.FindByCheckInCode(ctx, code)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case attendee == nil, attendee.GatheringID != gatheringID:
+		return nil, ErrRecordNotFound
+	}
+
+	return attendee, nil
+}
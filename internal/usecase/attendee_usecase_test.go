@@ -1 +1,292 @@
 package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCheckInCodeUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success, created on the first call", func(t *testing.T) {
+		var createdCode string
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		gomock.InOrder(
+			mockAttendeeRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, int64(321), int64(444)).Times(1).
+				Return(&model.Attendee{MemberID: 321, GatheringID: 444}, nil),
+			mockAttendeeRepo.EXPECT().UpdateCheckInCode(ctx, int64(321), int64(444), gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, _, _ int64, code string) error {
+					createdCode = code
+					return nil
+				}),
+			mockAttendeeRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, int64(321), int64(444)).Times(1).
+				DoAndReturn(func(_ context.Context, _, _ int64) (*model.Attendee, error) {
+					return &model.Attendee{MemberID: 321, GatheringID: 444, CheckInCode: &createdCode}, nil
+				}),
+		)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).Return(&model.Gathering{ID: 444}, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, mockGatheringRepo, nil, nil)
+
+		code, err := attendeeUsecase.FindCheckInCode(ctx, 321, 444)
+		assert.NoError(t, err)
+		assert.Len(t, code, 64)
+		assert.Equal(t, createdCode, code)
+	})
+
+	t.Run("success, the code stored by a concurrent request wins", func(t *testing.T) {
+		storedCode := "stored"
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		gomock.InOrder(
+			mockAttendeeRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, int64(321), int64(444)).Times(1).
+				Return(&model.Attendee{MemberID: 321, GatheringID: 444}, nil),
+			mockAttendeeRepo.EXPECT().UpdateCheckInCode(ctx, int64(321), int64(444), gomock.Any()).Times(1).Return(nil),
+			mockAttendeeRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, int64(321), int64(444)).Times(1).
+				Return(&model.Attendee{MemberID: 321, GatheringID: 444, CheckInCode: &storedCode}, nil),
+		)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).Return(&model.Gathering{ID: 444}, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, mockGatheringRepo, nil, nil)
+
+		code, err := attendeeUsecase.FindCheckInCode(ctx, 321, 444)
+		assert.NoError(t, err)
+		assert.Equal(t, storedCode, code)
+	})
+
+	t.Run("failed, member invited to every occurrence of a recurring gathering", func(t *testing.T) {
+		scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, int64(321), int64(444)).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444}, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).
+			Return(&model.Gathering{ID: 444, ScheduledAt: &scheduledAt, Recurrence: "FREQ=WEEKLY"}, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, mockGatheringRepo, nil, nil)
+
+		_, err := attendeeUsecase.FindCheckInCode(ctx, 321, 444)
+		assert.ErrorIs(t, err, ErrSeriesCheckIn)
+	})
+
+	t.Run("success, existing code", func(t *testing.T) {
+		existingCode := "code"
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, int64(321), int64(444)).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444, CheckInCode: &existingCode}, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, nil, nil, nil)
+
+		code, err := attendeeUsecase.FindCheckInCode(ctx, 321, 444)
+		assert.NoError(t, err)
+		assert.Equal(t, existingCode, code)
+	})
+
+	t.Run("failed, member has no seat", func(t *testing.T) {
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, int64(321), int64(444)).Times(1).Return(nil, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, nil, nil, nil)
+
+		_, err := attendeeUsecase.FindCheckInCode(ctx, 321, 444)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestCheckInUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	code := "code"
+
+	t.Run("success", func(t *testing.T) {
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444, CheckInCode: &code}, nil)
+		mockAttendeeRepo.EXPECT().UpdateCheckedInAt(ctx, int64(321), int64(444), gomock.Not(gomock.Nil())).Times(1).Return(true, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).Return(&model.Gathering{ID: 444}, nil)
+		eventRecorder := event.NewRecorder()
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, mockGatheringRepo, initializeTransactionManagerMock(ctrl), eventRecorder)
+
+		res, err := attendeeUsecase.CheckIn(ctx, int64(444), code)
+		assert.NoError(t, err)
+		assert.NotNil(t, res.CheckedInAt)
+		assert.Equal(t, []model.EventType{model.AttendeeCheckedIn}, eventRecorder.Types())
+	})
+
+	t.Run("failed, a concurrent scan checked the attendee in first", func(t *testing.T) {
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444, CheckInCode: &code}, nil)
+		mockAttendeeRepo.EXPECT().UpdateCheckedInAt(ctx, int64(321), int64(444), gomock.Not(gomock.Nil())).Times(1).Return(false, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).Return(&model.Gathering{ID: 444}, nil)
+		eventRecorder := event.NewRecorder()
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, mockGatheringRepo, initializeTransactionManagerMock(ctrl), eventRecorder)
+
+		res, err := attendeeUsecase.CheckIn(ctx, int64(444), code)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrAlreadyCheckedIn)
+		assert.Empty(t, eventRecorder.Events())
+	})
+
+	t.Run("failed, member invited to every occurrence of a recurring gathering", func(t *testing.T) {
+		scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444, CheckInCode: &code}, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).
+			Return(&model.Gathering{ID: 444, ScheduledAt: &scheduledAt, Recurrence: "FREQ=WEEKLY"}, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, mockGatheringRepo, nil, nil)
+
+		res, err := attendeeUsecase.CheckIn(ctx, int64(444), code)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrSeriesCheckIn)
+	})
+
+	t.Run("failed, already checked in", func(t *testing.T) {
+		checkedInAt := time.Now()
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444, CheckedInAt: &checkedInAt}, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, nil, nil, nil)
+
+		res, err := attendeeUsecase.CheckIn(ctx, int64(444), code)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrAlreadyCheckedIn)
+	})
+
+	t.Run("failed, unknown code", func(t *testing.T) {
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).Return(nil, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, nil, nil, nil)
+
+		res, err := attendeeUsecase.CheckIn(ctx, int64(444), code)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, code of another gathering", func(t *testing.T) {
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 555, CheckInCode: &code}, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, nil, nil, nil)
+
+		res, err := attendeeUsecase.CheckIn(ctx, int64(444), code)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, empty code", func(t *testing.T) {
+		attendeeUsecase := NewAttendeeUsecase(nil, nil, nil, nil)
+
+		res, err := attendeeUsecase.CheckIn(ctx, int64(444), "")
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, error update", func(t *testing.T) {
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444}, nil)
+		mockAttendeeRepo.EXPECT().UpdateCheckedInAt(ctx, int64(321), int64(444), gomock.Any()).Times(1).Return(false, errors.New("error"))
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).Return(&model.Gathering{ID: 444}, nil)
+		eventRecorder := event.NewRecorder()
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, mockGatheringRepo, initializeTransactionManagerMock(ctrl), eventRecorder)
+
+		res, err := attendeeUsecase.CheckIn(ctx, int64(444), code)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+		assert.Empty(t, eventRecorder.Events())
+	})
+}
+
+func TestUndoCheckInUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	code := "code"
+
+	t.Run("success", func(t *testing.T) {
+		checkedInAt := time.Now()
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444, CheckedInAt: &checkedInAt}, nil)
+		mockAttendeeRepo.EXPECT().UpdateCheckedInAt(ctx, int64(321), int64(444), nil).Times(1).Return(true, nil)
+		eventRecorder := event.NewRecorder()
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, nil, initializeTransactionManagerMock(ctrl), eventRecorder)
+
+		res, err := attendeeUsecase.UndoCheckIn(ctx, int64(444), code)
+		assert.NoError(t, err)
+		assert.Nil(t, res.CheckedInAt)
+		assert.Equal(t, []model.EventType{model.AttendeeCheckInUndone}, eventRecorder.Types())
+	})
+
+	t.Run("failed, not checked in", func(t *testing.T) {
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByCheckInCode(ctx, code).Times(1).
+			Return(&model.Attendee{MemberID: 321, GatheringID: 444}, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, nil, nil, nil)
+
+		res, err := attendeeUsecase.UndoCheckIn(ctx, int64(444), code)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrNotCheckedIn)
+	})
+}
+
+func TestFindAttendanceByGatheringIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		attendance := &model.Attendance{GatheringID: 444, Expected: 7, CheckedIn: 3}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).Return(&model.Gathering{ID: 444}, nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindAttendanceByGatheringID(ctx, int64(444)).Times(1).Return(attendance, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(mockAttendeeRepo, mockGatheringRepo, nil, nil)
+
+		res, err := attendeeUsecase.FindAttendanceByGatheringID(ctx, 444)
+		assert.NoError(t, err)
+		assert.Equal(t, attendance, res)
+	})
+
+	t.Run("failed, gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(444)).Times(1).Return(nil, nil)
+
+		attendeeUsecase := NewAttendeeUsecase(nil, mockGatheringRepo, nil, nil)
+
+		res, err := attendeeUsecase.FindAttendanceByGatheringID(ctx, 444)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// the occurrences of a recurring gathering exported to calendars, the recurrence may never end
+const (
+	calendarLookBehind     = 90 * 24 * time.Hour
+	calendarLookAhead      = 365 * 24 * time.Hour
+	maxCalendarOccurrences = 500
+)
+
+type calendarUsecase struct {
+	memberRepo     model.MemberRepository
+	gatheringRepo  model.GatheringRepository
+	attendeeRepo   model.AttendeeRepository
+	occurrenceRepo model.GatheringOccurrenceRepository
+}
+
+func NewCalendarUsecase(
+	memberRepo model.MemberRepository,
+	gatheringRepo model.GatheringRepository,
+	attendeeRepo model.AttendeeRepository,
+	occurrenceRepo model.GatheringOccurrenceRepository,
+) model.CalendarUsecase {
+	return &calendarUsecase{
+		memberRepo:     memberRepo,
+		gatheringRepo:  gatheringRepo,
+		attendeeRepo:   attendeeRepo,
+		occurrenceRepo: occurrenceRepo,
+	}
+}
+
+// FindCalendarToken returns the token of the member calendar feed, it is ErrRecordNotFound until ResetCalendarToken
+// creates it
+func (cu *calendarUsecase) FindCalendarToken(ctx context.Context, memberID int64) (string, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	member, err := cu.memberRepo.FindByID(ctx, memberID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return "", err
+	case member == nil:
+		return "", ErrRecordNotFound
+	case member.CalendarToken == nil || *member.CalendarToken == "":
+		return "", ErrRecordNotFound
+	}
+
+	return *member.CalendarToken, nil
+}
+
+// ResetCalendarToken creates the token of the member calendar feed or gives it a new one, the feed URL holding the
+// previous one stops working
+func (cu *calendarUsecase) ResetCalendarToken(ctx context.Context, memberID int64) (string, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	member, err := cu.memberRepo.FindByID(ctx, memberID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return "", err
+	case member == nil:
+		return "", ErrRecordNotFound
+	}
+
+	token, err := model.GenerateSecret()
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	if err = cu.memberRepo.UpdateCalendarToken(ctx, memberID, token); err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+// FindCalendarFeed finds the occurrences of the gatherings attended by the member holding the token, the gatherings
+// cancelled while the member attended them are kept so calendar apps can show them as cancelled.
+// A member invited to a single occurrence only gets that occurrence
+func (cu *calendarUsecase) FindCalendarFeed(ctx context.Context, token string) (*model.CalendarFeed, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	if token == "" {
+		return nil, ErrRecordNotFound
+	}
+
+	member, err := cu.memberRepo.FindByCalendarToken(ctx, token)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case member == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	attendees, err := cu.attendeeRepo.FindByMemberID(ctx, member.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cancelledAttendees, err := cu.attendeeRepo.FindCancelledByMemberID(ctx, member.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	attendees = append(attendees, cancelledAttendees...)
+	gatheringIDs := make([]int64, 0, len(attendees))
+	for _, attendee := range attendees {
+		gatheringIDs = append(gatheringIDs, attendee.GatheringID)
+	}
+	gatheringIDs = uniqueIDs(gatheringIDs)
+
+	gatherings, err := cu.gatheringRepo.FindByIDsWithDeleted(ctx, gatheringIDs)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	gatheringsByID := make(map[int64]*model.Gathering, len(gatherings))
+	for _, gathering := range gatherings {
+		gatheringsByID[gathering.ID] = gathering
+	}
+
+	overrides, err := cu.occurrenceRepo.FindByGatheringIDs(ctx, gatheringIDs)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	var occurrences []*model.Occurrence
+	for _, attendee := range attendees {
+		gathering, ok := gatheringsByID[attendee.GatheringID]
+		switch {
+		case !ok || gathering.ScheduledAt == nil:
+			continue
+		case attendee.OccurrenceAt != nil:
+			occurrences = append(occurrences, gathering.Occurrence(*attendee.OccurrenceAt, overrides))
+		default:
+			res, err := calendarOccurrences(gathering, overrides, now)
+			if err != nil {
+				logger.WithField("gatheringID", gathering.ID).Error(err)
+				continue
+			}
+			occurrences = append(occurrences, res...)
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].ScheduledAt.Before(occurrences[j].ScheduledAt)
+	})
+
+	return &model.CalendarFeed{
+		Name:        fmt.Sprintf("Gatherings of %s %s", member.FirstName, member.LastName),
+		Occurrences: occurrences,
+	}, nil
+}
+
+// FindGatheringCalendar finds the occurrences of the gathering to export it to calendars
+func (cu *calendarUsecase) FindGatheringCalendar(ctx context.Context, gatheringID int64) (*model.CalendarFeed, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gathering, err := cu.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	case gathering.ScheduledAt == nil:
+		return nil, ErrGatheringNotScheduled
+	}
+
+	var overrides []*model.GatheringOccurrenceOverride
+	if gathering.IsRecurring() {
+		overrides, err = cu.occurrenceRepo.FindByGatheringID(ctx, gatheringID)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	occurrences, err := calendarOccurrences(gathering, overrides, time.Now())
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &model.CalendarFeed{
+		Name:        gathering.Name,
+		Occurrences: occurrences,
+	}, nil
+}
+
+// calendarOccurrences lists the occurrences of the gathering shown in calendars, a gathering that does not recur is
+// always shown while a recurring one is expanded from a while ago to a year ahead
+func calendarOccurrences(gathering *model.Gathering, overrides []*model.GatheringOccurrenceOverride, now time.Time) ([]*model.Occurrence, error) {
+	if !gathering.IsRecurring() {
+		return []*model.Occurrence{gathering.Occurrence(*gathering.ScheduledAt, nil)}, nil
+	}
+	return gathering.Occurrences(now.Add(-calendarLookBehind), now.Add(calendarLookAhead), maxCalendarOccurrences, overrides)
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestFindCalendarToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("existing token", func(t *testing.T) {
+		token := "token"
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.Member{ID: 1, CalendarToken: &token}, nil)
+		mockMemberRepo.EXPECT().UpdateCalendarToken(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		calendarUsecase := NewCalendarUsecase(mockMemberRepo, nil, nil, nil)
+
+		res, err := calendarUsecase.FindCalendarToken(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, token, res)
+	})
+
+	t.Run("token is not created", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.Member{ID: 1}, nil)
+		mockMemberRepo.EXPECT().UpdateCalendarToken(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		calendarUsecase := NewCalendarUsecase(mockMemberRepo, nil, nil, nil)
+
+		_, err := calendarUsecase.FindCalendarToken(ctx, 1)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("member not found", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		calendarUsecase := NewCalendarUsecase(mockMemberRepo, nil, nil, nil)
+
+		_, err := calendarUsecase.FindCalendarToken(ctx, 1)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestResetCalendarToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success, the token is replaced", func(t *testing.T) {
+		token := "old"
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.Member{ID: 1, CalendarToken: &token}, nil)
+		mockMemberRepo.EXPECT().UpdateCalendarToken(ctx, int64(1), gomock.Any()).Times(1).Return(nil)
+
+		calendarUsecase := NewCalendarUsecase(mockMemberRepo, nil, nil, nil)
+
+		res, err := calendarUsecase.ResetCalendarToken(ctx, 1)
+		assert.NoError(t, err)
+		assert.NotEqual(t, token, res)
+	})
+
+	t.Run("error update token", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.Member{ID: 1}, nil)
+		mockMemberRepo.EXPECT().UpdateCalendarToken(ctx, int64(1), gomock.Any()).Times(1).Return(errors.New("error"))
+
+		calendarUsecase := NewCalendarUsecase(mockMemberRepo, nil, nil, nil)
+
+		_, err := calendarUsecase.ResetCalendarToken(ctx, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindCalendarFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	member := &model.Member{ID: 1, FirstName: "John"}
+
+	t.Run("success, attended and cancelled gatherings", func(t *testing.T) {
+		scheduledAt := time.Now().Add(24 * time.Hour).Truncate(time.Second).UTC()
+		weeklyAt := scheduledAt.Add(time.Hour)
+		gatherings := []*model.Gathering{
+			{ID: 10, ScheduledAt: &scheduledAt},
+			{ID: 20, ScheduledAt: &scheduledAt, DeletedAt: gorm.DeletedAt{Time: scheduledAt, Valid: true}},
+			{ID: 30},
+			{ID: 40, ScheduledAt: &weeklyAt, Recurrence: "FREQ=WEEKLY;COUNT=3"},
+		}
+		secondAt := weeklyAt.AddDate(0, 0, 7)
+		overrides := []*model.GatheringOccurrenceOverride{{GatheringID: 40, OccurrenceAt: secondAt, Cancelled: true}}
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByCalendarToken(ctx, "token").Times(1).Return(member, nil)
+		mockAttendeeRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).
+			Return([]*model.Attendee{
+				{MemberID: 1, GatheringID: 10},
+				{MemberID: 1, GatheringID: 30},
+				{MemberID: 1, GatheringID: 40, OccurrenceAt: &secondAt},
+			}, nil)
+		mockAttendeeRepo.EXPECT().FindCancelledByMemberID(ctx, member.ID).Times(1).
+			Return([]*model.Attendee{{MemberID: 1, GatheringID: 20}}, nil)
+		mockGatheringRepo.EXPECT().FindByIDsWithDeleted(ctx, []int64{10, 30, 40, 20}).Times(1).Return(gatherings, nil)
+		mockOccurrenceRepo.EXPECT().FindByGatheringIDs(ctx, []int64{10, 30, 40, 20}).Times(1).Return(overrides, nil)
+
+		calendarUsecase := NewCalendarUsecase(mockMemberRepo, mockGatheringRepo, mockAttendeeRepo, mockOccurrenceRepo)
+
+		res, err := calendarUsecase.FindCalendarFeed(ctx, "token")
+		assert.NoError(t, err)
+		assert.Equal(t, "Gatherings of John ", res.Name)
+		if assert.Len(t, res.Occurrences, 3) {
+			assert.Equal(t, int64(10), res.Occurrences[0].GatheringID)
+			assert.False(t, res.Occurrences[0].Cancelled)
+			assert.Equal(t, int64(20), res.Occurrences[1].GatheringID)
+			assert.True(t, res.Occurrences[1].Cancelled)
+			// the member is only invited to the second occurrence, which is cancelled
+			assert.Equal(t, int64(40), res.Occurrences[2].GatheringID)
+			assert.True(t, res.Occurrences[2].OccurrenceAt.Equal(secondAt))
+			assert.True(t, res.Occurrences[2].Cancelled)
+		}
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		calendarUsecase := NewCalendarUsecase(nil, nil, nil, nil)
+
+		_, err := calendarUsecase.FindCalendarFeed(ctx, "")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByCalendarToken(ctx, "token").Times(1).Return(nil, nil)
+
+		calendarUsecase := NewCalendarUsecase(mockMemberRepo, nil, nil, nil)
+
+		_, err := calendarUsecase.FindCalendarFeed(ctx, "token")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("error find cancelled attendees", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByCalendarToken(ctx, "token").Times(1).Return(member, nil)
+		mockAttendeeRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockAttendeeRepo.EXPECT().FindCancelledByMemberID(ctx, member.ID).Times(1).Return(nil, errors.New("error"))
+
+		calendarUsecase := NewCalendarUsecase(mockMemberRepo, nil, mockAttendeeRepo, nil)
+
+		_, err := calendarUsecase.FindCalendarFeed(ctx, "token")
+		assert.Error(t, err)
+	})
+}
+
+func TestFindGatheringCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	scheduledAt := time.Now().Add(24 * time.Hour).Truncate(time.Second).UTC()
+
+	t.Run("success, single gathering", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 1, Name: "Dinner", ScheduledAt: &scheduledAt}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+
+		calendarUsecase := NewCalendarUsecase(nil, mockGatheringRepo, nil, nil)
+
+		res, err := calendarUsecase.FindGatheringCalendar(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Dinner", res.Name)
+		if assert.Len(t, res.Occurrences, 1) {
+			assert.True(t, res.Occurrences[0].ScheduledAt.Equal(scheduledAt))
+		}
+	})
+
+	t.Run("success, recurring gathering with a rescheduled occurrence", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 1, Name: "Run", ScheduledAt: &scheduledAt, Recurrence: "FREQ=DAILY;COUNT=3"}
+		secondAt := scheduledAt.AddDate(0, 0, 1)
+		movedAt := secondAt.Add(2 * time.Hour)
+		overrides := []*model.GatheringOccurrenceOverride{{GatheringID: 1, OccurrenceAt: secondAt, ScheduledAt: &movedAt}}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).Return(overrides, nil)
+
+		calendarUsecase := NewCalendarUsecase(nil, mockGatheringRepo, nil, mockOccurrenceRepo)
+
+		res, err := calendarUsecase.FindGatheringCalendar(ctx, 1)
+		assert.NoError(t, err)
+		if assert.Len(t, res.Occurrences, 3) {
+			assert.True(t, res.Occurrences[1].ScheduledAt.Equal(movedAt))
+			assert.True(t, res.Occurrences[1].Overridden)
+		}
+	})
+
+	t.Run("gathering not scheduled", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.Gathering{ID: 1}, nil)
+
+		calendarUsecase := NewCalendarUsecase(nil, mockGatheringRepo, nil, nil)
+
+		_, err := calendarUsecase.FindGatheringCalendar(ctx, 1)
+		assert.ErrorIs(t, err, ErrGatheringNotScheduled)
+	})
+
+	t.Run("gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		calendarUsecase := NewCalendarUsecase(nil, mockGatheringRepo, nil, nil)
+
+		_, err := calendarUsecase.FindGatheringCalendar(ctx, 1)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
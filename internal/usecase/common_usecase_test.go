@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+)
+
+// initializeTransactionManagerMock returns a transaction manager that runs the given function in place
+func initializeTransactionManagerMock(ctrl *gomock.Controller) *mock.MockTransactionManager {
+	mockTransactionManager := mock.NewMockTransactionManager(ctrl)
+	mockTransactionManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	return mockTransactionManager
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// InviteEmailToGathering invites someone who is not a member yet, the invitation is linked to the member
+// registering with the email. It is always pending, only the member can accept it
+func (iu *invitationUsecase) InviteEmailToGathering(ctx context.Context, invitation *model.EmailInvitation) (*model.EmailInvitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        ctx,
+		"invitation": invitation,
+	})
+
+	email, ok := model.NormalizeEmail(invitation.Email)
+	if !ok {
+		return nil, ErrInvalidEmail
+	}
+	invitation.Email = email
+	invitation.Status = model.Pending
+
+	gatheringRes, err := iu.gatheringRepo.FindByID(ctx, invitation.GatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gatheringRes == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	occurrenceAt, err := invitedOccurrence(gatheringRes, invitation.OccurrenceAt)
+	if err != nil {
+		return nil, err
+	}
+	invitation.OccurrenceAt = occurrenceAt
+
+	member, err := iu.memberRepo.FindByEmail(ctx, email)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case member != nil:
+		return nil, ErrEmailAlreadyRegistered
+	}
+
+	err = iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := iu.emailInvitationRepo.Create(ctx, invitation); err != nil {
+			return err
+		}
+		return publishEvent(ctx, iu.eventPublisher, model.EmailInvitationSent, invitation)
+	})
+	switch {
+	case errors.Is(err, model.ErrDuplicateEntry):
+		return nil, ErrInvitationAlreadyExists
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// FindEmailInvitationsByGatheringID lists the invitations of the gathering still waiting for their member to register
+func (iu *invitationUsecase) FindEmailInvitationsByGatheringID(ctx context.Context, gatheringID int64) ([]*model.EmailInvitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gatheringRes, err := iu.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gatheringRes == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	res, err := iu.emailInvitationRepo.FindByGatheringID(ctx, gatheringID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (iu *invitationUsecase) FindEmailInvitationByID(ctx context.Context, invitationID int64) (*model.EmailInvitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"invitationID": invitationID,
+	})
+
+	res, err := iu.emailInvitationRepo.FindByID(ctx, invitationID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case res == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	return res, nil
+}
+
+func (iu *invitationUsecase) DeleteEmailInvitationByID(ctx context.Context, invitationID int64) (*model.EmailInvitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"invitationID": invitationID,
+	})
+
+	invitation, err := iu.emailInvitationRepo.FindByID(ctx, invitationID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case invitation == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	if err = iu.emailInvitationRepo.DeleteByID(ctx, invitationID); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// LinkEmailInvitations turns the invitations addressed to the email of the newly registered member into invitations
+// of the member, the seats are taken or waitlisted like for any invitation. The invitations to gatherings deleted in
+// the meantime are dropped. It joins the transaction registering the member
+func (iu *invitationUsecase) LinkEmailInvitations(ctx context.Context, member *model.Member) ([]*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"member": member,
+	})
+
+	email, ok := model.NormalizeEmail(member.Email)
+	if !ok {
+		return nil, nil
+	}
+
+	var res []*model.Invitation
+	err := iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		emailInvitations, err := iu.emailInvitationRepo.FindByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+
+		for _, emailInvitation := range emailInvitations {
+			if err := iu.emailInvitationRepo.DeleteByID(ctx, emailInvitation.ID); err != nil {
+				return err
+			}
+
+			gathering, err := iu.gatheringRepo.FindByID(ctx, emailInvitation.GatheringID)
+			if err != nil {
+				return err
+			}
+			if gathering == nil {
+				continue
+			}
+
+			invitation := &model.Invitation{
+				ID:           model.GenerateID(),
+				MemberID:     member.ID,
+				GatheringID:  emailInvitation.GatheringID,
+				OccurrenceAt: emailInvitation.OccurrenceAt,
+				Status:       emailInvitation.Status,
+			}
+			if err := iu.createOrReviveInvitation(ctx, gathering, invitation, false); err != nil {
+				return err
+			}
+			if err := publishEvent(ctx, iu.eventPublisher, model.InvitationLinked, invitation); err != nil {
+				return err
+			}
+			res = append(res, invitation)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
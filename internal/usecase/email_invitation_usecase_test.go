@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInviteEmailToGatheringUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 444, Name: "gathering"}
+
+	t.Run("success", func(t *testing.T) {
+		invitation := &model.EmailInvitation{ID: 123, GatheringID: gathering.ID, Email: " John@Doe.com ", Status: model.Active}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByEmail(ctx, "john@doe.com").Times(1).Return(nil, nil)
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().Create(ctx, invitation).Times(1).Return(nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			memberRepo:          mockMemberRepo,
+			gatheringRepo:       mockGatheringRepo,
+			emailInvitationRepo: mockEmailInvitationRepo,
+			transactionManager:  initializeTransactionManagerMock(ctrl),
+			eventPublisher:      eventRecorder,
+		}
+
+		res, err := invitationUsecase.InviteEmailToGathering(ctx, invitation)
+		assert.NoError(t, err)
+		assert.Equal(t, "john@doe.com", res.Email)
+		assert.Equal(t, model.Pending, res.Status)
+		assert.Equal(t, []model.EventType{model.EmailInvitationSent}, eventRecorder.Types())
+	})
+
+	t.Run("failed, invalid email", func(t *testing.T) {
+		for _, email := range []string{"", "john", "John <john@doe.com>", "john@doe.com, jane@doe.com"} {
+			invitationUsecase := invitationUsecase{}
+
+			res, err := invitationUsecase.InviteEmailToGathering(ctx, &model.EmailInvitation{GatheringID: gathering.ID, Email: email})
+			assert.Nil(t, res)
+			assert.ErrorIs(t, err, ErrInvalidEmail, email)
+		}
+	})
+
+	t.Run("failed, email belongs to a member", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByEmail(ctx, "john@doe.com").Times(1).Return(&model.Member{ID: 321}, nil)
+
+		invitationUsecase := invitationUsecase{
+			memberRepo:    mockMemberRepo,
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		res, err := invitationUsecase.InviteEmailToGathering(ctx, &model.EmailInvitation{GatheringID: gathering.ID, Email: "john@doe.com"})
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrEmailAlreadyRegistered)
+	})
+
+	t.Run("failed, email already invited", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByEmail(ctx, "john@doe.com").Times(1).Return(nil, nil)
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(model.ErrDuplicateEntry)
+
+		invitationUsecase := invitationUsecase{
+			memberRepo:          mockMemberRepo,
+			gatheringRepo:       mockGatheringRepo,
+			emailInvitationRepo: mockEmailInvitationRepo,
+			transactionManager:  initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.InviteEmailToGathering(ctx, &model.EmailInvitation{GatheringID: gathering.ID, Email: "john@doe.com"})
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvitationAlreadyExists)
+	})
+
+	t.Run("failed, gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		res, err := invitationUsecase.InviteEmailToGathering(ctx, &model.EmailInvitation{GatheringID: gathering.ID, Email: "john@doe.com"})
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestLinkEmailInvitationsUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	member := &model.Member{ID: 321, FirstName: "John", Email: "John@Doe.com"}
+	gathering := &model.Gathering{ID: 444, Name: "gathering"}
+
+	t.Run("success", func(t *testing.T) {
+		emailInvitations := []*model.EmailInvitation{
+			{ID: 1, GatheringID: gathering.ID, Email: "john@doe.com", Status: model.Pending},
+			{ID: 2, GatheringID: 555, Email: "john@doe.com", Status: model.Pending},
+		}
+
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().FindByEmail(ctx, "john@doe.com").Times(1).Return(emailInvitations, nil)
+		mockEmailInvitationRepo.EXPECT().DeleteByID(ctx, int64(1)).Times(1).Return(nil)
+		mockEmailInvitationRepo.EXPECT().DeleteByID(ctx, int64(2)).Times(1).Return(nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		// the second gathering was deleted while the invitation was pending
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(555)).Times(1).Return(nil, nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().Create(ctx, &model.Attendee{MemberID: member.ID, GatheringID: gathering.ID}).Times(1).Return(nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:      mockInvitationRepo,
+			gatheringRepo:       mockGatheringRepo,
+			attendeeRepo:        mockAttendeeRepo,
+			emailInvitationRepo: mockEmailInvitationRepo,
+			transactionManager:  initializeTransactionManagerMock(ctrl),
+			eventPublisher:      eventRecorder,
+		}
+
+		res, err := invitationUsecase.LinkEmailInvitations(ctx, member)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, member.ID, res[0].MemberID)
+		assert.Equal(t, gathering.ID, res[0].GatheringID)
+		assert.Equal(t, model.Pending, res[0].Status)
+		assert.Equal(t, []model.EventType{model.InvitationLinked}, eventRecorder.Types())
+	})
+
+	t.Run("success, no invitation", func(t *testing.T) {
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().FindByEmail(ctx, "john@doe.com").Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			emailInvitationRepo: mockEmailInvitationRepo,
+			transactionManager:  initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.LinkEmailInvitations(ctx, member)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("failed, error create invitation", func(t *testing.T) {
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().FindByEmail(ctx, "john@doe.com").Times(1).
+			Return([]*model.EmailInvitation{{ID: 1, GatheringID: gathering.ID, Email: "john@doe.com"}}, nil)
+		mockEmailInvitationRepo.EXPECT().DeleteByID(ctx, int64(1)).Times(1).Return(nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:      mockInvitationRepo,
+			gatheringRepo:       mockGatheringRepo,
+			emailInvitationRepo: mockEmailInvitationRepo,
+			transactionManager:  initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.LinkEmailInvitations(ctx, member)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindEmailInvitationByIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		invitation := &model.EmailInvitation{ID: 123, GatheringID: 444, Email: "john@doe.com"}
+
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+
+		invitationUsecase := invitationUsecase{
+			emailInvitationRepo: mockEmailInvitationRepo,
+		}
+
+		res, err := invitationUsecase.FindEmailInvitationByID(ctx, invitation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, invitation, res)
+	})
+
+	t.Run("failed, not found", func(t *testing.T) {
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			emailInvitationRepo: mockEmailInvitationRepo,
+		}
+
+		res, err := invitationUsecase.FindEmailInvitationByID(ctx, 123)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestDeleteEmailInvitationByIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		invitation := &model.EmailInvitation{ID: 123, GatheringID: 444, Email: "john@doe.com"}
+
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+		mockEmailInvitationRepo.EXPECT().DeleteByID(ctx, invitation.ID).Times(1).Return(nil)
+
+		invitationUsecase := invitationUsecase{
+			emailInvitationRepo: mockEmailInvitationRepo,
+		}
+
+		res, err := invitationUsecase.DeleteEmailInvitationByID(ctx, invitation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, invitation, res)
+	})
+
+	t.Run("failed, not found", func(t *testing.T) {
+		mockEmailInvitationRepo := mock.NewMockEmailInvitationRepository(ctrl)
+		mockEmailInvitationRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			emailInvitationRepo: mockEmailInvitationRepo,
+		}
+
+		res, err := invitationUsecase.DeleteEmailInvitationByID(ctx, 123)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
@@ -3,5 +3,72 @@ package usecase
 import "errors"
 
 var (
-	ErrRecordNotFound = errors.New("record not found")
+	ErrRecordNotFound           = errors.New("record not found")
+	ErrInvitationAlreadyExists  = errors.New("member is already invited to this gathering")
+	ErrInvitedToOtherOccurrence = errors.New("member is already invited to another occurrence of this gathering, invite them to the series instead")
+	ErrInvitationDeclined       = errors.New("invitation was declined, invite the member again")
+	ErrInvitationExpired        = errors.New("invitation has expired, invite the member again")
+	ErrInvitationForbidden      = errors.New("invitation belongs to another member")
+	ErrInvalidEmail             = errors.New("invalid email address")
+	ErrEmailAlreadyRegistered   = errors.New("email belongs to a member, invite the member instead")
+
+	ErrRecordNotDeleted     = errors.New("record is not deleted")
+	ErrRestoreParentDeleted = errors.New("member or gathering of the record is deleted, restore it first")
+	ErrDeletionRestricted   = errors.New("record still has live invitations, delete them first")
+
+	ErrBulkInvitationEmpty       = errors.New("no member to invite")
+	ErrBulkInvitationTooLarge    = errors.New("too many members to invite at once")
+	ErrInvalidBulkInvitationMode = errors.New("invalid bulk invitation mode")
+	ErrBulkInvitationRejected    = errors.New("bulk invitation rejected, no member was invited")
+
+	ErrInvalidWebhookURL       = errors.New("webhook URL must be an absolute http or https URL")
+	ErrInvalidWebhookEventType = errors.New("unknown or missing webhook event type")
+	ErrWebhookDeliveryPending  = errors.New("webhook delivery is still pending")
+	ErrWebhookForbidden        = errors.New("webhook subscription belongs to another member")
+
+	ErrInvalidTimeZone          = errors.New("time zone must be an IANA time zone name such as Asia/Jakarta")
+	ErrInvalidGatheringSchedule = errors.New("gathering must end after it starts, and an end needs a start")
+	ErrGatheringStartInPast     = errors.New("gathering must start in the future")
+	ErrGatheringNotScheduled    = errors.New("gathering is not scheduled yet")
+	ErrInvalidRecurrence        = errors.New("invalid gathering recurrence")
+	ErrInvalidOccurrence        = errors.New("occurrence is not part of the gathering recurrence")
+
+	ErrInvalidReminderOffset = errors.New("reminder offsets must be distinct, between 1 minute and 30 days, and at most 10")
+
+	ErrJobNotDead = errors.New("only dead jobs can be retried")
+
+	ErrOutboxMessageNotDeadLettered = errors.New("only dead-lettered outbox messages can be replayed")
+
+	ErrInvalidDateRange = errors.New("date range must end after it starts")
+
+	ErrInvalidMemberImport = errors.New("invalid member import file")
+	ErrMemberNameRequired  = errors.New("first and last name are required")
+	ErrMemberFieldTooLong  = errors.New("names and email can't be longer than 100 characters")
+
+	ErrInvalidMemberGroupName  = errors.New("group name is required and can't be longer than 100 characters")
+	ErrMemberGroupMembersEmpty = errors.New("no member to add or remove")
+	ErrMemberGroupTooLarge     = errors.New("a group can't have more than 500 members")
+	ErrMemberGroupForbidden    = errors.New("member group belongs to another member")
+
+	ErrInvalidGatheringRole = errors.New("role must be co_host or moderator")
+	ErrGatheringOwnerRole   = errors.New("the owner keeps their role, transfer the ownership instead")
+	ErrGatheringForbidden   = errors.New("member's role on the gathering does not allow this")
+
+	ErrInvalidCapacity = errors.New("gathering capacity can't be negative")
+
+	ErrInvalidAllowedGuests     = errors.New("allowed guests can't be negative")
+	ErrAllowedGuestsBelowGuests = errors.New("allowed guests can't be lower than the guests the member brings")
+	ErrInvalidGuests            = errors.New("guests must be between 0 and the allowed guests of the invitation, with at most one name each")
+	ErrGuestsNotAccepted        = errors.New("only an accepted invitation can bring guests")
+	ErrNotEnoughSeats           = errors.New("not enough seats left on the gathering for the guests")
+
+	ErrAlreadyCheckedIn = errors.New("attendee has already checked in")
+	ErrNotCheckedIn     = errors.New("attendee has not checked in")
+	ErrSeriesCheckIn    = errors.New("members check in to one occurrence of a recurring gathering, invite them to the occurrence")
+
+	ErrInviteLinksDisabled = errors.New("invite links are disabled")
+	ErrInvalidInviteLink   = errors.New("invite link must expire in the future and its max uses can't be negative")
+	ErrInviteLinkExpired   = errors.New("invite link has expired")
+	ErrInviteLinkRevoked   = errors.New("invite link was revoked")
+	ErrInviteLinkUsedUp    = errors.New("invite link has no use left")
 )
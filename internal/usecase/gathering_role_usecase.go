@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type gatheringRoleUsecase struct {
+	roleRepo           model.GatheringRoleRepository
+	gatheringRepo      model.GatheringRepository
+	memberRepo         model.MemberRepository
+	transactionManager model.TransactionManager
+}
+
+func NewGatheringRoleUsecase(
+	roleRepo model.GatheringRoleRepository,
+	gatheringRepo model.GatheringRepository,
+	memberRepo model.MemberRepository,
+	transactionManager model.TransactionManager,
+) model.GatheringRoleUsecase {
+	return &gatheringRoleUsecase{
+		roleRepo:           roleRepo,
+		gatheringRepo:      gatheringRepo,
+		memberRepo:         memberRepo,
+		transactionManager: transactionManager,
+	}
+}
+
+func (ru *gatheringRoleUsecase) FindGatheringRoles(ctx context.Context, gatheringID int64) ([]*model.GatheringRole, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gathering, err := ru.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	res, err := ru.roleRepo.FindByGatheringID(ctx, gatheringID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AddGatheringRole makes the member a co-host or a moderator of the gathering, it replaces the role they already
+// had. The owner can't be given another role, the ownership is transferred instead
+func (ru *gatheringRoleUsecase) AddGatheringRole(ctx context.Context, role *model.GatheringRole) (*model.GatheringRole, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  ctx,
+		"role": role,
+	})
+
+	if !role.Role.IsValid() || role.Role == model.GatheringOwner {
+		return nil, ErrInvalidGatheringRole
+	}
+
+	var res *model.GatheringRole
+	err := ru.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// the gathering is locked so its roles change one at a time
+		gathering, err := ru.gatheringRepo.LockByID(ctx, role.GatheringID)
+		switch {
+		case err != nil:
+			return err
+		case gathering == nil:
+			return ErrRecordNotFound
+		case gathering.Creator == role.MemberID:
+			return ErrGatheringOwnerRole
+		}
+
+		member, err := ru.memberRepo.FindByID(ctx, role.MemberID)
+		switch {
+		case err != nil:
+			return err
+		case member == nil:
+			return ErrRecordNotFound
+		}
+
+		if err := ru.roleRepo.Save(ctx, role); err != nil {
+			return err
+		}
+
+		res, err = ru.roleRepo.FindByGatheringIDAndMemberID(ctx, role.GatheringID, role.MemberID)
+		return err
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RemoveGatheringRole takes the role of the member on the gathering away, the owner keeps theirs
+func (ru *gatheringRoleUsecase) RemoveGatheringRole(ctx context.Context, gatheringID int64, memberID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"memberID":    memberID,
+	})
+
+	err := ru.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		gathering, err := ru.gatheringRepo.LockByID(ctx, gatheringID)
+		switch {
+		case err != nil:
+			return err
+		case gathering == nil:
+			return ErrRecordNotFound
+		case gathering.Creator == memberID:
+			return ErrGatheringOwnerRole
+		}
+
+		role, err := ru.roleRepo.FindByGatheringIDAndMemberID(ctx, gatheringID, memberID)
+		switch {
+		case err != nil:
+			return err
+		case role == nil:
+			return ErrRecordNotFound
+		}
+
+		return ru.roleRepo.DeleteByGatheringIDAndMemberID(ctx, gatheringID, memberID)
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// TransferGatheringOwnership makes the member the owner and Creator of the gathering, the previous owner stays on as
+// a co-host
+func (ru *gatheringRoleUsecase) TransferGatheringOwnership(ctx context.Context, gatheringID int64, newOwner int64) (*model.Gathering, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"newOwner":    newOwner,
+	})
+
+	var res *model.Gathering
+	err := ru.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		gathering, err := ru.gatheringRepo.LockByID(ctx, gatheringID)
+		switch {
+		case err != nil:
+			return err
+		case gathering == nil:
+			return ErrRecordNotFound
+		case gathering.Creator == newOwner:
+			res = gathering
+			return nil
+		}
+
+		member, err := ru.memberRepo.FindByID(ctx, newOwner)
+		switch {
+		case err != nil:
+			return err
+		case member == nil:
+			return ErrRecordNotFound
+		}
+
+		err = ru.roleRepo.Save(ctx, &model.GatheringRole{
+			GatheringID: gatheringID,
+			MemberID:    gathering.Creator,
+			Role:        model.GatheringCoHost,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = ru.roleRepo.Save(ctx, &model.GatheringRole{
+			GatheringID: gatheringID,
+			MemberID:    newOwner,
+			Role:        model.GatheringOwner,
+		})
+		if err != nil {
+			return err
+		}
+
+		gathering.Creator = newOwner
+		res, err = ru.gatheringRepo.UpdateByID(ctx, gathering)
+		return err
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AuthorizeGathering checks the role of the actor on the gathering grants the permission
+func (ru *gatheringRoleUsecase) AuthorizeGathering(ctx context.Context, gatheringID int64, actorID int64, permission model.GatheringPermission) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"actorID":     actorID,
+		"permission":  permission,
+	})
+
+	gathering, err := ru.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return err
+	case gathering == nil:
+		return ErrRecordNotFound
+	}
+
+	role, err := ru.roleRepo.FindByGatheringIDAndMemberID(ctx, gatheringID, actorID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return err
+	case role == nil || !role.Role.Can(permission):
+		return ErrGatheringForbidden
+	}
+
+	return nil
+}
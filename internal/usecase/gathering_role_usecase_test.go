@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddGatheringRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 1, Creator: 123}
+
+	t.Run("success", func(t *testing.T) {
+		role := &model.GatheringRole{GatheringID: 1, MemberID: 10, Role: model.GatheringCoHost}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(10)).Times(1).Return(&model.Member{ID: 10}, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().Save(ctx, role).Times(1).Return(nil)
+		mockRoleRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, int64(1), int64(10)).Times(1).Return(role, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			roleRepo:           mockRoleRepo,
+			gatheringRepo:      mockGatheringRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := gatheringRoleUsecase.AddGatheringRole(ctx, role)
+
+		assert.NoError(t, err)
+		assert.Equal(t, role, res)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		gatheringRoleUsecase := gatheringRoleUsecase{}
+
+		_, err := gatheringRoleUsecase.AddGatheringRole(ctx, &model.GatheringRole{GatheringID: 1, MemberID: 10, Role: "admin"})
+		assert.ErrorIs(t, err, ErrInvalidGatheringRole)
+
+		_, err = gatheringRoleUsecase.AddGatheringRole(ctx, &model.GatheringRole{GatheringID: 1, MemberID: 10, Role: model.GatheringOwner})
+		assert.ErrorIs(t, err, ErrInvalidGatheringRole)
+	})
+
+	t.Run("member is the owner", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		_, err := gatheringRoleUsecase.AddGatheringRole(ctx, &model.GatheringRole{GatheringID: 1, MemberID: 123, Role: model.GatheringModerator})
+
+		assert.ErrorIs(t, err, ErrGatheringOwnerRole)
+	})
+
+	t.Run("member not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(10)).Times(1).Return(nil, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		_, err := gatheringRoleUsecase.AddGatheringRole(ctx, &model.GatheringRole{GatheringID: 1, MemberID: 10, Role: model.GatheringCoHost})
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestRemoveGatheringRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 1, Creator: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, int64(1), int64(10)).Times(1).
+			Return(&model.GatheringRole{GatheringID: 1, MemberID: 10, Role: model.GatheringCoHost}, nil)
+		mockRoleRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, int64(1), int64(10)).Times(1).Return(nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			roleRepo:           mockRoleRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		err := gatheringRoleUsecase.RemoveGatheringRole(ctx, 1, 10)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("member is the owner", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		err := gatheringRoleUsecase.RemoveGatheringRole(ctx, 1, 123)
+
+		assert.ErrorIs(t, err, ErrGatheringOwnerRole)
+	})
+
+	t.Run("role not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, int64(1), int64(10)).Times(1).Return(nil, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			roleRepo:           mockRoleRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		err := gatheringRoleUsecase.RemoveGatheringRole(ctx, 1, 10)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestTransferGatheringOwnership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 1, Creator: 123}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockGatheringRepo.EXPECT().UpdateByID(ctx, gathering).Times(1).Return(gathering, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(10)).Times(1).Return(&model.Member{ID: 10}, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		gomock.InOrder(
+			mockRoleRepo.EXPECT().Save(ctx, &model.GatheringRole{GatheringID: 1, MemberID: 123, Role: model.GatheringCoHost}).Times(1).Return(nil),
+			mockRoleRepo.EXPECT().Save(ctx, &model.GatheringRole{GatheringID: 1, MemberID: 10, Role: model.GatheringOwner}).Times(1).Return(nil),
+		)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			roleRepo:           mockRoleRepo,
+			gatheringRepo:      mockGatheringRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := gatheringRoleUsecase.TransferGatheringOwnership(ctx, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), res.Creator)
+	})
+
+	t.Run("member is already the owner", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 1, Creator: 123}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := gatheringRoleUsecase.TransferGatheringOwnership(ctx, 1, 123)
+
+		assert.NoError(t, err)
+		assert.Equal(t, gathering, res)
+	})
+
+	t.Run("error on saving role", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 1, Creator: 123}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(10)).Times(1).Return(&model.Member{ID: 10}, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().Save(ctx, gomock.Any()).Times(1).Return(errors.New("error"))
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			roleRepo:           mockRoleRepo,
+			gatheringRepo:      mockGatheringRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := gatheringRoleUsecase.TransferGatheringOwnership(ctx, 1, 10)
+
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorizeGathering(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 1, Creator: 123}
+
+	t.Run("moderator can invite but not update", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(2).Return(gathering, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, int64(1), int64(10)).Times(2).
+			Return(&model.GatheringRole{GatheringID: 1, MemberID: 10, Role: model.GatheringModerator}, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			roleRepo:      mockRoleRepo,
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		err := gatheringRoleUsecase.AuthorizeGathering(ctx, 1, 10, model.InviteToGathering)
+		assert.NoError(t, err)
+
+		err = gatheringRoleUsecase.AuthorizeGathering(ctx, 1, 10, model.UpdateGathering)
+		assert.ErrorIs(t, err, ErrGatheringForbidden)
+	})
+
+	t.Run("no role", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, int64(1), int64(10)).Times(1).Return(nil, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			roleRepo:      mockRoleRepo,
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		err := gatheringRoleUsecase.AuthorizeGathering(ctx, 1, 10, model.InviteToGathering)
+
+		assert.ErrorIs(t, err, ErrGatheringForbidden)
+	})
+
+	t.Run("gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		gatheringRoleUsecase := gatheringRoleUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		err := gatheringRoleUsecase.AuthorizeGathering(ctx, 1, 10, model.DeleteGathering)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
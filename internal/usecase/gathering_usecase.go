@@ -2,18 +2,50 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/sirupsen/logrus"
 )
 
 type gatheringUsecase struct {
-	gatheringRepo model.GatheringRepository
+	gatheringRepo      model.GatheringRepository
+	invitationRepo     model.InvitationRepository
+	attendeeRepo       model.AttendeeRepository
+	reminderRepo       model.GatheringReminderRepository
+	waitlistRepo       model.WaitlistRepository
+	roleRepo           model.GatheringRoleRepository
+	transactionManager model.TransactionManager
+	eventPublisher     model.EventPublisher
+	deletionPolicy     model.DeletionPolicy
+	reminderOffsets    []int
 }
 
-func NewGatheringUsecase(gatheringRepo model.GatheringRepository) model.GatheringUsecase {
+func NewGatheringUsecase(
+	gatheringRepo model.GatheringRepository,
+	invitationRepo model.InvitationRepository,
+	attendeeRepo model.AttendeeRepository,
+	reminderRepo model.GatheringReminderRepository,
+	waitlistRepo model.WaitlistRepository,
+	roleRepo model.GatheringRoleRepository,
+	transactionManager model.TransactionManager,
+	eventPublisher model.EventPublisher,
+	deletionPolicy model.DeletionPolicy,
+	reminderOffsets []int,
+) model.GatheringUsecase {
 	return &gatheringUsecase{
-		gatheringRepo: gatheringRepo,
+		gatheringRepo:      gatheringRepo,
+		invitationRepo:     invitationRepo,
+		attendeeRepo:       attendeeRepo,
+		reminderRepo:       reminderRepo,
+		waitlistRepo:       waitlistRepo,
+		roleRepo:           roleRepo,
+		transactionManager: transactionManager,
+		eventPublisher:     eventPublisher,
+		deletionPolicy:     deletionPolicy,
+		reminderOffsets:    reminderOffsets,
 	}
 }
 
@@ -22,7 +54,44 @@ func (gu *gatheringUsecase) CreateGathering(ctx context.Context, gathering *mode
 		"ctx":       ctx,
 		"gathering": gathering,
 	})
-	err := gu.gatheringRepo.Create(ctx, gathering)
+
+	now := time.Now()
+	if gathering.Capacity < 0 {
+		return ErrInvalidCapacity
+	}
+	if err := normalizeGatheringSchedule(gathering); err != nil {
+		return err
+	}
+	if gathering.ScheduledAt != nil && !gathering.ScheduledAt.After(now) {
+		return ErrGatheringStartInPast
+	}
+
+	err := gu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := gu.gatheringRepo.Create(ctx, gathering); err != nil {
+			return err
+		}
+
+		err := gu.roleRepo.Save(ctx, &model.GatheringRole{
+			GatheringID: gathering.ID,
+			MemberID:    gathering.Creator,
+			Role:        model.GatheringOwner,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(gu.reminderOffsets) > 0 {
+			reminders := make([]*model.GatheringReminder, 0, len(gu.reminderOffsets))
+			for _, offsetMinutes := range gu.reminderOffsets {
+				reminders = append(reminders, model.NewGatheringReminder(gathering, offsetMinutes, now))
+			}
+			if err := gu.reminderRepo.Save(ctx, gathering.ID, reminders); err != nil {
+				return err
+			}
+		}
+
+		return publishEvent(ctx, gu.eventPublisher, model.GatheringCreated, gathering)
+	})
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -54,6 +123,13 @@ func (gu *gatheringUsecase) UpdateGatheringByID(ctx context.Context, gathering *
 		"gathering": gathering,
 	})
 
+	if gathering.Capacity < 0 {
+		return nil, ErrInvalidCapacity
+	}
+	if err := normalizeGatheringSchedule(gathering); err != nil {
+		return nil, err
+	}
+
 	oldGathering, err := gu.gatheringRepo.FindByID(ctx, gathering.ID)
 	switch {
 	case err != nil:
@@ -62,8 +138,51 @@ func (gu *gatheringUsecase) UpdateGatheringByID(ctx context.Context, gathering *
 	case oldGathering == nil:
 		return nil, ErrRecordNotFound
 	}
+	// the owner only changes when the ownership is transferred
+	gathering.Creator = oldGathering.Creator
+
+	var res *model.Gathering
+	err = gu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err = gu.gatheringRepo.UpdateByID(ctx, gathering)
+		if err != nil {
+			return err
+		}
 
-	res, err := gu.gatheringRepo.UpdateByID(ctx, gathering)
+		// the seats added to a full gathering go to its waitlist
+		if oldGathering.IsCapacityLimited() && (!res.IsCapacityLimited() || res.Capacity > oldGathering.Capacity) {
+			promoter := &waitlistPromoter{
+				gatheringRepo:  gu.gatheringRepo,
+				invitationRepo: gu.invitationRepo,
+				attendeeRepo:   gu.attendeeRepo,
+				waitlistRepo:   gu.waitlistRepo,
+				eventPublisher: gu.eventPublisher,
+			}
+			if _, err := promoter.promote(ctx, res.ID); err != nil {
+				return err
+			}
+		}
+
+		if sameTime(oldGathering.ScheduledAt, res.ScheduledAt) {
+			return nil
+		}
+
+		reminders, err := gu.reminderRepo.FindByGatheringID(ctx, res.ID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, reminder := range reminders {
+			reminder.Schedule(res.ScheduledAt, now)
+		}
+		if err = gu.reminderRepo.Save(ctx, res.ID, reminders); err != nil {
+			return err
+		}
+
+		return publishEvent(ctx, gu.eventPublisher, model.GatheringRescheduled, &model.GatheringRescheduledPayload{
+			Gathering:           res,
+			PreviousScheduledAt: oldGathering.ScheduledAt,
+		})
+	})
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -87,7 +206,107 @@ func (gu *gatheringUsecase) DeleteGatheringByID(ctx context.Context, gatheringID
 		return nil, ErrRecordNotFound
 	}
 
-	res, err := gu.gatheringRepo.DeleteByID(ctx, gatheringID)
+	var res *model.Gathering
+	err = gu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		invitations, err := gu.invitationRepo.FindByGatheringID(ctx, gatheringID)
+		switch {
+		case err != nil:
+			return err
+		case gu.deletionPolicy == model.RestrictDeletion && hasLiveInvitation(invitations):
+			return ErrDeletionRestricted
+		}
+
+		if err := gu.invitationRepo.DeleteByGatheringID(ctx, gatheringID); err != nil {
+			return err
+		}
+
+		if err := gu.attendeeRepo.DeleteByGatheringID(ctx, gatheringID); err != nil {
+			return err
+		}
+
+		if err := gu.waitlistRepo.DeleteByGatheringID(ctx, gatheringID); err != nil {
+			return err
+		}
+
+		res, err = gu.gatheringRepo.DeleteByID(ctx, gatheringID)
+		if err != nil {
+			return err
+		}
+
+		return publishEvent(ctx, gu.eventPublisher, model.GatheringCancelled, &model.GatheringCancelledPayload{
+			Gathering: res,
+			MemberIDs: invitedMemberIDs(invitations),
+		})
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// normalizeGatheringSchedule validates the time zone, the start and end and the recurrence of the gathering,
+// the start and end are stored in UTC and rendered in the gathering time zone
+func normalizeGatheringSchedule(gathering *model.Gathering) error {
+	if gathering.TimeZone == "" {
+		gathering.TimeZone = model.DefaultTimeZone
+	}
+	// "Local" is the server zone, not an IANA one
+	if _, err := time.LoadLocation(gathering.TimeZone); err != nil || gathering.TimeZone == "Local" {
+		return ErrInvalidTimeZone
+	}
+
+	if gathering.EndsAt != nil && (gathering.ScheduledAt == nil || !gathering.EndsAt.After(*gathering.ScheduledAt)) {
+		return ErrInvalidGatheringSchedule
+	}
+
+	if gathering.ScheduledAt != nil {
+		scheduledAt := gathering.ScheduledAt.UTC()
+		gathering.ScheduledAt = &scheduledAt
+	}
+	if gathering.EndsAt != nil {
+		endsAt := gathering.EndsAt.UTC()
+		gathering.EndsAt = &endsAt
+	}
+
+	gathering.Recurrence = strings.TrimPrefix(strings.TrimSpace(gathering.Recurrence), "RRULE:")
+	if gathering.Recurrence != "" {
+		if _, err := gathering.RecurrenceRule(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+		}
+	}
+	return nil
+}
+
+// hasLiveInvitation reports whether any of the invitations still stands, expired and declined ones do not restrict a deletion
+func hasLiveInvitation(invitations []*model.Invitation) bool {
+	for _, invitation := range invitations {
+		if invitation.IsLive() {
+			return true
+		}
+	}
+	return false
+}
+
+// invitedMemberIDs lists the members the gathering cancellation has to be told to, the members who declined or let
+// their invitation expire are not coming anyway
+func invitedMemberIDs(invitations []*model.Invitation) []int64 {
+	memberIDs := make([]int64, 0, len(invitations))
+	for _, invitation := range invitations {
+		if invitation.IsLive() {
+			memberIDs = append(memberIDs, invitation.MemberID)
+		}
+	}
+	return memberIDs
+}
+
+func (gu *gatheringUsecase) FindDeletedGatherings(ctx context.Context) ([]*model.Gathering, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	res, err := gu.gatheringRepo.FindAllDeleted(ctx)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -95,3 +314,30 @@ func (gu *gatheringUsecase) DeleteGatheringByID(ctx context.Context, gatheringID
 
 	return res, nil
 }
+
+func (gu *gatheringUsecase) RestoreGatheringByID(ctx context.Context, gatheringID int64) (*model.Gathering, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gathering, err := gu.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering != nil:
+		return nil, ErrRecordNotDeleted
+	}
+
+	res, err := gu.gatheringRepo.RestoreByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case res == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	return res, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
 	"github.com/golang/mock/gomock"
@@ -33,14 +34,141 @@ func TestCreateGatheringUsecase(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
 		mockGatheringRepo.EXPECT().Create(ctx, gathering).Times(1).Return(nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().Save(ctx, &model.GatheringRole{
+			GatheringID: gathering.ID,
+			MemberID:    gathering.Creator,
+			Role:        model.GatheringOwner,
+		}).Times(1).Return(nil)
+		eventRecorder := event.NewRecorder()
 
 		gatheringUsecase := gatheringUsecase{
-			gatheringRepo: mockGatheringRepo,
+			gatheringRepo:      mockGatheringRepo,
+			roleRepo:           mockRoleRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		err := gatheringUsecase.CreateGathering(ctx, gathering)
 
 		assert.NoError(t, err)
+		assert.Equal(t, []model.EventType{model.GatheringCreated}, eventRecorder.Types())
+	})
+
+	t.Run("success with default reminders", func(t *testing.T) {
+		scheduledAt := time.Now().Add(48 * time.Hour)
+		scheduledGathering := &model.Gathering{ID: 124, Creator: 321, ScheduledAt: &scheduledAt}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().Create(ctx, scheduledGathering).Times(1).Return(nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().Save(ctx, gomock.Any()).Times(1).Return(nil)
+		mockReminderRepo := mock.NewMockGatheringReminderRepository(ctrl)
+		mockReminderRepo.EXPECT().Save(ctx, scheduledGathering.ID, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ int64, reminders []*model.GatheringReminder) error {
+				assert.Len(t, reminders, 2)
+				assert.Equal(t, 1440, reminders[0].OffsetMinutes)
+				assert.True(t, scheduledAt.Add(-24*time.Hour).Equal(*reminders[0].RemindAt))
+				assert.Equal(t, 60, reminders[1].OffsetMinutes)
+				return nil
+			})
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			roleRepo:           mockRoleRepo,
+			reminderRepo:       mockReminderRepo,
+			reminderOffsets:    []int{1440, 60},
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		err := gatheringUsecase.CreateGathering(ctx, scheduledGathering)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success, schedule is stored in UTC", func(t *testing.T) {
+		jakarta, _ := time.LoadLocation("Asia/Jakarta")
+		scheduledAt := time.Now().Add(48 * time.Hour).In(jakarta)
+		endsAt := scheduledAt.Add(2 * time.Hour)
+		scheduledGathering := &model.Gathering{ID: 125, ScheduledAt: &scheduledAt, EndsAt: &endsAt, TimeZone: "Asia/Jakarta"}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().Create(ctx, scheduledGathering).Times(1).Return(nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().Save(ctx, gomock.Any()).Times(1).Return(nil)
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			roleRepo:           mockRoleRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		err := gatheringUsecase.CreateGathering(ctx, scheduledGathering)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.UTC, scheduledGathering.ScheduledAt.Location())
+		assert.True(t, scheduledAt.Equal(*scheduledGathering.ScheduledAt))
+		assert.Equal(t, time.UTC, scheduledGathering.EndsAt.Location())
+		assert.Equal(t, jakarta, scheduledGathering.LocalScheduledAt().Location())
+	})
+
+	t.Run("success, default time zone", func(t *testing.T) {
+		unscheduledGathering := &model.Gathering{ID: 126}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().Create(ctx, unscheduledGathering).Times(1).Return(nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().Save(ctx, gomock.Any()).Times(1).Return(nil)
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			roleRepo:           mockRoleRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		err := gatheringUsecase.CreateGathering(ctx, unscheduledGathering)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.DefaultTimeZone, unscheduledGathering.TimeZone)
+	})
+
+	t.Run("failed, invalid schedule", func(t *testing.T) {
+		future := time.Now().Add(time.Hour)
+		past := time.Now().Add(-time.Hour)
+		beforeFuture := future.Add(-time.Minute)
+
+		tests := []struct {
+			name      string
+			gathering *model.Gathering
+			err       error
+		}{
+			{"unknown time zone", &model.Gathering{TimeZone: "Mars/Olympus_Mons"}, ErrInvalidTimeZone},
+			{"server time zone", &model.Gathering{TimeZone: "Local"}, ErrInvalidTimeZone},
+			{"end without start", &model.Gathering{EndsAt: &future}, ErrInvalidGatheringSchedule},
+			{"end before start", &model.Gathering{ScheduledAt: &future, EndsAt: &beforeFuture}, ErrInvalidGatheringSchedule},
+			{"end at start", &model.Gathering{ScheduledAt: &future, EndsAt: &future}, ErrInvalidGatheringSchedule},
+			{"start in the past", &model.Gathering{ScheduledAt: &past}, ErrGatheringStartInPast},
+			{"recurrence without start", &model.Gathering{Recurrence: "FREQ=WEEKLY"}, ErrInvalidRecurrence},
+			{"unparsable recurrence", &model.Gathering{ScheduledAt: &future, Recurrence: "FREQ=SOMETIMES"}, ErrInvalidRecurrence},
+			{"yearly recurrence", &model.Gathering{ScheduledAt: &future, Recurrence: "FREQ=YEARLY"}, ErrInvalidRecurrence},
+			{"recurrence within a day", &model.Gathering{ScheduledAt: &future, Recurrence: "FREQ=DAILY;BYHOUR=9,18"}, ErrInvalidRecurrence},
+			{"negative capacity", &model.Gathering{Capacity: -1}, ErrInvalidCapacity},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+				mockGatheringRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+				gatheringUsecase := gatheringUsecase{
+					gatheringRepo: mockGatheringRepo,
+				}
+
+				err := gatheringUsecase.CreateGathering(ctx, tt.gathering)
+
+				assert.ErrorIs(t, err, tt.err)
+			})
+		}
 	})
 
 	t.Run("error create member", func(t *testing.T) {
@@ -48,7 +176,8 @@ func TestCreateGatheringUsecase(t *testing.T) {
 		mockGatheringRepo.EXPECT().Create(ctx, gathering).Times(1).Return(errors.New("error"))
 
 		gatheringUsecase := gatheringUsecase{
-			gatheringRepo: mockGatheringRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		err := gatheringUsecase.CreateGathering(ctx, gathering)
@@ -142,14 +271,113 @@ func TestUpdateGatheringByIDUsecase(t *testing.T) {
 
 		mockGatheringRepo.EXPECT().UpdateByID(ctx, gathering).Times(1).Return(gathering, nil)
 
+		eventRecorder := event.NewRecorder()
+
 		gatheringUsecase := gatheringUsecase{
-			gatheringRepo: mockGatheringRepo,
+			gatheringRepo:      mockGatheringRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := gatheringUsecase.UpdateGatheringByID(ctx, gathering)
 		assert.NotNil(t, res)
 		assert.NoError(t, err)
 		assert.Equal(t, "xxx", res.Name)
+		assert.Empty(t, eventRecorder.Events())
+	})
+
+	t.Run("success, owner is kept", func(t *testing.T) {
+		oldGathering := &model.Gathering{ID: gathering.ID, Creator: 321, Name: "aaa"}
+		newGathering := &model.Gathering{ID: gathering.ID, Creator: 999, Name: "xxx"}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(oldGathering, nil)
+		mockGatheringRepo.EXPECT().UpdateByID(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, g *model.Gathering) (*model.Gathering, error) {
+				return g, nil
+			})
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := gatheringUsecase.UpdateGatheringByID(ctx, newGathering)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(321), res.Creator)
+	})
+
+	t.Run("success, rescheduled", func(t *testing.T) {
+		scheduledAt := date.Add(24 * time.Hour)
+		rescheduledAt := date.Add(48 * time.Hour)
+		oldGathering := &model.Gathering{ID: gathering.ID, Name: gathering.Name, ScheduledAt: &scheduledAt}
+		newGathering := &model.Gathering{ID: gathering.ID, Name: gathering.Name, ScheduledAt: &rescheduledAt}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(oldGathering, nil)
+		mockGatheringRepo.EXPECT().UpdateByID(ctx, newGathering).Times(1).Return(newGathering, nil)
+		reminder := model.NewGatheringReminder(oldGathering, 60, date)
+		mockReminderRepo := mock.NewMockGatheringReminderRepository(ctrl)
+		mockReminderRepo.EXPECT().FindByGatheringID(ctx, gathering.ID).Times(1).
+			Return([]*model.GatheringReminder{reminder}, nil)
+		mockReminderRepo.EXPECT().Save(ctx, gathering.ID, []*model.GatheringReminder{reminder}).Times(1).Return(nil)
+		eventRecorder := event.NewRecorder()
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			reminderRepo:       mockReminderRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := gatheringUsecase.UpdateGatheringByID(ctx, newGathering)
+		assert.NotNil(t, res)
+		assert.NoError(t, err)
+		assert.True(t, rescheduledAt.Add(-time.Hour).Equal(*reminder.RemindAt))
+
+		events := eventRecorder.Events()
+		assert.Len(t, events, 1)
+		assert.Equal(t, model.GatheringRescheduled, events[0].Type)
+
+		var payload model.GatheringRescheduledPayload
+		assert.NoError(t, events[0].DecodePayload(&payload))
+		assert.True(t, scheduledAt.Equal(*payload.PreviousScheduledAt))
+		assert.True(t, rescheduledAt.Equal(*payload.Gathering.ScheduledAt))
+	})
+
+	t.Run("success, raised capacity promotes the waitlist", func(t *testing.T) {
+		oldGathering := &model.Gathering{ID: gathering.ID, Name: gathering.Name, Type: model.WithFixedNumberOfAttendees, Capacity: 1}
+		newGathering := &model.Gathering{ID: gathering.ID, Name: gathering.Name, Type: model.WithFixedNumberOfAttendees, Capacity: 2}
+		invitation := &model.Invitation{ID: 10, MemberID: 20, GatheringID: gathering.ID, Status: model.Pending}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(oldGathering, nil)
+		mockGatheringRepo.EXPECT().UpdateByID(ctx, newGathering).Times(1).Return(newGathering, nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, gathering.ID).Times(1).Return(newGathering, nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().CountSeatsByGatheringID(ctx, gathering.ID).Times(1).Return(int64(1), nil)
+		mockAttendeeRepo.EXPECT().Revive(ctx, &model.Attendee{MemberID: invitation.MemberID, GatheringID: gathering.ID}).Times(1).Return(nil)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+		mockWaitlistRepo.EXPECT().FindFirstByGatheringID(ctx, gathering.ID).Times(1).
+			Return(&model.WaitlistEntry{GatheringID: gathering.ID, MemberID: invitation.MemberID, Position: 1}, nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, gathering.ID, invitation.MemberID).Times(1).Return(nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, gathering.ID).Times(1).Return(invitation, nil)
+		eventRecorder := event.NewRecorder()
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := gatheringUsecase.UpdateGatheringByID(ctx, newGathering)
+		assert.NotNil(t, res)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventType{model.InvitationPromoted}, eventRecorder.Types())
 	})
 
 	t.Run("failed, error find by ID", func(t *testing.T) {
@@ -159,7 +387,8 @@ func TestUpdateGatheringByIDUsecase(t *testing.T) {
 		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, errors.New("error"))
 
 		gatheringUsecase := gatheringUsecase{
-			gatheringRepo: mockGatheringRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := gatheringUsecase.UpdateGatheringByID(ctx, gathering)
@@ -174,7 +403,8 @@ func TestUpdateGatheringByIDUsecase(t *testing.T) {
 		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
 
 		gatheringUsecase := gatheringUsecase{
-			gatheringRepo: mockGatheringRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := gatheringUsecase.UpdateGatheringByID(ctx, gathering)
@@ -192,7 +422,8 @@ func TestUpdateGatheringByIDUsecase(t *testing.T) {
 		mockGatheringRepo.EXPECT().UpdateByID(ctx, gathering).Times(1).Return(nil, errorUpdate)
 
 		gatheringUsecase := gatheringUsecase{
-			gatheringRepo: mockGatheringRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := gatheringUsecase.UpdateGatheringByID(ctx, gathering)
@@ -200,6 +431,23 @@ func TestUpdateGatheringByIDUsecase(t *testing.T) {
 		assert.Error(t, err)
 		assert.EqualError(t, err, errorUpdate.Error())
 	})
+
+	t.Run("failed, ends before it starts", func(t *testing.T) {
+		scheduledAt := date.Add(24 * time.Hour)
+		endsAt := date
+		invalidGathering := &model.Gathering{ID: gathering.ID, ScheduledAt: &scheduledAt, EndsAt: &endsAt}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Times(0)
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		res, err := gatheringUsecase.UpdateGatheringByID(ctx, invalidGathering)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvalidGatheringSchedule)
+	})
 }
 
 func TestDeleteGatheringByIDUsecase(t *testing.T) {
@@ -220,14 +468,46 @@ func TestDeleteGatheringByIDUsecase(t *testing.T) {
 		Name:      "aaa",
 	}
 
-	t.Run("success", func(t *testing.T) {
+	invitations := []*model.Invitation{
+		{ID: 1, MemberID: 11, GatheringID: gathering.ID, Status: model.Active},
+		{ID: 2, MemberID: 12, GatheringID: gathering.ID, Status: model.Pending},
+		{ID: 3, MemberID: 13, GatheringID: gathering.ID, Status: model.Declined},
+		{ID: 4, MemberID: 14, GatheringID: gathering.ID, Status: model.Expired},
+	}
+
+	t.Run("success, cascade to invitations, attendees and the waitlist", func(t *testing.T) {
 		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
-		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+		mockEventPublisher := mock.NewMockEventPublisher(ctrl)
 
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByGatheringID(ctx, gathering.ID).Times(1).Return(invitations, nil)
 		mockGatheringRepo.EXPECT().DeleteByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockEventPublisher.EXPECT().Publish(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, events ...*model.Event) error {
+				assert.Len(t, events, 1)
+				assert.Equal(t, model.GatheringCancelled, events[0].Type)
+
+				var payload model.GatheringCancelledPayload
+				assert.NoError(t, events[0].DecodePayload(&payload))
+				assert.Equal(t, gathering.ID, payload.Gathering.ID)
+				assert.Equal(t, []int64{11, 12}, payload.MemberIDs)
+				return nil
+			})
 
 		gatheringUsecase := gatheringUsecase{
-			gatheringRepo: mockGatheringRepo,
+			gatheringRepo:      mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     mockEventPublisher,
+			deletionPolicy:     model.CascadeDeletion,
 		}
 
 		res, err := gatheringUsecase.DeleteGatheringByID(ctx, gathering.ID)
@@ -235,6 +515,36 @@ func TestDeleteGatheringByIDUsecase(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("failed, publish error rolls the deletion back", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+		mockEventPublisher := mock.NewMockEventPublisher(ctrl)
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByGatheringID(ctx, gathering.ID).Times(1).Return(nil, nil)
+		mockGatheringRepo.EXPECT().DeleteByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockEventPublisher.EXPECT().Publish(ctx, gomock.Any()).Times(1).Return(errors.New("error"))
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     mockEventPublisher,
+			deletionPolicy:     model.CascadeDeletion,
+		}
+
+		res, err := gatheringUsecase.DeleteGatheringByID(ctx, gathering.ID)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+
 	t.Run("failed, error find by ID", func(t *testing.T) {
 		mockGatherignRepo := mock.NewMockGatheringRepository(ctrl)
 		mockGatherignRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, errors.New("error"))
@@ -261,16 +571,76 @@ func TestDeleteGatheringByIDUsecase(t *testing.T) {
 		assert.EqualError(t, err, ErrRecordNotFound.Error())
 	})
 
+	t.Run("failed, restricted by live invitations", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByGatheringID(ctx, gathering.ID).Times(1).Return(invitations, nil)
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			deletionPolicy:     model.RestrictDeletion,
+		}
+
+		res, err := gatheringUsecase.DeleteGatheringByID(ctx, gathering.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrDeletionRestricted)
+	})
+
+	t.Run("success, restrict ignores expired invitations", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByGatheringID(ctx, gathering.ID).Times(1).
+			Return([]*model.Invitation{{ID: 3, MemberID: 13, GatheringID: gathering.ID, Status: model.Expired}}, nil)
+		mockGatheringRepo.EXPECT().DeleteByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo:      mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     event.NewRecorder(),
+			deletionPolicy:     model.RestrictDeletion,
+		}
+
+		res, err := gatheringUsecase.DeleteGatheringByID(ctx, gathering.ID)
+		assert.NotNil(t, res)
+		assert.NoError(t, err)
+	})
+
 	t.Run("failed, error delete", func(t *testing.T) {
 		errorDelete := errors.New("error delete")
 
 		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
-		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
 
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByGatheringID(ctx, gathering.ID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringID(ctx, gathering.ID).Times(1).Return(nil)
 		mockGatheringRepo.EXPECT().DeleteByID(ctx, gathering.ID).Times(1).Return(nil, errorDelete)
 
 		gatheringUsecase := gatheringUsecase{
-			gatheringRepo: mockGatheringRepo,
+			gatheringRepo:      mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			deletionPolicy:     model.CascadeDeletion,
 		}
 
 		res, err := gatheringUsecase.DeleteGatheringByID(ctx, gathering.ID)
@@ -279,3 +649,83 @@ func TestDeleteGatheringByIDUsecase(t *testing.T) {
 		assert.EqualError(t, err, errorDelete.Error())
 	})
 }
+
+func TestRestoreGatheringByIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+		mockGatheringRepo.EXPECT().RestoreByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		res, err := gatheringUsecase.RestoreGatheringByID(ctx, gathering.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, gathering, res)
+	})
+
+	t.Run("failed, gathering is not deleted", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		res, err := gatheringUsecase.RestoreGatheringByID(ctx, gathering.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotDeleted)
+	})
+
+	t.Run("failed, gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+		mockGatheringRepo.EXPECT().RestoreByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		res, err := gatheringUsecase.RestoreGatheringByID(ctx, gathering.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, error restore", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+		mockGatheringRepo.EXPECT().RestoreByID(ctx, gathering.ID).Times(1).Return(nil, errors.New("error"))
+
+		gatheringUsecase := gatheringUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		res, err := gatheringUsecase.RestoreGatheringByID(ctx, gathering.ID)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindDeletedGatheringsUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+	mockGatheringRepo.EXPECT().FindAllDeleted(ctx).Times(1).Return([]*model.Gathering{{ID: 123}}, nil)
+
+	gatheringUsecase := gatheringUsecase{
+		gatheringRepo: mockGatheringRepo,
+	}
+
+	res, err := gatheringUsecase.FindDeletedGatherings(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
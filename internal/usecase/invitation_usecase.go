@@ -2,27 +2,45 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/sirupsen/logrus"
 )
 
 type invitationUsecase struct {
-	invitationRepo model.InvitationRepository
-	memberRepo     model.MemberRepository
-	gatheringRepo  model.GatheringRepository
-	attendeeRepo   model.AttendeeRepository
+	invitationRepo      model.InvitationRepository
+	memberRepo          model.MemberRepository
+	gatheringRepo       model.GatheringRepository
+	attendeeRepo        model.AttendeeRepository
+	waitlistRepo        model.WaitlistRepository
+	emailInvitationRepo model.EmailInvitationRepository
+	occurrenceRepo      model.GatheringOccurrenceRepository
+	transactionManager  model.TransactionManager
+	eventPublisher      model.EventPublisher
 }
 
 func NewInvitationUsecase(invitationRepo model.InvitationRepository,
 	memberRepo model.MemberRepository,
 	gatheringRepo model.GatheringRepository,
-	attendeeRepo model.AttendeeRepository) model.InvitationUsecase {
+	attendeeRepo model.AttendeeRepository,
+	waitlistRepo model.WaitlistRepository,
+	emailInvitationRepo model.EmailInvitationRepository,
+	occurrenceRepo model.GatheringOccurrenceRepository,
+	transactionManager model.TransactionManager,
+	eventPublisher model.EventPublisher) model.InvitationUsecase {
 	return &invitationUsecase{
-		invitationRepo: invitationRepo,
-		memberRepo:     memberRepo,
-		gatheringRepo:  gatheringRepo,
-		attendeeRepo:   attendeeRepo,
+		invitationRepo:      invitationRepo,
+		memberRepo:          memberRepo,
+		gatheringRepo:       gatheringRepo,
+		attendeeRepo:        attendeeRepo,
+		waitlistRepo:        waitlistRepo,
+		emailInvitationRepo: emailInvitationRepo,
+		occurrenceRepo:      occurrenceRepo,
+		transactionManager:  transactionManager,
+		eventPublisher:      eventPublisher,
 	}
 }
 
@@ -32,6 +50,12 @@ func (iu *invitationUsecase) InviteMemberToGathering(ctx context.Context, invita
 		"invitation": invitation,
 	})
 
+	// the member brings guests when accepting the invitation
+	if invitation.AllowedGuests < 0 {
+		return nil, ErrInvalidAllowedGuests
+	}
+	invitation.Guests, invitation.GuestNames = 0, nil
+
 	memberRes, err := iu.memberRepo.FindByID(ctx, invitation.MemberID)
 	switch {
 	case err != nil:
@@ -50,24 +74,230 @@ func (iu *invitationUsecase) InviteMemberToGathering(ctx context.Context, invita
 		return nil, ErrRecordNotFound
 	}
 
-	err = iu.invitationRepo.Create(ctx, invitation)
+	occurrenceAt, err := invitedOccurrence(gatheringRes, invitation.OccurrenceAt)
 	if err != nil {
+		return nil, err
+	}
+	invitation.OccurrenceAt = occurrenceAt
+
+	oldInvitation, err := iu.invitationRepo.FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID)
+	switch {
+	case err != nil:
 		logger.Error(err)
 		return nil, err
+	case oldInvitation != nil && oldInvitation.IsLive():
+		return nil, liveInvitationError(oldInvitation, invitation.OccurrenceAt)
+	case oldInvitation != nil:
+		// re-inviting revives the deleted or expired invitation instead of creating a second one
+		invitation.ID = oldInvitation.ID
 	}
 
-	attendee := &model.Attendee{
-		MemberID:    invitation.MemberID,
-		GatheringID: invitation.GatheringID,
+	var res *model.Invitation
+	err = iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := iu.createOrReviveInvitation(ctx, gatheringRes, invitation, oldInvitation != nil); err != nil {
+			return err
+		}
+
+		res, err = iu.invitationRepo.FindByID(ctx, invitation.ID)
+		if err != nil {
+			return err
+		}
+		res.WaitlistPosition = invitation.WaitlistPosition
+
+		return publishEvent(ctx, iu.eventPublisher, model.InvitationSent, res)
+	})
+	switch {
+	case errors.Is(err, model.ErrDuplicateEntry):
+		return nil, ErrInvitationAlreadyExists
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (iu *invitationUsecase) BulkInviteMembersToGathering(ctx context.Context, bulkInvitation *model.BulkInvitation) (*model.BulkInvitationReport, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"bulkInvitation": bulkInvitation,
+	})
+
+	memberIDs := uniqueIDs(bulkInvitation.MemberIDs)
+	switch {
+	case len(memberIDs) == 0:
+		return nil, ErrBulkInvitationEmpty
+	case len(memberIDs) > model.MaxBulkInvitationMembers:
+		return nil, ErrBulkInvitationTooLarge
+	case bulkInvitation.AllowedGuests < 0:
+		return nil, ErrInvalidAllowedGuests
 	}
 
-	err = iu.attendeeRepo.Create(ctx, attendee)
+	mode := bulkInvitation.Mode
+	switch mode {
+	case "":
+		mode = model.BulkInvitationTransactional
+	case model.BulkInvitationTransactional, model.BulkInvitationBestEffort:
+	default:
+		return nil, ErrInvalidBulkInvitationMode
+	}
+
+	gatheringRes, err := iu.gatheringRepo.FindByID(ctx, bulkInvitation.GatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gatheringRes == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	occurrenceAt, err := invitedOccurrence(gatheringRes, bulkInvitation.OccurrenceAt)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := iu.memberRepo.FindByIDs(ctx, memberIDs)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	foundMembers := make(map[int64]bool, len(members))
+	for _, member := range members {
+		foundMembers[member.ID] = true
+	}
+
+	oldInvitations, err := iu.invitationRepo.FindByGatheringIDAndMemberIDs(ctx, bulkInvitation.GatheringID, memberIDs)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
+	invitationsByMember := make(map[int64]*model.Invitation, len(oldInvitations))
+	for _, oldInvitation := range oldInvitations {
+		invitationsByMember[oldInvitation.MemberID] = oldInvitation
+	}
+
+	report := &model.BulkInvitationReport{
+		GatheringID: bulkInvitation.GatheringID,
+		Mode:        mode,
+	}
+
+	type pendingInvitation struct {
+		invitation *model.Invitation
+		revive     bool
+		result     *model.BulkInvitationResult
+	}
+	var pendings []*pendingInvitation
+	hasMissingMember := false
+
+	for _, memberID := range memberIDs {
+		result := &model.BulkInvitationResult{MemberID: memberID}
+		report.Results = append(report.Results, result)
+
+		oldInvitation := invitationsByMember[memberID]
+		switch {
+		case !foundMembers[memberID]:
+			result.Status = model.BulkInvitationMemberNotFound
+			result.Error = ErrRecordNotFound.Error()
+			hasMissingMember = true
+			continue
+		case oldInvitation != nil && oldInvitation.IsLive():
+			result.Status = model.BulkInvitationAlreadyInvited
+			result.InvitationID = oldInvitation.ID
+			if err := liveInvitationError(oldInvitation, occurrenceAt); errors.Is(err, ErrInvitedToOtherOccurrence) {
+				result.Error = err.Error()
+			}
+			continue
+		}
+
+		invitation := &model.Invitation{
+			ID:            model.GenerateID(),
+			MemberID:      memberID,
+			GatheringID:   bulkInvitation.GatheringID,
+			OccurrenceAt:  occurrenceAt,
+			Status:        bulkInvitation.Status,
+			AllowedGuests: bulkInvitation.AllowedGuests,
+		}
+		if oldInvitation != nil {
+			invitation.ID = oldInvitation.ID
+		}
+
+		pendings = append(pendings, &pendingInvitation{
+			invitation: invitation,
+			revive:     oldInvitation != nil,
+			result:     result,
+		})
+	}
+
+	if mode == model.BulkInvitationBestEffort {
+		for _, pending := range pendings {
+			err = iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+				if err := iu.createOrReviveInvitation(ctx, gatheringRes, pending.invitation, pending.revive); err != nil {
+					return err
+				}
+				return publishEvent(ctx, iu.eventPublisher, model.InvitationSent, pending.invitation)
+			})
+			switch {
+			case errors.Is(err, model.ErrDuplicateEntry):
+				pending.result.Status = model.BulkInvitationAlreadyInvited
+			case err != nil:
+				logger.WithField("memberID", pending.invitation.MemberID).Error(err)
+				pending.result.Status = model.BulkInvitationFailed
+				pending.result.Error = err.Error()
+			default:
+				pending.result.Status = invitedResultStatus(pending.invitation)
+				pending.result.InvitationID = pending.invitation.ID
+			}
+		}
+
+		report.Tally()
+		return report, nil
+	}
+
+	// transactional mode invites nobody when a member is missing or an insert fails
+	if hasMissingMember {
+		for _, pending := range pendings {
+			pending.result.Status = model.BulkInvitationRolledBack
+		}
+		report.Tally()
+		return report, ErrBulkInvitationRejected
+	}
 
-	return iu.invitationRepo.FindByID(ctx, invitation.ID)
+	var failed *pendingInvitation
+	err = iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, pending := range pendings {
+			if err := iu.createOrReviveInvitation(ctx, gatheringRes, pending.invitation, pending.revive); err != nil {
+				failed = pending
+				return err
+			}
+			if err := publishEvent(ctx, iu.eventPublisher, model.InvitationSent, pending.invitation); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		for _, pending := range pendings {
+			pending.result.Status = model.BulkInvitationRolledBack
+		}
+		if failed != nil {
+			failed.result.Status = model.BulkInvitationFailed
+			failed.result.Error = err.Error()
+			if errors.Is(err, model.ErrDuplicateEntry) {
+				failed.result.Error = ErrInvitationAlreadyExists.Error()
+			}
+		}
+		report.Tally()
+		return report, ErrBulkInvitationRejected
+	}
+
+	for _, pending := range pendings {
+		pending.result.Status = invitedResultStatus(pending.invitation)
+		pending.result.InvitationID = pending.invitation.ID
+	}
+
+	report.Tally()
+	return report, nil
 }
 
 func (iu *invitationUsecase) FindInvitationByID(ctx context.Context, invitationID int64) (*model.Invitation, error) {
@@ -89,6 +319,8 @@ func (iu *invitationUsecase) FindInvitationByID(ctx context.Context, invitationI
 	return res, nil
 }
 
+// UpdateInvitationByID updates the status and the guests of the invitation, the guests allowed to the member only
+// change with UpdateAllowedGuestsByID
 func (iu *invitationUsecase) UpdateInvitationByID(ctx context.Context, invitation *model.Invitation) (*model.Invitation, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":        ctx,
@@ -104,6 +336,14 @@ func (iu *invitationUsecase) UpdateInvitationByID(ctx context.Context, invitatio
 		return nil, ErrRecordNotFound
 	}
 
+	// the invitation stays with its member and gathering, the seats are counted on them
+	invitation.MemberID = oldInvitation.MemberID
+	invitation.GatheringID = oldInvitation.GatheringID
+	invitation.AllowedGuests = oldInvitation.AllowedGuests
+	if err := normalizeGuests(invitation); err != nil {
+		return nil, err
+	}
+
 	memberRes, err := iu.memberRepo.FindByID(ctx, invitation.MemberID)
 	switch {
 	case err != nil:
@@ -122,7 +362,44 @@ func (iu *invitationUsecase) UpdateInvitationByID(ctx context.Context, invitatio
 		return nil, ErrRecordNotFound
 	}
 
-	res, err := iu.invitationRepo.UpdateByID(ctx, invitation)
+	// a declined or expired invitation gave its seat away, the member has to be invited again
+	switch {
+	case oldInvitation.Status == model.Declined && invitation.Status != model.Declined:
+		return nil, ErrInvitationDeclined
+	case oldInvitation.Status == model.Expired && invitation.Status != model.Expired:
+		return nil, ErrInvitationExpired
+	}
+
+	invitation.AcceptedAt = oldInvitation.AcceptedAt
+	if oldInvitation.Status == model.Pending && invitation.Status == model.Active {
+		now := time.Now()
+		invitation.AcceptedAt = &now
+	}
+
+	var res *model.Invitation
+	err = iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err = iu.invitationRepo.UpdateByID(ctx, invitation)
+		switch {
+		case err != nil:
+			return err
+		case oldInvitation.Status != model.Declined && res.Status == model.Declined:
+			if err := iu.releaseSeat(ctx, res); err != nil {
+				return err
+			}
+			return publishEvent(ctx, iu.eventPublisher, model.InvitationDeclined, res)
+		}
+
+		if res.Guests != oldInvitation.Guests {
+			if err := iu.seatGuests(ctx, res, oldInvitation.Guests); err != nil {
+				return err
+			}
+		}
+
+		if oldInvitation.Status != model.Active && res.Status == model.Active {
+			return publishEvent(ctx, iu.eventPublisher, model.InvitationAccepted, res)
+		}
+		return nil
+	})
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -131,6 +408,39 @@ func (iu *invitationUsecase) UpdateInvitationByID(ctx context.Context, invitatio
 	return res, nil
 }
 
+// UpdateAllowedGuestsByID changes how many guests the member of the invitation may bring, it can't go below the
+// guests the member already brings
+func (iu *invitationUsecase) UpdateAllowedGuestsByID(ctx context.Context, invitationID int64, allowedGuests int) (*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":           ctx,
+		"invitationID":  invitationID,
+		"allowedGuests": allowedGuests,
+	})
+
+	if allowedGuests < 0 {
+		return nil, ErrInvalidAllowedGuests
+	}
+
+	invitation, err := iu.invitationRepo.FindByID(ctx, invitationID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case invitation == nil:
+		return nil, ErrRecordNotFound
+	case invitation.Guests > allowedGuests:
+		return nil, ErrAllowedGuestsBelowGuests
+	}
+
+	if err = iu.invitationRepo.UpdateAllowedGuestsByID(ctx, invitationID, allowedGuests); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	invitation.AllowedGuests = allowedGuests
+	return invitation, nil
+}
+
 func (iu *invitationUsecase) DeleteInvitationByID(ctx context.Context, invitationID int64) (*model.Invitation, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":          ctx,
@@ -146,13 +456,33 @@ func (iu *invitationUsecase) DeleteInvitationByID(ctx context.Context, invitatio
 		return nil, ErrRecordNotFound
 	}
 
-	res, err := iu.invitationRepo.DeleteByID(ctx, invitationID)
+	var res *model.Invitation
+	err = iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err = iu.invitationRepo.DeleteByID(ctx, invitationID)
+		if err != nil {
+			return err
+		}
+
+		if err := iu.releaseSeat(ctx, invitation); err != nil {
+			return err
+		}
+
+		return publishEvent(ctx, iu.eventPublisher, model.InvitationDeleted, res)
+	})
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	_, err = iu.attendeeRepo.DeleteByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID)
+	return res, nil
+}
+
+func (iu *invitationUsecase) FindDeletedInvitations(ctx context.Context) ([]*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	res, err := iu.invitationRepo.FindAllDeleted(ctx)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -160,3 +490,377 @@ func (iu *invitationUsecase) DeleteInvitationByID(ctx context.Context, invitatio
 
 	return res, nil
 }
+
+// RestoreInvitationByID restores the invitation together with its attendee
+func (iu *invitationUsecase) RestoreInvitationByID(ctx context.Context, invitationID int64) (*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"invitationID": invitationID,
+	})
+
+	invitation, err := iu.invitationRepo.FindByID(ctx, invitationID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case invitation != nil:
+		return nil, ErrRecordNotDeleted
+	}
+
+	var res *model.Invitation
+	err = iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err = iu.invitationRepo.RestoreByID(ctx, invitationID)
+		if err != nil || res == nil {
+			return err
+		}
+
+		memberRes, err := iu.memberRepo.FindByID(ctx, res.MemberID)
+		if err != nil {
+			return err
+		}
+		gatheringRes, err := iu.gatheringRepo.FindByID(ctx, res.GatheringID)
+		if err != nil {
+			return err
+		}
+		if memberRes == nil || gatheringRes == nil {
+			return ErrRestoreParentDeleted
+		}
+
+		return iu.restoreSeat(ctx, gatheringRes, res)
+	})
+	switch {
+	case errors.Is(err, ErrRestoreParentDeleted):
+		return nil, err
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case res == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	return res, nil
+}
+
+// ExpirePendingInvitations expires the pending invitations whose occurrence of a gathering with expiring invitations
+// already started, the seats they held are released in the same transaction
+func (iu *invitationUsecase) ExpirePendingInvitations(ctx context.Context) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	var expired []*model.Invitation
+	err := iu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		pending, err := iu.invitationRepo.LockPendingOfStartedGatherings(ctx, now)
+		if err != nil || len(pending) == 0 {
+			return err
+		}
+
+		gatherings := make(map[int64]*model.Gathering)
+		gatheringIDs := make([]int64, 0)
+		for _, invitation := range pending {
+			if _, ok := gatherings[invitation.GatheringID]; ok {
+				continue
+			}
+			gathering, err := iu.gatheringRepo.FindByID(ctx, invitation.GatheringID)
+			if err != nil {
+				return err
+			}
+			gatherings[invitation.GatheringID] = gathering
+			gatheringIDs = append(gatheringIDs, invitation.GatheringID)
+		}
+
+		overrides, err := iu.occurrenceRepo.FindByGatheringIDs(ctx, gatheringIDs)
+		if err != nil {
+			return err
+		}
+
+		// the invitations of a recurring gathering expire with their own occurrence
+		expiredIDs := make([]int64, 0, len(pending))
+		for _, invitation := range pending {
+			gathering := gatherings[invitation.GatheringID]
+			if gathering == nil {
+				continue
+			}
+			expiresAt, err := invitation.ExpiresAt(gathering, overrides)
+			if err != nil {
+				return err
+			}
+			if expiresAt == nil || expiresAt.After(now) {
+				continue
+			}
+			invitation.Status = model.Expired
+			expired = append(expired, invitation)
+			expiredIDs = append(expiredIDs, invitation.ID)
+		}
+
+		if err = iu.invitationRepo.ExpireByIDs(ctx, expiredIDs); err != nil {
+			return err
+		}
+
+		released := make(map[int64]bool)
+		for _, invitation := range expired {
+			if _, err = iu.attendeeRepo.DeleteByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID); err != nil {
+				return err
+			}
+			if err = iu.waitlistRepo.DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID); err != nil {
+				return err
+			}
+			released[invitation.GatheringID] = true
+		}
+
+		// the released seats go to the waitlists
+		promoter := iu.waitlistPromoter()
+		for _, gatheringID := range gatheringIDs {
+			if !released[gatheringID] {
+				continue
+			}
+			if _, err = promoter.promote(ctx, gatheringID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return int64(len(expired)), nil
+}
+
+// ExportRosterByGatheringID calls fn with the roster entry of every member invited to the gathering. A gathering
+// that is not found fails before fn is called
+func (iu *invitationUsecase) ExportRosterByGatheringID(ctx context.Context, gatheringID int64, fn func(entry *model.RosterEntry) error) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gathering, err := iu.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return err
+	case gathering == nil:
+		return ErrRecordNotFound
+	}
+
+	return iu.invitationRepo.StreamRosterByGatheringID(ctx, gatheringID, fn)
+}
+
+// createOrReviveInvitation writes the invitation and its attendee, the member is waitlisted instead of attending
+// when the gathering is full. It is expected to run inside a transaction
+func (iu *invitationUsecase) createOrReviveInvitation(ctx context.Context, gathering *model.Gathering, invitation *model.Invitation, revive bool) error {
+	attendee := &model.Attendee{
+		MemberID:     invitation.MemberID,
+		GatheringID:  invitation.GatheringID,
+		OccurrenceAt: invitation.OccurrenceAt,
+	}
+
+	if revive {
+		if _, err := iu.invitationRepo.Revive(ctx, invitation); err != nil {
+			return err
+		}
+	} else if err := iu.invitationRepo.Create(ctx, invitation); err != nil {
+		return err
+	}
+
+	if gathering.IsCapacityLimited() {
+		// the members already waiting get the free seats first
+		seatLeft, err := iu.waitlistPromoter().promote(ctx, gathering.ID)
+		if err != nil {
+			return err
+		}
+		if !seatLeft {
+			return iu.waitlistMember(ctx, invitation)
+		}
+	}
+
+	if revive {
+		return iu.attendeeRepo.Revive(ctx, attendee)
+	}
+	return iu.attendeeRepo.Create(ctx, attendee)
+}
+
+// restoreSeat gives the restored invitation its seat back like createOrReviveInvitation, the member is waitlisted
+// instead when the gathering has no seat left for them and their guests. An invitation that is no longer live holds
+// no seat. It is expected to run inside a transaction
+func (iu *invitationUsecase) restoreSeat(ctx context.Context, gathering *model.Gathering, invitation *model.Invitation) error {
+	if !invitation.IsLive() {
+		return nil
+	}
+
+	if gathering.IsCapacityLimited() {
+		// the members already waiting get the free seats first
+		seatLeft, err := iu.waitlistPromoter().promote(ctx, gathering.ID)
+		if err != nil {
+			return err
+		}
+		if seatLeft {
+			taken, err := iu.attendeeRepo.CountSeatsByGatheringID(ctx, gathering.ID)
+			if err != nil {
+				return err
+			}
+			seatLeft = taken+invitation.Seats() <= int64(gathering.Capacity)
+		}
+		if !seatLeft {
+			return iu.waitlistMember(ctx, invitation)
+		}
+	}
+
+	attendee, err := iu.attendeeRepo.RestoreByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID)
+	if err != nil || attendee != nil {
+		return err
+	}
+
+	// the member was waitlisted when the invitation was deleted, they get the seat now
+	return iu.attendeeRepo.Revive(ctx, &model.Attendee{
+		MemberID:     invitation.MemberID,
+		GatheringID:  invitation.GatheringID,
+		OccurrenceAt: invitation.OccurrenceAt,
+		Guests:       invitation.Guests,
+	})
+}
+
+// waitlistMember puts the invited member at the end of the gathering waitlist
+func (iu *invitationUsecase) waitlistMember(ctx context.Context, invitation *model.Invitation) error {
+	// a member invited again goes to the end of the waitlist
+	if err := iu.waitlistRepo.DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID); err != nil {
+		return err
+	}
+
+	err := iu.waitlistRepo.Create(ctx, &model.WaitlistEntry{
+		GatheringID: invitation.GatheringID,
+		MemberID:    invitation.MemberID,
+	})
+	if err != nil {
+		return err
+	}
+
+	entry, err := iu.waitlistRepo.FindByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID)
+	if err != nil || entry == nil {
+		return err
+	}
+	invitation.WaitlistPosition = entry.Position
+	return nil
+}
+
+// seatGuests gives the guests the member of the invitation brings their seats, or releases the seats of the guests
+// no longer coming. A member still on the waitlist is promoted only once there are seats for the guests too. It is
+// expected to run inside a transaction
+func (iu *invitationUsecase) seatGuests(ctx context.Context, invitation *model.Invitation, previousGuests int) error {
+	gathering, err := iu.gatheringRepo.LockByID(ctx, invitation.GatheringID)
+	if err != nil || gathering == nil {
+		return err
+	}
+
+	entry, err := iu.waitlistRepo.FindByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID)
+	if err != nil {
+		return err
+	}
+
+	addedGuests := invitation.Guests - previousGuests
+	if entry == nil {
+		if gathering.IsCapacityLimited() && addedGuests > 0 {
+			taken, err := iu.attendeeRepo.CountSeatsByGatheringID(ctx, gathering.ID)
+			if err != nil {
+				return err
+			}
+			if taken+int64(addedGuests) > int64(gathering.Capacity) {
+				return ErrNotEnoughSeats
+			}
+		}
+
+		err = iu.attendeeRepo.UpdateGuestsByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID, invitation.Guests)
+		if err != nil {
+			return err
+		}
+	}
+
+	if gathering.IsCapacityLimited() && addedGuests < 0 {
+		_, err = iu.waitlistPromoter().promote(ctx, gathering.ID)
+	}
+	return err
+}
+
+// releaseSeat removes the member of the invitation from the gathering, or from its waitlist, and gives the seat to
+// the next waitlisted member. It is expected to run inside a transaction
+func (iu *invitationUsecase) releaseSeat(ctx context.Context, invitation *model.Invitation) error {
+	if _, err := iu.attendeeRepo.DeleteByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID); err != nil {
+		return err
+	}
+
+	if err := iu.waitlistRepo.DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID); err != nil {
+		return err
+	}
+
+	_, err := iu.waitlistPromoter().promote(ctx, invitation.GatheringID)
+	return err
+}
+
+func (iu *invitationUsecase) waitlistPromoter() *waitlistPromoter {
+	return &waitlistPromoter{
+		gatheringRepo:  iu.gatheringRepo,
+		invitationRepo: iu.invitationRepo,
+		attendeeRepo:   iu.attendeeRepo,
+		waitlistRepo:   iu.waitlistRepo,
+		eventPublisher: iu.eventPublisher,
+	}
+}
+
+// invitedResultStatus tells whether the invited member got a seat or was waitlisted
+func invitedResultStatus(invitation *model.Invitation) model.BulkInvitationResultStatus {
+	if invitation.WaitlistPosition > 0 {
+		return model.BulkInvitationWaitlisted
+	}
+	return model.BulkInvitationInvited
+}
+
+// normalizeGuests checks the guests the member brings fit the invitation, blank guest names are dropped
+func normalizeGuests(invitation *model.Invitation) error {
+	if invitation.AllowedGuests < 0 {
+		return ErrInvalidAllowedGuests
+	}
+
+	var guestNames []string
+	for _, name := range invitation.GuestNames {
+		if name = strings.TrimSpace(name); name != "" {
+			guestNames = append(guestNames, name)
+		}
+	}
+	invitation.GuestNames = guestNames
+
+	switch {
+	case invitation.Guests < 0, invitation.Guests > invitation.AllowedGuests, len(guestNames) > invitation.Guests:
+		return ErrInvalidGuests
+	case invitation.Guests > 0 && invitation.Status != model.Active:
+		return ErrGuestsNotAccepted
+	}
+	return nil
+}
+
+// liveInvitationError tells why the live invitation of the member prevents inviting them to the occurrence again.
+// The member holds one invitation per gathering, so an invitation to another occurrence, or to a single occurrence
+// when the series is asked for, can't be added next to it
+func liveInvitationError(invitation *model.Invitation, occurrenceAt *time.Time) error {
+	sameOccurrence := invitation.OccurrenceAt == nil && occurrenceAt == nil ||
+		invitation.OccurrenceAt != nil && occurrenceAt != nil && invitation.OccurrenceAt.Equal(*occurrenceAt)
+	if sameOccurrence {
+		return ErrInvitationAlreadyExists
+	}
+	return ErrInvitedToOtherOccurrence
+}
+
+// invitedOccurrence checks the occurrence an invitation is scoped to belongs to the gathering, no occurrence invites
+// the member to every occurrence
+func invitedOccurrence(gathering *model.Gathering, occurrenceAt *time.Time) (*time.Time, error) {
+	if occurrenceAt == nil {
+		return nil, nil
+	}
+	if !gathering.HasOccurrence(*occurrenceAt) {
+		return nil, ErrInvalidOccurrence
+	}
+	at := occurrenceAt.UTC()
+	return &at, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
 	"github.com/golang/mock/gomock"
@@ -60,21 +61,223 @@ func TestInviteMemberToGatheringUsecase(t *testing.T) {
 
 		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
 		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
 		mockInvitationRepo.EXPECT().Create(ctx, invitation).Times(1).Return(nil)
 		mockAttendeeRepo.EXPECT().Create(ctx, attendee).Times(1).Return(nil)
 		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+		eventRecorder := event.NewRecorder()
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
-			gatheringRepo:  mockGatheringRepo,
-			attendeeRepo:   mockAttendeeRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     eventRecorder,
 		}
 
 		res, err := invitationUsecase.InviteMemberToGathering(ctx, invitation)
 		assert.NotNil(t, res)
 		assert.NoError(t, err)
+		assert.Equal(t, []model.EventType{model.InvitationSent}, eventRecorder.Types())
+	})
+
+	t.Run("success, waitlisted on a full gathering", func(t *testing.T) {
+		fullGathering := &model.Gathering{
+			ID:       invitation.GatheringID,
+			Creator:  111,
+			Type:     model.WithFixedNumberOfAttendees,
+			Name:     "gathering",
+			Capacity: 2,
+		}
+		waitlistedInvitation := &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Pending,
+		}
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(fullGathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().Create(ctx, waitlistedInvitation).Times(1).Return(nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, invitation.GatheringID).Times(1).Return(fullGathering, nil)
+		mockAttendeeRepo.EXPECT().CountSeatsByGatheringID(ctx, invitation.GatheringID).Times(1).Return(int64(2), nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().Create(ctx, &model.WaitlistEntry{GatheringID: invitation.GatheringID, MemberID: invitation.MemberID}).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID).Times(1).
+			Return(&model.WaitlistEntry{GatheringID: invitation.GatheringID, MemberID: invitation.MemberID, Position: 3}, nil)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(waitlistedInvitation, nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     eventRecorder,
+		}
+
+		res, err := invitationUsecase.InviteMemberToGathering(ctx, waitlistedInvitation)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res.WaitlistPosition)
+		assert.Equal(t, []model.EventType{model.InvitationSent}, eventRecorder.Types())
+	})
+
+	t.Run("success, revive deleted invitation", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		newInvitation := &model.Invitation{
+			ID:          999,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Pending,
+		}
+		deletedInvitation := &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Active,
+			DeletedAt:   gorm.DeletedAt{Time: date, Valid: true},
+		}
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(deletedInvitation, nil)
+		mockInvitationRepo.EXPECT().Revive(ctx, newInvitation).Times(1).Return(invitation, nil)
+		mockAttendeeRepo.EXPECT().Revive(ctx, attendee).Times(1).Return(nil)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.InviteMemberToGathering(ctx, newInvitation)
+		assert.NoError(t, err)
+		assert.Equal(t, invitation.ID, res.ID)
+	})
+
+	t.Run("success, revive expired invitation", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		newInvitation := &model.Invitation{
+			ID:          999,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Pending,
+		}
+		expiredInvitation := &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Expired,
+		}
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(expiredInvitation, nil)
+		mockInvitationRepo.EXPECT().Revive(ctx, newInvitation).Times(1).Return(invitation, nil)
+		mockAttendeeRepo.EXPECT().Revive(ctx, attendee).Times(1).Return(nil)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.InviteMemberToGathering(ctx, newInvitation)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("success, invited to one occurrence", func(t *testing.T) {
+		scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		occurrenceAt := scheduledAt.AddDate(0, 0, 7).In(time.FixedZone("WIB", 7*60*60))
+		recurringGathering := &model.Gathering{ID: invitation.GatheringID, ScheduledAt: &scheduledAt, Recurrence: "FREQ=WEEKLY"}
+		occurrenceInvitation := &model.Invitation{
+			ID:           invitation.ID,
+			MemberID:     invitation.MemberID,
+			GatheringID:  invitation.GatheringID,
+			OccurrenceAt: &occurrenceAt,
+			Status:       model.Pending,
+		}
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(recurringGathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().Create(ctx, occurrenceInvitation).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, attendee *model.Attendee) error {
+				assert.True(t, attendee.OccurrenceAt.Equal(occurrenceAt))
+				assert.Equal(t, time.UTC, attendee.OccurrenceAt.Location())
+				return nil
+			})
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(occurrenceInvitation, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     event.NewRecorder(),
+		}
+
+		_, err := invitationUsecase.InviteMemberToGathering(ctx, occurrenceInvitation)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed, not an occurrence of the gathering", func(t *testing.T) {
+		occurrenceAt := time.Now()
+		occurrenceInvitation := &model.Invitation{
+			ID:           invitation.ID,
+			MemberID:     invitation.MemberID,
+			GatheringID:  invitation.GatheringID,
+			OccurrenceAt: &occurrenceAt,
+		}
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo: mockInvitationRepo,
+			memberRepo:     mockMemberRepo,
+			gatheringRepo:  mockGatheringRepo,
+		}
 
+		_, err := invitationUsecase.InviteMemberToGathering(ctx, occurrenceInvitation)
+		assert.ErrorIs(t, err, ErrInvalidOccurrence)
 	})
 
 	t.Run("failed, error find member by id", func(t *testing.T) {
@@ -144,14 +347,66 @@ func TestInviteMemberToGatheringUsecase(t *testing.T) {
 		assert.EqualError(t, err, ErrRecordNotFound.Error())
 	})
 
-	t.Run("failed, error create", func(t *testing.T) {
+	t.Run("failed, error find existing invitation", func(t *testing.T) {
 		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
 		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
 		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
 
 		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
 		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
-		mockInvitationRepo.EXPECT().Create(ctx, invitation).Times(1).Return(errors.New("error"))
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo: mockInvitationRepo,
+			memberRepo:     mockMemberRepo,
+			gatheringRepo:  mockGatheringRepo,
+		}
+
+		res, err := invitationUsecase.InviteMemberToGathering(ctx, invitation)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed, member already invited", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(invitation, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo: mockInvitationRepo,
+			memberRepo:     mockMemberRepo,
+			gatheringRepo:  mockGatheringRepo,
+		}
+
+		res, err := invitationUsecase.InviteMemberToGathering(ctx, invitation)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvitationAlreadyExists)
+	})
+
+	t.Run("failed, member already invited to another occurrence", func(t *testing.T) {
+		scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		firstAt := scheduledAt.AddDate(0, 0, 7)
+		secondAt := scheduledAt.AddDate(0, 0, 14)
+		recurringGathering := &model.Gathering{ID: invitation.GatheringID, ScheduledAt: &scheduledAt, Recurrence: "FREQ=WEEKLY"}
+		oldInvitation := &model.Invitation{
+			ID:           invitation.ID,
+			MemberID:     invitation.MemberID,
+			GatheringID:  invitation.GatheringID,
+			OccurrenceAt: &firstAt,
+			Status:       model.Pending,
+		}
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(recurringGathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(oldInvitation, nil)
 
 		invitationUsecase := invitationUsecase{
 			invitationRepo: mockInvitationRepo,
@@ -159,6 +414,55 @@ func TestInviteMemberToGatheringUsecase(t *testing.T) {
 			gatheringRepo:  mockGatheringRepo,
 		}
 
+		res, err := invitationUsecase.InviteMemberToGathering(ctx, &model.Invitation{
+			MemberID:     invitation.MemberID,
+			GatheringID:  invitation.GatheringID,
+			OccurrenceAt: &secondAt,
+			Status:       model.Pending,
+		})
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvitedToOtherOccurrence)
+	})
+
+	t.Run("failed, concurrent invite hits unique constraint", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().Create(ctx, invitation).Times(1).Return(model.ErrDuplicateEntry)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.InviteMemberToGathering(ctx, invitation)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvitationAlreadyExists)
+	})
+
+	t.Run("failed, error create", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().Create(ctx, invitation).Times(1).Return(errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
 		res, err := invitationUsecase.InviteMemberToGathering(ctx, invitation)
 		assert.Nil(t, res)
 		assert.Error(t, err)
@@ -172,14 +476,16 @@ func TestInviteMemberToGatheringUsecase(t *testing.T) {
 
 		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
 		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
 		mockInvitationRepo.EXPECT().Create(ctx, invitation).Times(1).Return(nil)
 		mockAttendeeRepo.EXPECT().Create(ctx, attendee).Times(1).Return(errors.New("error"))
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
-			gatheringRepo:  mockGatheringRepo,
-			attendeeRepo:   mockAttendeeRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.InviteMemberToGathering(ctx, invitation)
@@ -195,15 +501,17 @@ func TestInviteMemberToGatheringUsecase(t *testing.T) {
 
 		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
 		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
 		mockInvitationRepo.EXPECT().Create(ctx, invitation).Times(1).Return(nil)
 		mockAttendeeRepo.EXPECT().Create(ctx, attendee).Times(1).Return(nil)
 		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, errors.New("error"))
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
-			gatheringRepo:  mockGatheringRepo,
-			attendeeRepo:   mockAttendeeRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.InviteMemberToGathering(ctx, invitation)
@@ -318,16 +626,207 @@ func TestUpdateInvitationByID(t *testing.T) {
 		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
 		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
 		mockInvitationRepo.EXPECT().UpdateByID(ctx, invitation).Times(1).Return(invitation, nil)
+		eventRecorder := event.NewRecorder()
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
-			gatheringRepo:  mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
 		assert.NotNil(t, res)
 		assert.NoError(t, err)
+		assert.Nil(t, res.AcceptedAt)
+		assert.Empty(t, eventRecorder.Events())
+	})
+
+	t.Run("success, member and gathering stay those of the stored invitation", func(t *testing.T) {
+		movedInvitation := &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    999,
+			GatheringID: 888,
+			Status:      model.Active,
+		}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().UpdateByID(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, updated *model.Invitation) (*model.Invitation, error) {
+				assert.Equal(t, invitation.MemberID, updated.MemberID)
+				assert.Equal(t, invitation.GatheringID, updated.GatheringID)
+				return invitation, nil
+			})
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			eventPublisher:     event.NewRecorder(),
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		_, err := invitationUsecase.UpdateInvitationByID(ctx, movedInvitation)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success, accepted", func(t *testing.T) {
+		pendingInvitation := &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Pending,
+		}
+		acceptedInvitation := *invitation
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(pendingInvitation, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockInvitationRepo.EXPECT().UpdateByID(ctx, &acceptedInvitation).Times(1).Return(&acceptedInvitation, nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, &acceptedInvitation)
+		assert.NoError(t, err)
+		assert.NotNil(t, res.AcceptedAt)
+		assert.Equal(t, []model.EventType{model.InvitationAccepted}, eventRecorder.Types())
+	})
+
+	t.Run("success, declined seat goes to the waitlist", func(t *testing.T) {
+		fullGathering := &model.Gathering{
+			ID:       invitation.GatheringID,
+			Creator:  111,
+			Type:     model.WithFixedNumberOfAttendees,
+			Name:     "gathering",
+			Capacity: 2,
+		}
+		declinedInvitation := &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Declined,
+		}
+		waitlistedInvitation := &model.Invitation{
+			ID:          999,
+			MemberID:    555,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Pending,
+		}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(fullGathering, nil)
+		mockInvitationRepo.EXPECT().UpdateByID(ctx, declinedInvitation).Times(1).Return(declinedInvitation, nil)
+		mockAttendeeRepo.EXPECT().DeleteByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID).Times(1).Return(nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, invitation.GatheringID).Times(1).Return(fullGathering, nil)
+		mockAttendeeRepo.EXPECT().CountSeatsByGatheringID(ctx, invitation.GatheringID).Times(1).Return(int64(1), nil)
+		mockWaitlistRepo.EXPECT().FindFirstByGatheringID(ctx, invitation.GatheringID).Times(1).
+			Return(&model.WaitlistEntry{GatheringID: invitation.GatheringID, MemberID: waitlistedInvitation.MemberID, Position: 1}, nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, waitlistedInvitation.MemberID).Times(1).Return(nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, waitlistedInvitation.MemberID, invitation.GatheringID).Times(1).Return(waitlistedInvitation, nil)
+		mockAttendeeRepo.EXPECT().Revive(ctx, &model.Attendee{MemberID: waitlistedInvitation.MemberID, GatheringID: invitation.GatheringID}).Times(1).Return(nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, declinedInvitation)
+		assert.NotNil(t, res)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventType{model.InvitationPromoted, model.InvitationDeclined}, eventRecorder.Types())
+	})
+
+	t.Run("failed, invitation already declined", func(t *testing.T) {
+		declinedInvitation := &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Declined,
+		}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(declinedInvitation, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
+		assert.Nil(t, res)
+		assert.EqualError(t, err, ErrInvitationDeclined.Error())
+	})
+
+	t.Run("failed, expired invitation can't become active", func(t *testing.T) {
+		expiredInvitation := &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Expired,
+		}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(expiredInvitation, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, &model.Invitation{
+			ID:          invitation.ID,
+			MemberID:    invitation.MemberID,
+			GatheringID: invitation.GatheringID,
+			Status:      model.Active,
+		})
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvitationExpired)
 	})
 
 	t.Run("failed, error find invitation by id", func(t *testing.T) {
@@ -336,7 +835,8 @@ func TestUpdateInvitationByID(t *testing.T) {
 		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, errors.New("error"))
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
@@ -350,7 +850,8 @@ func TestUpdateInvitationByID(t *testing.T) {
 		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
@@ -367,8 +868,9 @@ func TestUpdateInvitationByID(t *testing.T) {
 		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(nil, errors.New("error"))
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
@@ -384,8 +886,9 @@ func TestUpdateInvitationByID(t *testing.T) {
 		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(nil, nil)
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
@@ -404,9 +907,10 @@ func TestUpdateInvitationByID(t *testing.T) {
 		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(nil, errors.New("error"))
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
-			gatheringRepo:  mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
@@ -424,9 +928,10 @@ func TestUpdateInvitationByID(t *testing.T) {
 		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(nil, nil)
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
-			gatheringRepo:  mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
@@ -446,9 +951,10 @@ func TestUpdateInvitationByID(t *testing.T) {
 		mockInvitationRepo.EXPECT().UpdateByID(ctx, invitation).Times(1).Return(nil, errors.New("error"))
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			memberRepo:     mockMemberRepo,
-			gatheringRepo:  mockGatheringRepo,
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
@@ -457,6 +963,261 @@ func TestUpdateInvitationByID(t *testing.T) {
 	})
 }
 
+func TestUpdateInvitationGuestsUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	member := &model.Member{ID: 321, FirstName: "first", Email: "first@last.com"}
+	gathering := &model.Gathering{ID: 444, Name: "gathering", Type: model.WithFixedNumberOfAttendees, Capacity: 5}
+	pendingInvitation := &model.Invitation{ID: 123, MemberID: member.ID, GatheringID: gathering.ID, Status: model.Pending, AllowedGuests: 2}
+
+	acceptedInvitation := func(guests int, guestNames ...string) *model.Invitation {
+		return &model.Invitation{
+			ID:            pendingInvitation.ID,
+			MemberID:      member.ID,
+			GatheringID:   gathering.ID,
+			Status:        model.Active,
+			AllowedGuests: 2,
+			Guests:        guests,
+			GuestNames:    guestNames,
+		}
+	}
+
+	t.Run("success, accepted with guests", func(t *testing.T) {
+		invitation := acceptedInvitation(2, " Jane ", "")
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(pendingInvitation, nil)
+		mockInvitationRepo.EXPECT().UpdateByID(ctx, invitation).Times(1).Return(invitation, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+		mockWaitlistRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, gathering.ID, member.ID).Times(1).Return(nil, nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().CountSeatsByGatheringID(ctx, gathering.ID).Times(1).Return(int64(3), nil)
+		mockAttendeeRepo.EXPECT().UpdateGuestsByMemberIDAndGatheringID(ctx, member.ID, gathering.ID, 2).Times(1).Return(nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Jane"}, res.GuestNames)
+		assert.Equal(t, []model.EventType{model.InvitationAccepted}, eventRecorder.Types())
+	})
+
+	t.Run("failed, not enough seats for the guests", func(t *testing.T) {
+		invitation := acceptedInvitation(2)
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(pendingInvitation, nil)
+		mockInvitationRepo.EXPECT().UpdateByID(ctx, invitation).Times(1).Return(invitation, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+		mockWaitlistRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, gathering.ID, member.ID).Times(1).Return(nil, nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().CountSeatsByGatheringID(ctx, gathering.ID).Times(1).Return(int64(4), nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrNotEnoughSeats)
+		assert.Empty(t, eventRecorder.Events())
+	})
+
+	t.Run("success, waitlisted member brings guests once promoted", func(t *testing.T) {
+		invitation := acceptedInvitation(1)
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(pendingInvitation, nil)
+		mockInvitationRepo.EXPECT().UpdateByID(ctx, invitation).Times(1).Return(invitation, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+		mockWaitlistRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, gathering.ID, member.ID).Times(1).
+			Return(&model.WaitlistEntry{GatheringID: gathering.ID, MemberID: member.ID, Position: 1}, nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res.Guests)
+		assert.Equal(t, []model.EventType{model.InvitationAccepted}, eventRecorder.Types())
+	})
+
+	t.Run("success, fewer guests leave the next waitlisted party waiting when it doesn't fit", func(t *testing.T) {
+		oldInvitation := acceptedInvitation(2)
+		invitation := acceptedInvitation(1)
+		waitingInvitation := &model.Invitation{ID: 124, MemberID: 322, GatheringID: gathering.ID, Status: model.Active, AllowedGuests: 2, Guests: 2}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(oldInvitation, nil)
+		mockInvitationRepo.EXPECT().UpdateByID(ctx, invitation).Times(1).Return(invitation, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, waitingInvitation.MemberID, gathering.ID).Times(1).Return(waitingInvitation, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, gathering.ID).Times(2).Return(gathering, nil)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+		mockWaitlistRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, gathering.ID, member.ID).Times(1).Return(nil, nil)
+		mockWaitlistRepo.EXPECT().FindFirstByGatheringID(ctx, gathering.ID).Times(1).
+			Return(&model.WaitlistEntry{GatheringID: gathering.ID, MemberID: waitingInvitation.MemberID, Position: 1}, nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().UpdateGuestsByMemberIDAndGatheringID(ctx, member.ID, gathering.ID, 1).Times(1).Return(nil)
+		// 1 seat is free, the waitlisted member needs 3
+		mockAttendeeRepo.EXPECT().CountSeatsByGatheringID(ctx, gathering.ID).Times(1).Return(int64(4), nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res.Guests)
+		assert.Empty(t, eventRecorder.Events())
+	})
+
+	t.Run("failed, invalid guests", func(t *testing.T) {
+		for _, invitation := range []*model.Invitation{
+			acceptedInvitation(3),
+			acceptedInvitation(-1),
+			acceptedInvitation(1, "Jane", "Joe"),
+		} {
+			mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+			mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(pendingInvitation, nil)
+
+			invitationUsecase := invitationUsecase{invitationRepo: mockInvitationRepo}
+
+			res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
+			assert.Nil(t, res)
+			assert.ErrorIs(t, err, ErrInvalidGuests)
+		}
+	})
+
+	t.Run("failed, guests above the allowed guests stored on the invitation", func(t *testing.T) {
+		invitation := acceptedInvitation(4)
+		invitation.AllowedGuests = 5
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(pendingInvitation, nil)
+
+		invitationUsecase := invitationUsecase{invitationRepo: mockInvitationRepo}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvalidGuests)
+	})
+
+	t.Run("failed, guests on an invitation not accepted", func(t *testing.T) {
+		invitation := acceptedInvitation(1)
+		invitation.Status = model.Pending
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(pendingInvitation, nil)
+
+		invitationUsecase := invitationUsecase{invitationRepo: mockInvitationRepo}
+
+		res, err := invitationUsecase.UpdateInvitationByID(ctx, invitation)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrGuestsNotAccepted)
+	})
+}
+
+func TestUpdateAllowedGuestsByIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	invitation := &model.Invitation{ID: 123, MemberID: 321, GatheringID: 444, Status: model.Active, AllowedGuests: 2, Guests: 2}
+
+	t.Run("success", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(&model.Invitation{ID: invitation.ID, Guests: 2, AllowedGuests: 2}, nil)
+		mockInvitationRepo.EXPECT().UpdateAllowedGuestsByID(ctx, invitation.ID, 3).Times(1).Return(nil)
+
+		invitationUsecase := invitationUsecase{invitationRepo: mockInvitationRepo}
+
+		res, err := invitationUsecase.UpdateAllowedGuestsByID(ctx, invitation.ID, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, res.AllowedGuests)
+	})
+
+	t.Run("failed, below the guests already brought", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+
+		invitationUsecase := invitationUsecase{invitationRepo: mockInvitationRepo}
+
+		res, err := invitationUsecase.UpdateAllowedGuestsByID(ctx, invitation.ID, 1)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrAllowedGuestsBelowGuests)
+	})
+
+	t.Run("failed, invitation not found", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{invitationRepo: mockInvitationRepo}
+
+		res, err := invitationUsecase.UpdateAllowedGuestsByID(ctx, invitation.ID, 3)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, negative allowed guests", func(t *testing.T) {
+		invitationUsecase := invitationUsecase{}
+
+		res, err := invitationUsecase.UpdateAllowedGuestsByID(ctx, invitation.ID, -1)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvalidAllowedGuests)
+	})
+}
+
 func TestDeleteInvitationByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -482,15 +1243,23 @@ func TestDeleteInvitationByID(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
 		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
 
 		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
 		mockInvitationRepo.EXPECT().DeleteByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
 		mockAttendeeRepo.EXPECT().DeleteByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(attendee, nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID).Times(1).Return(nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, invitation.GatheringID).Times(1).Return(&model.Gathering{ID: invitation.GatheringID}, nil)
+		mockWaitlistRepo.EXPECT().FindFirstByGatheringID(ctx, invitation.GatheringID).Times(1).Return(nil, nil)
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
-			attendeeRepo:   mockAttendeeRepo,
+			invitationRepo:     mockInvitationRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.DeleteInvitationByID(ctx, invitation.ID)
@@ -504,7 +1273,8 @@ func TestDeleteInvitationByID(t *testing.T) {
 		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, errors.New("error"))
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.DeleteInvitationByID(ctx, invitation.ID)
@@ -518,7 +1288,8 @@ func TestDeleteInvitationByID(t *testing.T) {
 		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.DeleteInvitationByID(ctx, invitation.ID)
@@ -534,7 +1305,8 @@ func TestDeleteInvitationByID(t *testing.T) {
 		mockInvitationRepo.EXPECT().DeleteByID(ctx, invitation.ID).Times(1).Return(nil, errors.New("error"))
 
 		invitationUsecase := invitationUsecase{
-			invitationRepo: mockInvitationRepo,
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		res, err := invitationUsecase.DeleteInvitationByID(ctx, invitation.ID)
@@ -543,3 +1315,604 @@ func TestDeleteInvitationByID(t *testing.T) {
 	})
 
 }
+
+func TestExpirePendingInvitationsUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("seats of the expired invitations are released", func(t *testing.T) {
+		startedAt := time.Now().Add(-time.Hour)
+		gathering := &model.Gathering{ID: 100, Type: model.WithExpirationForInvitations, ScheduledAt: &startedAt}
+		pending := []*model.Invitation{
+			{ID: 1, MemberID: 10, GatheringID: 100, Status: model.Pending},
+			{ID: 2, MemberID: 11, GatheringID: 100, Status: model.Pending},
+		}
+		waitlisted := &model.Invitation{ID: 3, MemberID: 12, GatheringID: 100, Status: model.Active}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().LockPendingOfStartedGatherings(ctx, gomock.Any()).Times(1).Return(pending, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().FindByGatheringIDs(ctx, []int64{gathering.ID}).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().ExpireByIDs(ctx, []int64{1, 2}).Times(1).Return(nil)
+		for _, invitation := range pending {
+			mockAttendeeRepo.EXPECT().DeleteByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, nil)
+			mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID).Times(1).Return(nil)
+		}
+		// the released seats go to the member waiting for one
+		mockGatheringRepo.EXPECT().LockByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockWaitlistRepo.EXPECT().FindFirstByGatheringID(ctx, gathering.ID).Times(1).
+			Return(&model.WaitlistEntry{GatheringID: gathering.ID, MemberID: waitlisted.MemberID}, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, waitlisted.MemberID, gathering.ID).Times(1).Return(waitlisted, nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, gathering.ID, waitlisted.MemberID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().Revive(ctx, gomock.Any()).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().FindFirstByGatheringID(ctx, gathering.ID).Times(1).Return(nil, nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			gatheringRepo:      mockGatheringRepo,
+			occurrenceRepo:     mockOccurrenceRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     eventRecorder,
+		}
+
+		res, err := invitationUsecase.ExpirePendingInvitations(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+		assert.Equal(t, model.Expired, pending[0].Status)
+		assert.Equal(t, []model.EventType{model.InvitationPromoted}, eventRecorder.Types())
+	})
+
+	t.Run("invitations of a recurring gathering expire with their occurrence", func(t *testing.T) {
+		firstAt := time.Now().Add(-36 * time.Hour).Truncate(time.Second)
+		secondAt := firstAt.Add(24 * time.Hour)
+		thirdAt := secondAt.Add(24 * time.Hour)
+		gathering := &model.Gathering{ID: 100, Type: model.WithExpirationForInvitations, ScheduledAt: &firstAt,
+			Recurrence: "FREQ=DAILY", TimeZone: "UTC"}
+		// the second occurrence is cancelled, the series invitation sent after the first one expires with the third
+		overrides := []*model.GatheringOccurrenceOverride{{GatheringID: 100, OccurrenceAt: secondAt, Cancelled: true}}
+		pending := []*model.Invitation{
+			{ID: 1, MemberID: 10, GatheringID: 100, Status: model.Pending, OccurrenceAt: &secondAt},
+			{ID: 2, MemberID: 11, GatheringID: 100, Status: model.Pending, OccurrenceAt: &thirdAt},
+			{ID: 3, MemberID: 12, GatheringID: 100, Status: model.Pending, InvitedAt: firstAt.Add(time.Hour)},
+			{ID: 4, MemberID: 13, GatheringID: 100, Status: model.Pending, InvitedAt: firstAt.Add(-time.Hour)},
+		}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().LockPendingOfStartedGatherings(ctx, gomock.Any()).Times(1).Return(pending, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().FindByGatheringIDs(ctx, []int64{gathering.ID}).Times(1).Return(overrides, nil)
+		mockInvitationRepo.EXPECT().ExpireByIDs(ctx, []int64{1, 4}).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().DeleteByMemberIDAndGatheringID(ctx, gomock.Any(), gathering.ID).Times(2).Return(nil, nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, gathering.ID, gomock.Any()).Times(2).Return(nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockWaitlistRepo.EXPECT().FindFirstByGatheringID(ctx, gathering.ID).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			gatheringRepo:      mockGatheringRepo,
+			occurrenceRepo:     mockOccurrenceRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.ExpirePendingInvitations(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+		assert.Equal(t, model.Pending, pending[1].Status)
+		assert.Equal(t, model.Pending, pending[2].Status)
+	})
+
+	t.Run("failed, error lock", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().LockPendingOfStartedGatherings(ctx, gomock.Any()).Times(1).Return(nil, errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		_, err := invitationUsecase.ExpirePendingInvitations(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestBulkInviteMembersToGatheringUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{
+		ID:       444,
+		Creator:  111,
+		Type:     model.WithExpirationForInvitations,
+		Name:     "gathering",
+		Location: "locc",
+	}
+
+	members := []*model.Member{
+		{ID: 1, Email: "one@last.com"},
+		{ID: 2, Email: "two@last.com"},
+		{ID: 3, Email: "three@last.com"},
+	}
+
+	liveInvitation := &model.Invitation{ID: 20, MemberID: 2, GatheringID: gathering.ID, Status: model.Pending}
+	expiredInvitation := &model.Invitation{ID: 30, MemberID: 3, GatheringID: gathering.ID, Status: model.Expired}
+
+	resultsByMember := func(report *model.BulkInvitationReport) map[int64]*model.BulkInvitationResult {
+		res := make(map[int64]*model.BulkInvitationResult)
+		for _, result := range report.Results {
+			res[result.MemberID] = result
+		}
+		return res
+	}
+
+	t.Run("success, transactional", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockTransactionManager := mock.NewMockTransactionManager(ctrl)
+
+		memberIDs := []int64{1, 2, 3, 1}
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1, 2, 3}).Times(1).Return(members, nil)
+		mockInvitationRepo.EXPECT().FindByGatheringIDAndMemberIDs(ctx, gathering.ID, []int64{1, 2, 3}).Times(1).
+			Return([]*model.Invitation{liveInvitation, expiredInvitation}, nil)
+		mockTransactionManager.EXPECT().WithTransaction(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		mockInvitationRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().Create(ctx, &model.Attendee{MemberID: 1, GatheringID: gathering.ID}).Times(1).Return(nil)
+		mockInvitationRepo.EXPECT().Revive(ctx, gomock.Any()).Times(1).Return(expiredInvitation, nil)
+		mockAttendeeRepo.EXPECT().Revive(ctx, &model.Attendee{MemberID: 3, GatheringID: gathering.ID}).Times(1).Return(nil)
+		eventRecorder := event.NewRecorder()
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: mockTransactionManager,
+			eventPublisher:     eventRecorder,
+		}
+
+		report, err := invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: gathering.ID,
+			MemberIDs:   memberIDs,
+			Status:      model.Pending,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, model.BulkInvitationTransactional, report.Mode)
+		assert.Equal(t, 2, report.Invited)
+		assert.Equal(t, 1, report.Skipped)
+		assert.Equal(t, 0, report.Failed)
+
+		results := resultsByMember(report)
+		assert.Len(t, results, 3)
+		assert.Equal(t, model.BulkInvitationInvited, results[1].Status)
+		assert.Equal(t, model.BulkInvitationAlreadyInvited, results[2].Status)
+		assert.Equal(t, liveInvitation.ID, results[2].InvitationID)
+		assert.Equal(t, model.BulkInvitationInvited, results[3].Status)
+		assert.Equal(t, expiredInvitation.ID, results[3].InvitationID)
+		assert.Equal(t, []model.EventType{model.InvitationSent, model.InvitationSent}, eventRecorder.Types())
+	})
+
+	t.Run("failed, transactional rejects unknown member", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1, 99}).Times(1).Return(members[:1], nil)
+		mockInvitationRepo.EXPECT().FindByGatheringIDAndMemberIDs(ctx, gathering.ID, []int64{1, 99}).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo: mockInvitationRepo,
+			memberRepo:     mockMemberRepo,
+			gatheringRepo:  mockGatheringRepo,
+		}
+
+		report, err := invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: gathering.ID,
+			MemberIDs:   []int64{1, 99},
+			Mode:        model.BulkInvitationTransactional,
+		})
+		assert.ErrorIs(t, err, ErrBulkInvitationRejected)
+		assert.Equal(t, 0, report.Invited)
+		assert.Equal(t, 2, report.Failed)
+
+		results := resultsByMember(report)
+		assert.Equal(t, model.BulkInvitationRolledBack, results[1].Status)
+		assert.Equal(t, model.BulkInvitationMemberNotFound, results[99].Status)
+	})
+
+	t.Run("failed, transactional rolls back on insert error", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1, 2}).Times(1).Return(members[:2], nil)
+		mockInvitationRepo.EXPECT().FindByGatheringIDAndMemberIDs(ctx, gathering.ID, []int64{1, 2}).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().Create(ctx, gomock.Any()).Times(2).Return(nil)
+		mockAttendeeRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		report, err := invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: gathering.ID,
+			MemberIDs:   []int64{1, 2},
+		})
+		assert.ErrorIs(t, err, ErrBulkInvitationRejected)
+		assert.Equal(t, 0, report.Invited)
+
+		results := resultsByMember(report)
+		assert.Equal(t, model.BulkInvitationRolledBack, results[1].Status)
+		assert.Equal(t, model.BulkInvitationFailed, results[2].Status)
+		assert.Equal(t, "error", results[2].Error)
+	})
+
+	t.Run("success, best effort", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1, 2, 99}).Times(1).Return(members[:2], nil)
+		mockInvitationRepo.EXPECT().FindByGatheringIDAndMemberIDs(ctx, gathering.ID, []int64{1, 2, 99}).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().Create(ctx, gomock.Any()).Times(2).Return(nil)
+		mockAttendeeRepo.EXPECT().Create(ctx, &model.Attendee{MemberID: 1, GatheringID: gathering.ID}).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().Create(ctx, &model.Attendee{MemberID: 2, GatheringID: gathering.ID}).Times(1).Return(errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		report, err := invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: gathering.ID,
+			MemberIDs:   []int64{1, 2, 99},
+			Mode:        model.BulkInvitationBestEffort,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Invited)
+		assert.Equal(t, 2, report.Failed)
+
+		results := resultsByMember(report)
+		assert.Equal(t, model.BulkInvitationInvited, results[1].Status)
+		assert.NotZero(t, results[1].InvitationID)
+		assert.Equal(t, model.BulkInvitationFailed, results[2].Status)
+		assert.Equal(t, model.BulkInvitationMemberNotFound, results[99].Status)
+	})
+
+	t.Run("failed, invalid request", func(t *testing.T) {
+		invitationUsecase := invitationUsecase{}
+
+		_, err := invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{GatheringID: gathering.ID})
+		assert.ErrorIs(t, err, ErrBulkInvitationEmpty)
+
+		_, err = invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: gathering.ID,
+			MemberIDs:   []int64{1},
+			Mode:        "unknown",
+		})
+		assert.ErrorIs(t, err, ErrInvalidBulkInvitationMode)
+
+		memberIDs := make([]int64, model.MaxBulkInvitationMembers+1)
+		for i := range memberIDs {
+			memberIDs[i] = int64(i + 1)
+		}
+		_, err = invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: gathering.ID,
+			MemberIDs:   memberIDs,
+		})
+		assert.ErrorIs(t, err, ErrBulkInvitationTooLarge)
+	})
+
+	t.Run("failed, gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		report, err := invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: gathering.ID,
+			MemberIDs:   []int64{1},
+		})
+		assert.Nil(t, report)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, error find members", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1}).Times(1).Return(nil, errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			memberRepo:    mockMemberRepo,
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		report, err := invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: gathering.ID,
+			MemberIDs:   []int64{1},
+		})
+		assert.Nil(t, report)
+		assert.Error(t, err)
+	})
+}
+
+func TestRestoreInvitationByIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	invitation := &model.Invitation{
+		ID:          123,
+		MemberID:    321,
+		GatheringID: 444,
+		Status:      model.Active,
+	}
+	member := &model.Member{ID: invitation.MemberID}
+	gathering := &model.Gathering{ID: invitation.GatheringID}
+	attendee := &model.Attendee{MemberID: invitation.MemberID, GatheringID: invitation.GatheringID}
+
+	t.Run("success, restores the attendee", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().RestoreByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockAttendeeRepo.EXPECT().RestoreByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(attendee, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.RestoreInvitationByID(ctx, invitation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, invitation, res)
+	})
+
+	t.Run("success, waitlists the member of a full gathering", func(t *testing.T) {
+		fullGathering := &model.Gathering{ID: invitation.GatheringID, Type: model.WithFixedNumberOfAttendees, Capacity: 5}
+		restored := &model.Invitation{ID: invitation.ID, MemberID: invitation.MemberID, GatheringID: invitation.GatheringID, Status: model.Active}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().RestoreByID(ctx, invitation.ID).Times(1).Return(restored, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(fullGathering, nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, invitation.GatheringID).Times(1).Return(fullGathering, nil)
+		mockAttendeeRepo.EXPECT().CountSeatsByGatheringID(ctx, invitation.GatheringID).Times(1).Return(int64(5), nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil)
+		mockWaitlistRepo.EXPECT().FindByGatheringIDAndMemberID(ctx, invitation.GatheringID, invitation.MemberID).Times(1).
+			Return(&model.WaitlistEntry{GatheringID: invitation.GatheringID, MemberID: invitation.MemberID, Position: 1}, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.RestoreInvitationByID(ctx, invitation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.WaitlistPosition)
+	})
+
+	t.Run("success, a declined invitation gets no seat", func(t *testing.T) {
+		declined := &model.Invitation{ID: invitation.ID, MemberID: invitation.MemberID, GatheringID: invitation.GatheringID, Status: model.Declined}
+
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().RestoreByID(ctx, invitation.ID).Times(1).Return(declined, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.RestoreInvitationByID(ctx, invitation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, declined, res)
+	})
+
+	t.Run("failed, invitation is not deleted", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo: mockInvitationRepo,
+		}
+
+		res, err := invitationUsecase.RestoreInvitationByID(ctx, invitation.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotDeleted)
+	})
+
+	t.Run("failed, invitation not found", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().RestoreByID(ctx, invitation.ID).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.RestoreInvitationByID(ctx, invitation.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, gathering is deleted", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().RestoreByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.RestoreInvitationByID(ctx, invitation.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRestoreParentDeleted)
+	})
+
+	t.Run("failed, error restore attendee", func(t *testing.T) {
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockInvitationRepo.EXPECT().FindByID(ctx, invitation.ID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().RestoreByID(ctx, invitation.ID).Times(1).Return(invitation, nil)
+		mockMemberRepo.EXPECT().FindByID(ctx, invitation.MemberID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().FindByID(ctx, invitation.GatheringID).Times(1).Return(gathering, nil)
+		mockAttendeeRepo.EXPECT().RestoreByMemberIDAndGatheringID(ctx, invitation.MemberID, invitation.GatheringID).Times(1).Return(nil, errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo:     mockInvitationRepo,
+			memberRepo:         mockMemberRepo,
+			gatheringRepo:      mockGatheringRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := invitationUsecase.RestoreInvitationByID(ctx, invitation.ID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestExportRosterByGatheringIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 222, Creator: 111, Name: "gathering"}
+	entry := &model.RosterEntry{MemberID: 321, FirstName: "first", Email: "first@last.com", Status: model.Active}
+
+	t.Run("success", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().StreamRosterByGatheringID(ctx, gathering.ID, gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, gatheringID int64, fn func(entry *model.RosterEntry) error) error {
+				return fn(entry)
+			})
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo: mockInvitationRepo,
+			gatheringRepo:  mockGatheringRepo,
+		}
+
+		var entries []*model.RosterEntry
+		err := invitationUsecase.ExportRosterByGatheringID(ctx, gathering.ID, func(entry *model.RosterEntry) error {
+			entries = append(entries, entry)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.RosterEntry{entry}, entries)
+	})
+
+	t.Run("failed, gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+
+		invitationUsecase := invitationUsecase{
+			gatheringRepo: mockGatheringRepo,
+		}
+
+		err := invitationUsecase.ExportRosterByGatheringID(ctx, gathering.ID, func(entry *model.RosterEntry) error {
+			t.Fatal("no entry is expected")
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, error stream roster", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().StreamRosterByGatheringID(ctx, gathering.ID, gomock.Any()).Times(1).Return(errors.New("error"))
+
+		invitationUsecase := invitationUsecase{
+			invitationRepo: mockInvitationRepo,
+			gatheringRepo:  mockGatheringRepo,
+		}
+
+		err := invitationUsecase.ExportRosterByGatheringID(ctx, gathering.ID, func(entry *model.RosterEntry) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type inviteLinkUsecase struct {
+	inviteLinkRepo     model.InviteLinkRepository
+	gatheringRepo      model.GatheringRepository
+	invitationUsecase  model.InvitationUsecase
+	transactionManager model.TransactionManager
+	secret             string
+	defaultTTL         time.Duration
+}
+
+// NewInviteLinkUsecase signs the link tokens with secret, invite links are disabled when it is empty. A link
+// created without an expiry expires after defaultTTL
+func NewInviteLinkUsecase(
+	inviteLinkRepo model.InviteLinkRepository,
+	gatheringRepo model.GatheringRepository,
+	invitationUsecase model.InvitationUsecase,
+	transactionManager model.TransactionManager,
+	secret string,
+	defaultTTL time.Duration,
+) model.InviteLinkUsecase {
+	return &inviteLinkUsecase{
+		inviteLinkRepo:     inviteLinkRepo,
+		gatheringRepo:      gatheringRepo,
+		invitationUsecase:  invitationUsecase,
+		transactionManager: transactionManager,
+		secret:             secret,
+		defaultTTL:         defaultTTL,
+	}
+}
+
+func (lu *inviteLinkUsecase) CreateInviteLink(ctx context.Context, link *model.InviteLink) (*model.InviteLink, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  ctx,
+		"link": link,
+	})
+
+	if lu.secret == "" {
+		return nil, ErrInviteLinksDisabled
+	}
+
+	now := time.Now()
+	if link.ExpiresAt.IsZero() {
+		link.ExpiresAt = now.Add(lu.defaultTTL)
+	}
+	if link.MaxUses < 0 || link.IsExpired(now) {
+		return nil, ErrInvalidInviteLink
+	}
+	// the token carries the expiry in seconds, so does the stored link
+	link.ExpiresAt = link.ExpiresAt.UTC().Truncate(time.Second)
+	link.Uses = 0
+	link.RevokedAt = nil
+
+	gathering, err := lu.gatheringRepo.FindByID(ctx, link.GatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	if err = lu.inviteLinkRepo.Create(ctx, link); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	link.Token = signInviteLink(lu.secret, link)
+	return link, nil
+}
+
+func (lu *inviteLinkUsecase) FindInviteLinksByGatheringID(ctx context.Context, gatheringID int64) ([]*model.InviteLink, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	if lu.secret == "" {
+		return nil, ErrInviteLinksDisabled
+	}
+
+	gathering, err := lu.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	links, err := lu.inviteLinkRepo.FindByGatheringID(ctx, gatheringID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	for _, link := range links {
+		link.Token = signInviteLink(lu.secret, link)
+	}
+	return links, nil
+}
+
+func (lu *inviteLinkUsecase) FindInviteLinkByID(ctx context.Context, linkID int64) (*model.InviteLink, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"linkID": linkID,
+	})
+
+	link, err := lu.inviteLinkRepo.FindByID(ctx, linkID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case link == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	return link, nil
+}
+
+// RevokeInviteLinkByID stops the link from being redeemed, the members who already redeemed it stay invited
+func (lu *inviteLinkUsecase) RevokeInviteLinkByID(ctx context.Context, linkID int64) (*model.InviteLink, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"linkID": linkID,
+	})
+
+	link, err := lu.inviteLinkRepo.FindByID(ctx, linkID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case link == nil:
+		return nil, ErrRecordNotFound
+	case link.RevokedAt != nil:
+		return link, nil
+	}
+
+	revokedAt := time.Now().UTC()
+	if err = lu.inviteLinkRepo.RevokeByID(ctx, linkID, revokedAt); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	link.RevokedAt = &revokedAt
+	return link, nil
+}
+
+// RedeemInviteLink invites the member to the gathering of the link with an active invitation, the use is only
+// counted when the member gets invited
+func (lu *inviteLinkUsecase) RedeemInviteLink(ctx context.Context, token string, memberID int64) (*model.Invitation, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	if lu.secret == "" {
+		return nil, ErrInviteLinksDisabled
+	}
+
+	// a forged token is reported like an unknown link
+	linkID, expiresAt, err := parseInviteLinkToken(lu.secret, token)
+	if err != nil {
+		return nil, ErrRecordNotFound
+	}
+	now := time.Now()
+	if !now.Before(expiresAt) {
+		return nil, ErrInviteLinkExpired
+	}
+
+	var res *model.Invitation
+	err = lu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		link, err := lu.inviteLinkRepo.LockByID(ctx, linkID)
+		switch {
+		case err != nil:
+			return err
+		case link == nil:
+			return ErrRecordNotFound
+		case link.RevokedAt != nil:
+			return ErrInviteLinkRevoked
+		case link.IsExpired(now):
+			return ErrInviteLinkExpired
+		case link.IsUsedUp():
+			return ErrInviteLinkUsedUp
+		}
+
+		res, err = lu.invitationUsecase.InviteMemberToGathering(ctx, &model.Invitation{
+			ID:          model.GenerateID(),
+			MemberID:    memberID,
+			GatheringID: link.GatheringID,
+			Status:      model.Active,
+		})
+		if err != nil {
+			return err
+		}
+
+		return lu.inviteLinkRepo.IncrementUses(ctx, link.ID)
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// signInviteLink builds the "<link id>.<expiry unix seconds>.<signature>" token of the link, the signature is the
+// base64url HMAC-SHA256 of the two first parts keyed with secret
+func signInviteLink(secret string, link *model.InviteLink) string {
+	payload := fmt.Sprintf("%d.%d", link.ID, link.ExpiresAt.Unix())
+	return payload + "." + inviteLinkSignature(secret, payload)
+}
+
+// parseInviteLinkToken checks the token signature and returns the link ID and expiry it carries
+func parseInviteLinkToken(secret string, token string) (int64, time.Time, error) {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 {
+		return 0, time.Time{}, ErrRecordNotFound
+	}
+	payload, signature := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(inviteLinkSignature(secret, payload))) {
+		return 0, time.Time{}, ErrRecordNotFound
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 2 {
+		return 0, time.Time{}, ErrRecordNotFound
+	}
+	linkID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return linkID, time.Unix(expiresAt, 0), nil
+}
+
+func inviteLinkSignature(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
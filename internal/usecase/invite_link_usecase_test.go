@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testInviteLinkSecret = "secret"
+
+func TestInviteLinkToken(t *testing.T) {
+	link := &model.InviteLink{ID: 123, ExpiresAt: time.Unix(1700000000, 0)}
+	token := signInviteLink(testInviteLinkSecret, link)
+
+	t.Run("round trip", func(t *testing.T) {
+		linkID, expiresAt, err := parseInviteLinkToken(testInviteLinkSecret, token)
+		assert.NoError(t, err)
+		assert.Equal(t, link.ID, linkID)
+		assert.True(t, link.ExpiresAt.Equal(expiresAt))
+	})
+
+	t.Run("rejects another secret", func(t *testing.T) {
+		_, _, err := parseInviteLinkToken("another", token)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects a tampered token", func(t *testing.T) {
+		for _, tampered := range []string{
+			"124" + token[3:],
+			token[:len(token)-1],
+			"",
+			"123.1700000000",
+		} {
+			_, _, err := parseInviteLinkToken(testInviteLinkSecret, tampered)
+			assert.Error(t, err, tampered)
+		}
+	})
+}
+
+func TestCreateInviteLinkUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 444, Name: "gathering"}
+
+	t.Run("success, default expiry", func(t *testing.T) {
+		link := &model.InviteLink{ID: 123, GatheringID: gathering.ID, MaxUses: 1}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().Create(ctx, link).Times(1).Return(nil)
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo: mockInviteLinkRepo,
+			gatheringRepo:  mockGatheringRepo,
+			secret:         testInviteLinkSecret,
+			defaultTTL:     time.Hour,
+		}
+
+		res, err := inviteLinkUsecase.CreateInviteLink(ctx, link)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), res.ExpiresAt, time.Minute)
+		assert.Equal(t, signInviteLink(testInviteLinkSecret, link), res.Token)
+	})
+
+	t.Run("failed, invalid link", func(t *testing.T) {
+		tests := []struct {
+			name string
+			link *model.InviteLink
+		}{
+			{"expired", &model.InviteLink{GatheringID: gathering.ID, ExpiresAt: time.Now().Add(-time.Minute)}},
+			{"negative max uses", &model.InviteLink{GatheringID: gathering.ID, MaxUses: -1}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				inviteLinkUsecase := inviteLinkUsecase{
+					secret:     testInviteLinkSecret,
+					defaultTTL: time.Hour,
+				}
+
+				res, err := inviteLinkUsecase.CreateInviteLink(ctx, tt.link)
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, ErrInvalidInviteLink)
+			})
+		}
+	})
+
+	t.Run("failed, gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, gathering.ID).Times(1).Return(nil, nil)
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			gatheringRepo: mockGatheringRepo,
+			secret:        testInviteLinkSecret,
+			defaultTTL:    time.Hour,
+		}
+
+		res, err := inviteLinkUsecase.CreateInviteLink(ctx, &model.InviteLink{GatheringID: gathering.ID})
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, invite links disabled", func(t *testing.T) {
+		inviteLinkUsecase := inviteLinkUsecase{}
+
+		res, err := inviteLinkUsecase.CreateInviteLink(ctx, &model.InviteLink{GatheringID: gathering.ID})
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInviteLinksDisabled)
+	})
+}
+
+func TestFindInviteLinkByIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(&model.InviteLink{ID: 123, GatheringID: 222}, nil)
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo: mockInviteLinkRepo,
+		}
+
+		res, err := inviteLinkUsecase.FindInviteLinkByID(ctx, 123)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(222), res.GatheringID)
+	})
+
+	t.Run("failed, link not found", func(t *testing.T) {
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(nil, nil)
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo: mockInviteLinkRepo,
+		}
+
+		res, err := inviteLinkUsecase.FindInviteLinkByID(ctx, 123)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestRevokeInviteLinkByIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(&model.InviteLink{ID: 123}, nil)
+		mockInviteLinkRepo.EXPECT().RevokeByID(ctx, int64(123), gomock.Any()).Times(1).Return(nil)
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo: mockInviteLinkRepo,
+		}
+
+		res, err := inviteLinkUsecase.RevokeInviteLinkByID(ctx, 123)
+		assert.NoError(t, err)
+		assert.NotNil(t, res.RevokedAt)
+	})
+
+	t.Run("success, already revoked", func(t *testing.T) {
+		revokedAt := time.Now().Add(-time.Hour)
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(&model.InviteLink{ID: 123, RevokedAt: &revokedAt}, nil)
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo: mockInviteLinkRepo,
+		}
+
+		res, err := inviteLinkUsecase.RevokeInviteLinkByID(ctx, 123)
+		assert.NoError(t, err)
+		assert.Equal(t, &revokedAt, res.RevokedAt)
+	})
+
+	t.Run("failed, link not found", func(t *testing.T) {
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(nil, nil)
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo: mockInviteLinkRepo,
+		}
+
+		res, err := inviteLinkUsecase.RevokeInviteLinkByID(ctx, 123)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestRedeemInviteLinkUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	memberID := int64(321)
+	link := &model.InviteLink{
+		ID:          123,
+		GatheringID: 444,
+		MaxUses:     2,
+		Uses:        1,
+		ExpiresAt:   time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	token := signInviteLink(testInviteLinkSecret, link)
+
+	t.Run("success", func(t *testing.T) {
+		invitation := &model.Invitation{ID: 999, MemberID: memberID, GatheringID: link.GatheringID, Status: model.Active}
+
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().LockByID(ctx, link.ID).Times(1).Return(link, nil)
+		mockInviteLinkRepo.EXPECT().IncrementUses(ctx, link.ID).Times(1).Return(nil)
+		mockInvitationUsecase := mock.NewMockInvitationUsecase(ctrl)
+		mockInvitationUsecase.EXPECT().InviteMemberToGathering(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, in *model.Invitation) (*model.Invitation, error) {
+				assert.Equal(t, memberID, in.MemberID)
+				assert.Equal(t, link.GatheringID, in.GatheringID)
+				assert.Equal(t, model.Active, in.Status)
+				return invitation, nil
+			})
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo:     mockInviteLinkRepo,
+			invitationUsecase:  mockInvitationUsecase,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			secret:             testInviteLinkSecret,
+		}
+
+		res, err := inviteLinkUsecase.RedeemInviteLink(ctx, token, memberID)
+		assert.NoError(t, err)
+		assert.Equal(t, invitation, res)
+	})
+
+	t.Run("failed, link not usable", func(t *testing.T) {
+		revokedAt := time.Now()
+		tests := []struct {
+			name string
+			link *model.InviteLink
+			err  error
+		}{
+			{"revoked", &model.InviteLink{ID: link.ID, ExpiresAt: link.ExpiresAt, RevokedAt: &revokedAt}, ErrInviteLinkRevoked},
+			{"used up", &model.InviteLink{ID: link.ID, ExpiresAt: link.ExpiresAt, MaxUses: 1, Uses: 1}, ErrInviteLinkUsedUp},
+			{"deleted", nil, ErrRecordNotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+				mockInviteLinkRepo.EXPECT().LockByID(ctx, link.ID).Times(1).Return(tt.link, nil)
+
+				inviteLinkUsecase := inviteLinkUsecase{
+					inviteLinkRepo:     mockInviteLinkRepo,
+					transactionManager: initializeTransactionManagerMock(ctrl),
+					secret:             testInviteLinkSecret,
+				}
+
+				res, err := inviteLinkUsecase.RedeemInviteLink(ctx, token, memberID)
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, tt.err)
+			})
+		}
+	})
+
+	t.Run("failed, expired token", func(t *testing.T) {
+		expired := signInviteLink(testInviteLinkSecret, &model.InviteLink{ID: link.ID, ExpiresAt: time.Now().Add(-time.Minute)})
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			secret: testInviteLinkSecret,
+		}
+
+		res, err := inviteLinkUsecase.RedeemInviteLink(ctx, expired, memberID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInviteLinkExpired)
+	})
+
+	t.Run("failed, forged token", func(t *testing.T) {
+		inviteLinkUsecase := inviteLinkUsecase{
+			secret: testInviteLinkSecret,
+		}
+
+		res, err := inviteLinkUsecase.RedeemInviteLink(ctx, signInviteLink("another", link), memberID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, member already invited does not use the link", func(t *testing.T) {
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().LockByID(ctx, link.ID).Times(1).Return(link, nil)
+		mockInviteLinkRepo.EXPECT().IncrementUses(gomock.Any(), gomock.Any()).Times(0)
+		mockInvitationUsecase := mock.NewMockInvitationUsecase(ctrl)
+		mockInvitationUsecase.EXPECT().InviteMemberToGathering(ctx, gomock.Any()).Times(1).
+			Return(nil, ErrInvitationAlreadyExists)
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo:     mockInviteLinkRepo,
+			invitationUsecase:  mockInvitationUsecase,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			secret:             testInviteLinkSecret,
+		}
+
+		res, err := inviteLinkUsecase.RedeemInviteLink(ctx, token, memberID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvitationAlreadyExists)
+	})
+
+	t.Run("failed, error lock link", func(t *testing.T) {
+		mockInviteLinkRepo := mock.NewMockInviteLinkRepository(ctrl)
+		mockInviteLinkRepo.EXPECT().LockByID(ctx, link.ID).Times(1).Return(nil, errors.New("error"))
+
+		inviteLinkUsecase := inviteLinkUsecase{
+			inviteLinkRepo:     mockInviteLinkRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			secret:             testInviteLinkSecret,
+		}
+
+		res, err := inviteLinkUsecase.RedeemInviteLink(ctx, token, memberID)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type jobUsecase struct {
+	jobRepo model.JobRepository
+}
+
+func NewJobUsecase(jobRepo model.JobRepository) model.JobUsecase {
+	return &jobUsecase{
+		jobRepo: jobRepo,
+	}
+}
+
+// FindJobRuns lists the latest job runs, optionally of one job or status
+func (ju *jobUsecase) FindJobRuns(ctx context.Context, filter *model.JobFilter) ([]*model.Job, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"filter": filter,
+	})
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = model.DefaultJobRunsLimit
+	case filter.Limit > model.MaxJobRunsLimit:
+		filter.Limit = model.MaxJobRunsLimit
+	}
+
+	res, err := ju.jobRepo.FindRuns(ctx, filter)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RetryJobByID queues a dead job again, with a fresh set of attempts
+func (ju *jobUsecase) RetryJobByID(ctx context.Context, jobID int64) (*model.Job, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"jobID": jobID,
+	})
+
+	job, err := ju.jobRepo.FindByID(ctx, jobID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case job == nil:
+		return nil, ErrRecordNotFound
+	case job.Status != model.JobDead:
+		return nil, ErrJobNotDead
+	}
+
+	job.Status = model.JobPending
+	job.Attempts = 0
+	job.RunAt = time.Now()
+	job.FinishedAt = nil
+
+	err = ju.jobRepo.Update(ctx, job)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return job, nil
+}
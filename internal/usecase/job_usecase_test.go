@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindJobRuns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("default limit", func(t *testing.T) {
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().FindRuns(ctx, &model.JobFilter{Name: "cleanup", Limit: model.DefaultJobRunsLimit}).Times(1).
+			Return([]*model.Job{{ID: 1}}, nil)
+
+		jobUsecase := NewJobUsecase(mockJobRepo)
+
+		res, err := jobUsecase.FindJobRuns(ctx, &model.JobFilter{Name: "cleanup"})
+
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().FindRuns(ctx, &model.JobFilter{Limit: model.MaxJobRunsLimit}).Times(1).Return(nil, nil)
+
+		jobUsecase := NewJobUsecase(mockJobRepo)
+
+		_, err := jobUsecase.FindJobRuns(ctx, &model.JobFilter{Limit: 10000})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error find runs", func(t *testing.T) {
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().FindRuns(ctx, gomock.Any()).Times(1).Return(nil, errors.New("error"))
+
+		jobUsecase := NewJobUsecase(mockJobRepo)
+
+		_, err := jobUsecase.FindJobRuns(ctx, &model.JobFilter{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRetryJobByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		job := &model.Job{ID: 1, Status: model.JobDead, Attempts: 5, MaxAttempts: 5}
+
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(job, nil)
+		mockJobRepo.EXPECT().Update(ctx, job).Times(1).Return(nil)
+
+		jobUsecase := NewJobUsecase(mockJobRepo)
+
+		res, err := jobUsecase.RetryJobByID(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.JobPending, res.Status)
+		assert.Equal(t, 0, res.Attempts)
+		assert.Nil(t, res.FinishedAt)
+	})
+
+	t.Run("not dead", func(t *testing.T) {
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.Job{ID: 1, Status: model.JobRunning}, nil)
+
+		jobUsecase := NewJobUsecase(mockJobRepo)
+
+		_, err := jobUsecase.RetryJobByID(ctx, 1)
+
+		assert.ErrorIs(t, err, ErrJobNotDead)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockJobRepo := mock.NewMockJobRepository(ctrl)
+		mockJobRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		jobUsecase := NewJobUsecase(mockJobRepo)
+
+		_, err := jobUsecase.RetryJobByID(ctx, 1)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
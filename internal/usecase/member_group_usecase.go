@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// maxMemberGroupNameLength is the length of the name column of the member_groups table
+const maxMemberGroupNameLength = 100
+
+type memberGroupUsecase struct {
+	groupRepo          model.MemberGroupRepository
+	memberRepo         model.MemberRepository
+	invitationUsecase  model.InvitationUsecase
+	transactionManager model.TransactionManager
+}
+
+func NewMemberGroupUsecase(
+	groupRepo model.MemberGroupRepository,
+	memberRepo model.MemberRepository,
+	invitationUsecase model.InvitationUsecase,
+	transactionManager model.TransactionManager,
+) model.MemberGroupUsecase {
+	return &memberGroupUsecase{
+		groupRepo:          groupRepo,
+		memberRepo:         memberRepo,
+		invitationUsecase:  invitationUsecase,
+		transactionManager: transactionManager,
+	}
+}
+
+func (mu *memberGroupUsecase) CreateGroup(ctx context.Context, group *model.MemberGroup) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"group": group,
+	})
+
+	if err := mu.validateGroup(ctx, group); err != nil {
+		return err
+	}
+
+	err := mu.groupRepo.Create(ctx, group)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// FindGroupByID finds the group, only its owner reads it
+func (mu *memberGroupUsecase) FindGroupByID(ctx context.Context, groupID, owner int64) (*model.MemberGroup, error) {
+	return mu.findOwnedGroup(ctx, groupID, owner)
+}
+
+func (mu *memberGroupUsecase) FindGroupsByOwner(ctx context.Context, owner int64) ([]*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"owner": owner,
+	})
+
+	res, err := mu.groupRepo.FindByOwner(ctx, owner)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateGroupByID renames the group, the owner of the group is the member updating it and can't change
+func (mu *memberGroupUsecase) UpdateGroupByID(ctx context.Context, group *model.MemberGroup) (*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"group": group,
+	})
+
+	if err := mu.validateGroup(ctx, group); err != nil {
+		return nil, err
+	}
+
+	if _, err := mu.findOwnedGroup(ctx, group.ID, group.Owner); err != nil {
+		return nil, err
+	}
+
+	res, err := mu.groupRepo.UpdateByID(ctx, group)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case res == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	return res, nil
+}
+
+func (mu *memberGroupUsecase) DeleteGroupByID(ctx context.Context, groupID, owner int64) (*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"groupID": groupID,
+		"owner":   owner,
+	})
+
+	if _, err := mu.findOwnedGroup(ctx, groupID, owner); err != nil {
+		return nil, err
+	}
+
+	res, err := mu.groupRepo.DeleteByID(ctx, groupID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// FindGroupMembers finds the members of the group in the order they were added, only its owner reads them
+func (mu *memberGroupUsecase) FindGroupMembers(ctx context.Context, groupID, owner int64) ([]*model.Member, error) {
+	if _, err := mu.findOwnedGroup(ctx, groupID, owner); err != nil {
+		return nil, err
+	}
+
+	return mu.groupMembers(ctx, groupID)
+}
+
+// groupMembers finds the members of the group in the order they were added
+func (mu *memberGroupUsecase) groupMembers(ctx context.Context, groupID int64) ([]*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"groupID": groupID,
+	})
+
+	memberIDs, err := mu.groupRepo.FindMemberIDs(ctx, groupID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	members, err := mu.memberRepo.FindByIDs(ctx, memberIDs)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	membersByID := make(map[int64]*model.Member, len(members))
+	for _, member := range members {
+		membersByID[member.ID] = member
+	}
+
+	res := make([]*model.Member, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if member, ok := membersByID[memberID]; ok {
+			res = append(res, member)
+		}
+	}
+	return res, nil
+}
+
+// AddGroupMembers adds the members to the group and returns its members, the members already in the group are
+// skipped. Nobody is added when one of the members is not found or the group would outgrow
+// model.MaxMemberGroupMembers
+func (mu *memberGroupUsecase) AddGroupMembers(ctx context.Context, groupID, owner int64, memberIDs []int64) ([]*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"groupID":   groupID,
+		"owner":     owner,
+		"memberIDs": memberIDs,
+	})
+
+	memberIDs = uniqueIDs(memberIDs)
+	switch {
+	case len(memberIDs) == 0:
+		return nil, ErrMemberGroupMembersEmpty
+	case len(memberIDs) > model.MaxMemberGroupMembers:
+		return nil, ErrMemberGroupTooLarge
+	}
+
+	err := mu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// the group is locked so concurrent additions can't outgrow it together
+		group, err := mu.groupRepo.LockByID(ctx, groupID)
+		switch {
+		case err != nil:
+			return err
+		case group == nil:
+			return ErrRecordNotFound
+		case group.Owner != owner:
+			return ErrMemberGroupForbidden
+		}
+
+		members, err := mu.memberRepo.FindByIDs(ctx, memberIDs)
+		switch {
+		case err != nil:
+			return err
+		case len(members) != len(memberIDs):
+			return ErrRecordNotFound
+		}
+
+		currentIDs, err := mu.groupRepo.FindMemberIDs(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if len(uniqueIDs(append(currentIDs, memberIDs...))) > model.MaxMemberGroupMembers {
+			return ErrMemberGroupTooLarge
+		}
+
+		return mu.groupRepo.AddMembers(ctx, groupID, memberIDs)
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return mu.groupMembers(ctx, groupID)
+}
+
+// RemoveGroupMembers removes the members from the group and returns its members, the members not in the group are
+// ignored
+func (mu *memberGroupUsecase) RemoveGroupMembers(ctx context.Context, groupID, owner int64, memberIDs []int64) ([]*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"groupID":   groupID,
+		"owner":     owner,
+		"memberIDs": memberIDs,
+	})
+
+	memberIDs = uniqueIDs(memberIDs)
+	if len(memberIDs) == 0 {
+		return nil, ErrMemberGroupMembersEmpty
+	}
+
+	if _, err := mu.findOwnedGroup(ctx, groupID, owner); err != nil {
+		return nil, err
+	}
+
+	err := mu.groupRepo.RemoveMembers(ctx, groupID, memberIDs)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return mu.groupMembers(ctx, groupID)
+}
+
+// InviteGroupToGathering invites every member of the group to the gathering like BulkInviteMembersToGathering, the
+// members already invited are skipped. Only the owner of the group invites it
+func (mu *memberGroupUsecase) InviteGroupToGathering(ctx context.Context, groupInvitation *model.GroupInvitation, owner int64) (*model.BulkInvitationReport, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":             ctx,
+		"groupInvitation": groupInvitation,
+		"owner":           owner,
+	})
+
+	if _, err := mu.findOwnedGroup(ctx, groupInvitation.GroupID, owner); err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := mu.groupRepo.FindMemberIDs(ctx, groupInvitation.GroupID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return mu.invitationUsecase.BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+		GatheringID:   groupInvitation.GatheringID,
+		MemberIDs:     memberIDs,
+		Status:        groupInvitation.Status,
+		Mode:          groupInvitation.Mode,
+		AllowedGuests: groupInvitation.AllowedGuests,
+		OccurrenceAt:  groupInvitation.OccurrenceAt,
+	})
+}
+
+// findOwnedGroup finds the group and refuses it to anyone but its owner
+func (mu *memberGroupUsecase) findOwnedGroup(ctx context.Context, groupID, owner int64) (*model.MemberGroup, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     ctx,
+		"groupID": groupID,
+		"owner":   owner,
+	})
+
+	group, err := mu.groupRepo.FindByID(ctx, groupID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case group == nil:
+		return nil, ErrRecordNotFound
+	case group.Owner != owner:
+		return nil, ErrMemberGroupForbidden
+	}
+
+	return group, nil
+}
+
+// validateGroup trims the group name and checks the owner is a member
+func (mu *memberGroupUsecase) validateGroup(ctx context.Context, group *model.MemberGroup) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" || utf8.RuneCountInString(group.Name) > maxMemberGroupNameLength {
+		return ErrInvalidMemberGroupName
+	}
+
+	owner, err := mu.memberRepo.FindByID(ctx, group.Owner)
+	switch {
+	case err != nil:
+		return err
+	case owner == nil:
+		return ErrRecordNotFound
+	}
+	return nil
+}
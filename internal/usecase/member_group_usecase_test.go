@@ -0,0 +1,449 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMemberGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		group := &model.MemberGroup{ID: 1, Name: "  Hikers ", Owner: 123}
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(&model.Member{ID: 123}, nil)
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().Create(ctx, group).Times(1).Return(nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:  mockGroupRepo,
+			memberRepo: mockMemberRepo,
+		}
+
+		err := memberGroupUsecase.CreateGroup(ctx, group)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Hikers", group.Name)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		memberGroupUsecase := memberGroupUsecase{}
+
+		err := memberGroupUsecase.CreateGroup(ctx, &model.MemberGroup{Name: " ", Owner: 123})
+		assert.ErrorIs(t, err, ErrInvalidMemberGroupName)
+
+		err = memberGroupUsecase.CreateGroup(ctx, &model.MemberGroup{Name: strings.Repeat("a", 101), Owner: 123})
+		assert.ErrorIs(t, err, ErrInvalidMemberGroupName)
+	})
+
+	t.Run("owner not found", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(nil, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			memberRepo: mockMemberRepo,
+		}
+
+		err := memberGroupUsecase.CreateGroup(ctx, &model.MemberGroup{Name: "Hikers", Owner: 123})
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestUpdateMemberGroupByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	group := &model.MemberGroup{ID: 1, Name: "Hikers", Owner: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(&model.Member{ID: 123}, nil)
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+		mockGroupRepo.EXPECT().UpdateByID(ctx, group).Times(1).Return(group, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:  mockGroupRepo,
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberGroupUsecase.UpdateGroupByID(ctx, group)
+
+		assert.NoError(t, err)
+		assert.Equal(t, group, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(123)).Times(1).Return(&model.Member{ID: 123}, nil)
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:  mockGroupRepo,
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberGroupUsecase.UpdateGroupByID(ctx, group)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("group of another member", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(124)).Times(1).Return(&model.Member{ID: 124}, nil)
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:  mockGroupRepo,
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberGroupUsecase.UpdateGroupByID(ctx, &model.MemberGroup{ID: 1, Name: "Hikers", Owner: 124})
+
+		assert.ErrorIs(t, err, ErrMemberGroupForbidden)
+		assert.Nil(t, res)
+	})
+}
+
+func TestDeleteMemberGroupByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	group := &model.MemberGroup{ID: 1, Name: "Hikers", Owner: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+		mockGroupRepo.EXPECT().DeleteByID(ctx, int64(1)).Times(1).Return(group, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.DeleteGroupByID(ctx, 1, 123)
+
+		assert.NoError(t, err)
+		assert.Equal(t, group, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.DeleteGroupByID(ctx, 1, 123)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("group of another member", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.DeleteGroupByID(ctx, 1, 124)
+
+		assert.ErrorIs(t, err, ErrMemberGroupForbidden)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindMemberGroupByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	group := &model.MemberGroup{ID: 1, Name: "Hikers", Owner: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.FindGroupByID(ctx, 1, 123)
+
+		assert.NoError(t, err)
+		assert.Equal(t, group, res)
+	})
+
+	t.Run("group of another member", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.FindGroupByID(ctx, 1, 124)
+
+		assert.ErrorIs(t, err, ErrMemberGroupForbidden)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFindMemberGroupMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	group := &model.MemberGroup{ID: 1, Name: "Hikers", Owner: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+		mockGroupRepo.EXPECT().FindMemberIDs(ctx, int64(1)).Times(1).Return([]int64{11, 10}, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{11, 10}).Times(1).
+			Return([]*model.Member{{ID: 10}, {ID: 11}}, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:  mockGroupRepo,
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberGroupUsecase.FindGroupMembers(ctx, 1, 123)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.Member{{ID: 11}, {ID: 10}}, res)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.FindGroupMembers(ctx, 1, 123)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("group of another member", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.FindGroupMembers(ctx, 1, 124)
+
+		assert.ErrorIs(t, err, ErrMemberGroupForbidden)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAddMemberGroupMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	group := &model.MemberGroup{ID: 1, Name: "Hikers", Owner: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(group, nil)
+		mockGroupRepo.EXPECT().FindMemberIDs(ctx, int64(1)).Times(1).Return([]int64{10}, nil)
+		mockGroupRepo.EXPECT().AddMembers(ctx, int64(1), []int64{10, 11}).Times(1).Return(nil)
+		mockGroupRepo.EXPECT().FindMemberIDs(ctx, int64(1)).Times(1).Return([]int64{10, 11}, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{10, 11}).Times(2).
+			Return([]*model.Member{{ID: 10}, {ID: 11}}, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:          mockGroupRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := memberGroupUsecase.AddGroupMembers(ctx, 1, 123, []int64{10, 11, 10})
+
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("no member", func(t *testing.T) {
+		memberGroupUsecase := memberGroupUsecase{}
+
+		res, err := memberGroupUsecase.AddGroupMembers(ctx, 1, 123, nil)
+
+		assert.ErrorIs(t, err, ErrMemberGroupMembersEmpty)
+		assert.Nil(t, res)
+	})
+
+	t.Run("member not found", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(group, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{10, 11}).Times(1).
+			Return([]*model.Member{{ID: 10}}, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:          mockGroupRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := memberGroupUsecase.AddGroupMembers(ctx, 1, 123, []int64{10, 11})
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("group of another member", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(group, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:          mockGroupRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := memberGroupUsecase.AddGroupMembers(ctx, 1, 124, []int64{10})
+
+		assert.ErrorIs(t, err, ErrMemberGroupForbidden)
+		assert.Nil(t, res)
+	})
+
+	t.Run("group too large", func(t *testing.T) {
+		currentIDs := make([]int64, 0, model.MaxMemberGroupMembers)
+		for i := 0; i < model.MaxMemberGroupMembers; i++ {
+			currentIDs = append(currentIDs, int64(100+i))
+		}
+
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().LockByID(ctx, int64(1)).Times(1).Return(group, nil)
+		mockGroupRepo.EXPECT().FindMemberIDs(ctx, int64(1)).Times(1).Return(currentIDs, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{10}).Times(1).
+			Return([]*model.Member{{ID: 10}}, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:          mockGroupRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := memberGroupUsecase.AddGroupMembers(ctx, 1, 123, []int64{10})
+
+		assert.ErrorIs(t, err, ErrMemberGroupTooLarge)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRemoveMemberGroupMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	group := &model.MemberGroup{ID: 1, Name: "Hikers", Owner: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+		mockGroupRepo.EXPECT().RemoveMembers(ctx, int64(1), []int64{10}).Times(1).Return(nil)
+		mockGroupRepo.EXPECT().FindMemberIDs(ctx, int64(1)).Times(1).Return([]int64{11}, nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{11}).Times(1).Return([]*model.Member{{ID: 11}}, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:  mockGroupRepo,
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberGroupUsecase.RemoveGroupMembers(ctx, 1, 123, []int64{10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.Member{{ID: 11}}, res)
+	})
+
+	t.Run("error from DB", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(group, nil)
+		mockGroupRepo.EXPECT().RemoveMembers(ctx, int64(1), []int64{10}).Times(1).Return(errors.New("error"))
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.RemoveGroupMembers(ctx, 1, 123, []int64{10})
+
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestInviteGroupToGathering(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	groupInvitation := &model.GroupInvitation{
+		GroupID:     1,
+		GatheringID: 2,
+		Status:      model.Pending,
+		Mode:        model.BulkInvitationBestEffort,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		report := &model.BulkInvitationReport{GatheringID: 2, Invited: 1, Skipped: 1}
+
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.MemberGroup{ID: 1, Owner: 123}, nil)
+		mockGroupRepo.EXPECT().FindMemberIDs(ctx, int64(1)).Times(1).Return([]int64{10, 11}, nil)
+		mockInvitationUsecase := mock.NewMockInvitationUsecase(ctrl)
+		mockInvitationUsecase.EXPECT().BulkInviteMembersToGathering(ctx, &model.BulkInvitation{
+			GatheringID: 2,
+			MemberIDs:   []int64{10, 11},
+			Status:      model.Pending,
+			Mode:        model.BulkInvitationBestEffort,
+		}).Times(1).Return(report, nil)
+
+		memberGroupUsecase := memberGroupUsecase{
+			groupRepo:         mockGroupRepo,
+			invitationUsecase: mockInvitationUsecase,
+		}
+
+		res, err := memberGroupUsecase.InviteGroupToGathering(ctx, groupInvitation, 123)
+
+		assert.NoError(t, err)
+		assert.Equal(t, report, res)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.InviteGroupToGathering(ctx, groupInvitation, 123)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("group of another member", func(t *testing.T) {
+		mockGroupRepo := mock.NewMockMemberGroupRepository(ctrl)
+		mockGroupRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.MemberGroup{ID: 1, Owner: 124}, nil)
+
+		memberGroupUsecase := memberGroupUsecase{groupRepo: mockGroupRepo}
+
+		res, err := memberGroupUsecase.InviteGroupToGathering(ctx, groupInvitation, 123)
+
+		assert.ErrorIs(t, err, ErrMemberGroupForbidden)
+		assert.Nil(t, res)
+	})
+}
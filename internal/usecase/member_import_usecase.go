@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// maxMemberFieldLength is the length of the name and email columns of the members table
+const maxMemberFieldLength = 100
+
+var memberImportColumns = []string{"first_name", "last_name", "email"}
+
+// ImportMembers registers the members of the CSV file, a member already registered with the email of a row gets the
+// names of the row instead. The whole file is validated before anything is written, then the valid rows are written
+// in batches of model.MemberImportBatchSize, each in its own transaction. A failed batch fails its rows only. A dry
+// run validates the file and reports what would change without writing it
+func (mu *memberUsecase) ImportMembers(ctx context.Context, r io.Reader, dryRun bool) (*model.MemberImportReport, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    ctx,
+		"dryRun": dryRun,
+	})
+
+	rows, err := parseMemberImport(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var valid []*model.MemberImportRow
+	for _, row := range rows {
+		if row.Status != model.MemberImportFailed {
+			valid = append(valid, row)
+		}
+	}
+
+	for start := 0; start < len(valid); start += model.MemberImportBatchSize {
+		end := start + model.MemberImportBatchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+
+		batch := valid[start:end]
+		if err := mu.importMemberBatch(ctx, batch, dryRun); err != nil {
+			logger.WithField("line", batch[0].Line).Error(err)
+			for _, row := range batch {
+				row.Status = model.MemberImportFailed
+				row.Error = err.Error()
+			}
+		}
+	}
+
+	report := &model.MemberImportReport{DryRun: dryRun}
+	report.Tally(rows)
+	return report, nil
+}
+
+// importMemberBatch creates the members of the rows not registered yet and renames the others
+func (mu *memberUsecase) importMemberBatch(ctx context.Context, rows []*model.MemberImportRow, dryRun bool) error {
+	upsert := func(ctx context.Context) error {
+		emails := make([]string, 0, len(rows))
+		for _, row := range rows {
+			emails = append(emails, row.Email)
+		}
+
+		members, err := mu.memberRepo.FindByEmails(ctx, emails)
+		if err != nil {
+			return err
+		}
+		// the members are the oldest first, an email registered twice belongs to its oldest member
+		membersByEmail := make(map[string]*model.Member, len(members))
+		for _, member := range members {
+			email := strings.ToLower(member.Email)
+			if _, ok := membersByEmail[email]; !ok {
+				membersByEmail[email] = member
+			}
+		}
+
+		var created, updated []*model.Member
+		for _, row := range rows {
+			member, ok := membersByEmail[row.Email]
+			switch {
+			case !ok:
+				row.Status = model.MemberImportCreated
+				row.Member.ID = model.GenerateID()
+				created = append(created, row.Member)
+			case member.FirstName == row.Member.FirstName && member.LastName == row.Member.LastName:
+				row.Status = model.MemberImportUnchanged
+				row.Member = member
+			default:
+				row.Status = model.MemberImportUpdated
+				member.FirstName, member.LastName = row.Member.FirstName, row.Member.LastName
+				row.Member = member
+				updated = append(updated, member)
+			}
+		}
+
+		if dryRun {
+			return nil
+		}
+
+		if err := mu.memberRepo.CreateBatch(ctx, created); err != nil {
+			return err
+		}
+		for _, member := range created {
+			// the member gets the invitations sent to their email before they registered
+			if _, err := mu.invitationUsecase.LinkEmailInvitations(ctx, member); err != nil {
+				return err
+			}
+			if err := publishEvent(ctx, mu.eventPublisher, model.MemberRegistered, member); err != nil {
+				return err
+			}
+		}
+
+		for _, member := range updated {
+			if _, err := mu.memberRepo.UpdateByID(ctx, member); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if dryRun {
+		return upsert(ctx)
+	}
+	return mu.transactionManager.WithTransaction(ctx, upsert)
+}
+
+// parseMemberImport reads the rows of the CSV file and validates them. The header names the first_name, last_name
+// and email columns in any order, the other columns are ignored. A row repeating the email of an earlier row fails
+func parseMemberImport(r io.Reader) ([]*model.MemberImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	switch {
+	case errors.Is(err, io.EOF):
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidMemberImport)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMemberImport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheets may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	for _, name := range memberImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: the header has no %s column", ErrInvalidMemberImport, name)
+		}
+	}
+
+	var rows []*model.MemberImportRow
+	lineByEmail := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		switch {
+		case errors.Is(err, io.EOF):
+			return rows, nil
+		case err != nil:
+			return nil, fmt.Errorf("%w: %w", ErrInvalidMemberImport, err)
+		}
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &model.MemberImportRow{
+			Line:  line,
+			Email: field("email"),
+			Member: &model.Member{
+				FirstName: field("first_name"),
+				LastName:  field("last_name"),
+			},
+		}
+		rows = append(rows, row)
+
+		if err := validateImportedMember(row); err != nil {
+			row.Status = model.MemberImportFailed
+			row.Error = err.Error()
+			continue
+		}
+
+		if firstLine, ok := lineByEmail[row.Email]; ok {
+			row.Status = model.MemberImportFailed
+			row.Error = fmt.Sprintf("email is already on line %d", firstLine)
+			continue
+		}
+		lineByEmail[row.Email] = line
+	}
+}
+
+// validateImportedMember checks the fields of the row and normalizes its email
+func validateImportedMember(row *model.MemberImportRow) error {
+	email, ok := model.NormalizeEmail(row.Email)
+	if !ok {
+		return ErrInvalidEmail
+	}
+	row.Email = email
+	row.Member.Email = email
+
+	switch {
+	case row.Member.FirstName == "" || row.Member.LastName == "":
+		return ErrMemberNameRequired
+	case utf8.RuneCountInString(row.Member.FirstName) > maxMemberFieldLength,
+		utf8.RuneCountInString(row.Member.LastName) > maxMemberFieldLength,
+		utf8.RuneCountInString(email) > maxMemberFieldLength:
+		return ErrMemberFieldTooLong
+	}
+	return nil
+}
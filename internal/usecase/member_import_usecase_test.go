@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	file := "First_Name,Last_Name,Email\n" +
+		"John,Doe,John@Doe.com\n" +
+		"Jane,Roe,jane@doe.com\n" +
+		"Jim,Doe,jim@doe.com\n"
+	emails := []string{"john@doe.com", "jane@doe.com", "jim@doe.com"}
+
+	existingMembers := func() []*model.Member {
+		return []*model.Member{
+			{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@doe.com"},
+			{ID: 2, FirstName: "Jane", LastName: "Doe", Email: "jane@doe.com"},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByEmails(gomock.Any(), emails).Times(1).Return(existingMembers(), nil)
+		mockMemberRepo.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, members []*model.Member) error {
+				assert.Len(t, members, 1)
+				assert.Equal(t, "jim@doe.com", members[0].Email)
+				assert.NotZero(t, members[0].ID)
+				return nil
+			})
+		mockMemberRepo.EXPECT().UpdateByID(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, member *model.Member) (*model.Member, error) {
+				assert.Equal(t, int64(2), member.ID)
+				assert.Equal(t, "Roe", member.LastName)
+				return member, nil
+			})
+		mockInvitationUsecase := mock.NewMockInvitationUsecase(ctrl)
+		mockInvitationUsecase.EXPECT().LinkEmailInvitations(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		eventRecorder := event.NewRecorder()
+
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			invitationUsecase:  mockInvitationUsecase,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := memberUsecase.ImportMembers(ctx, strings.NewReader(file), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &model.MemberImportReport{
+			Created:   1,
+			Updated:   1,
+			Unchanged: 1,
+			Errors:    []*model.MemberImportRow{},
+		}, res)
+		assert.Equal(t, []model.EventType{model.MemberRegistered}, eventRecorder.Types())
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByEmails(gomock.Any(), emails).Times(1).Return(existingMembers(), nil)
+		eventRecorder := event.NewRecorder()
+
+		memberUsecase := memberUsecase{
+			memberRepo:     mockMemberRepo,
+			eventPublisher: eventRecorder,
+		}
+
+		res, err := memberUsecase.ImportMembers(ctx, strings.NewReader(file), true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &model.MemberImportReport{
+			DryRun:    true,
+			Created:   1,
+			Updated:   1,
+			Unchanged: 1,
+			Errors:    []*model.MemberImportRow{},
+		}, res)
+		assert.Empty(t, eventRecorder.Events())
+	})
+
+	t.Run("invalid rows", func(t *testing.T) {
+		file := "email,first_name,last_name,phone\n" +
+			"not-an-email,John,Doe,123\n" +
+			"jane@doe.com,,Doe,123\n" +
+			"jim@doe.com,Jim,Doe\n" +
+			"JIM@doe.com,Jim,Doe,123\n" +
+			fmt.Sprintf("joe@doe.com,%s,Doe,123\n", strings.Repeat("a", 101))
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByEmails(gomock.Any(), []string{"jim@doe.com"}).Times(1).Return(nil, nil)
+		mockMemberRepo.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockInvitationUsecase := mock.NewMockInvitationUsecase(ctrl)
+		mockInvitationUsecase.EXPECT().LinkEmailInvitations(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			invitationUsecase:  mockInvitationUsecase,
+			eventPublisher:     event.NewRecorder(),
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := memberUsecase.ImportMembers(ctx, strings.NewReader(file), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res.Created)
+		assert.Equal(t, 4, res.Failed)
+		assert.Equal(t, []*model.MemberImportRow{
+			{Line: 2, Email: "not-an-email", Status: model.MemberImportFailed, Error: ErrInvalidEmail.Error()},
+			{Line: 3, Email: "jane@doe.com", Status: model.MemberImportFailed, Error: ErrMemberNameRequired.Error()},
+			{Line: 5, Email: "jim@doe.com", Status: model.MemberImportFailed, Error: "email is already on line 4"},
+			{Line: 6, Email: "joe@doe.com", Status: model.MemberImportFailed, Error: ErrMemberFieldTooLong.Error()},
+		}, clearImportedMembers(res.Errors))
+	})
+
+	t.Run("error from a batch", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByEmails(gomock.Any(), emails).Times(1).Return(existingMembers(), nil)
+		mockMemberRepo.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("error"))
+
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			eventPublisher:     event.NewRecorder(),
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		res, err := memberUsecase.ImportMembers(ctx, strings.NewReader(file), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, res.Failed)
+		assert.Len(t, res.Errors, 3)
+	})
+
+	t.Run("header without email column", func(t *testing.T) {
+		memberUsecase := memberUsecase{}
+
+		res, err := memberUsecase.ImportMembers(ctx, strings.NewReader("first_name,last_name\nJohn,Doe\n"), false)
+
+		assert.ErrorIs(t, err, ErrInvalidMemberImport)
+		assert.Nil(t, res)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		memberUsecase := memberUsecase{}
+
+		res, err := memberUsecase.ImportMembers(ctx, strings.NewReader(""), false)
+
+		assert.ErrorIs(t, err, ErrInvalidMemberImport)
+		assert.Nil(t, res)
+	})
+
+	t.Run("malformed file", func(t *testing.T) {
+		memberUsecase := memberUsecase{}
+
+		res, err := memberUsecase.ImportMembers(ctx, strings.NewReader("first_name,last_name,email\n\"John,Doe,john@doe.com\n"), false)
+
+		assert.ErrorIs(t, err, ErrInvalidMemberImport)
+		assert.Nil(t, res)
+	})
+}
+
+// clearImportedMembers drops the members of the rows, they are not part of the report
+func clearImportedMembers(rows []*model.MemberImportRow) []*model.MemberImportRow {
+	for _, row := range rows {
+		row.Member = nil
+	}
+	return rows
+}
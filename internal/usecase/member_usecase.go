@@ -2,18 +2,45 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/sirupsen/logrus"
 )
 
 type memberUsecase struct {
-	memberRepo model.MemberRepository
+	memberRepo         model.MemberRepository
+	invitationRepo     model.InvitationRepository
+	attendeeRepo       model.AttendeeRepository
+	gatheringRepo      model.GatheringRepository
+	waitlistRepo       model.WaitlistRepository
+	invitationUsecase  model.InvitationUsecase
+	transactionManager model.TransactionManager
+	eventPublisher     model.EventPublisher
+	deletionPolicy     model.DeletionPolicy
 }
 
-func NewMemberUsecase(memberRepo model.MemberRepository) model.MemberUsecase {
+func NewMemberUsecase(
+	memberRepo model.MemberRepository,
+	invitationRepo model.InvitationRepository,
+	attendeeRepo model.AttendeeRepository,
+	gatheringRepo model.GatheringRepository,
+	waitlistRepo model.WaitlistRepository,
+	invitationUsecase model.InvitationUsecase,
+	transactionManager model.TransactionManager,
+	eventPublisher model.EventPublisher,
+	deletionPolicy model.DeletionPolicy,
+) model.MemberUsecase {
 	return &memberUsecase{
-		memberRepo: memberRepo,
+		memberRepo:         memberRepo,
+		invitationRepo:     invitationRepo,
+		attendeeRepo:       attendeeRepo,
+		gatheringRepo:      gatheringRepo,
+		waitlistRepo:       waitlistRepo,
+		invitationUsecase:  invitationUsecase,
+		transactionManager: transactionManager,
+		eventPublisher:     eventPublisher,
+		deletionPolicy:     deletionPolicy,
 	}
 }
 
@@ -22,7 +49,16 @@ func (mu *memberUsecase) Register(ctx context.Context, member *model.Member) err
 		"ctx":    ctx,
 		"member": member,
 	})
-	err := mu.memberRepo.Create(ctx, member)
+	err := mu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := mu.memberRepo.Create(ctx, member); err != nil {
+			return err
+		}
+		// the member gets the invitations sent to their email before they registered
+		if _, err := mu.invitationUsecase.LinkEmailInvitations(ctx, member); err != nil {
+			return err
+		}
+		return publishEvent(ctx, mu.eventPublisher, model.MemberRegistered, member)
+	})
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -87,11 +123,138 @@ func (mu *memberUsecase) DeleteMemberByID(ctx context.Context, memberID int64) (
 		return nil, ErrRecordNotFound
 	}
 
-	res, err := mu.memberRepo.DeleteByID(ctx, memberID)
+	var res *model.Member
+	err = mu.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if mu.deletionPolicy == model.RestrictDeletion {
+			invitations, err := mu.invitationRepo.FindByMemberID(ctx, memberID)
+			switch {
+			case err != nil:
+				return err
+			case hasLiveInvitation(invitations):
+				return ErrDeletionRestricted
+			}
+		}
+
+		if err := mu.invitationRepo.DeleteByMemberID(ctx, memberID); err != nil {
+			return err
+		}
+
+		attendees, err := mu.attendeeRepo.FindByMemberID(ctx, memberID)
+		if err != nil {
+			return err
+		}
+
+		if err := mu.attendeeRepo.DeleteByMemberID(ctx, memberID); err != nil {
+			return err
+		}
+
+		res, err = mu.memberRepo.DeleteByID(ctx, memberID)
+		if err != nil {
+			return err
+		}
+
+		// the seats the member held go to the waitlists of their gatherings
+		promoter := mu.waitlistPromoter()
+		promoted := make(map[int64]bool, len(attendees))
+		for _, attendee := range attendees {
+			if promoted[attendee.GatheringID] {
+				continue
+			}
+			promoted[attendee.GatheringID] = true
+			if _, err := promoter.promote(ctx, attendee.GatheringID); err != nil {
+				return err
+			}
+		}
+
+		return publishEvent(ctx, mu.eventPublisher, model.MemberDeleted, res)
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (mu *memberUsecase) FindDeletedMembers(ctx context.Context) ([]*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	res, err := mu.memberRepo.FindAllDeleted(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (mu *memberUsecase) RestoreMemberByID(ctx context.Context, memberID int64) (*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	member, err := mu.memberRepo.FindByID(ctx, memberID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case member != nil:
+		return nil, ErrRecordNotDeleted
+	}
+
+	res, err := mu.memberRepo.RestoreByID(ctx, memberID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case res == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	return res, nil
+}
+
+// FindMemberStats reports the attendance of the member to the gatherings starting within [from, to)
+func (mu *memberUsecase) FindMemberStats(ctx context.Context, memberID int64, from, to time.Time) (*model.MemberStats, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+		"from":     from,
+		"to":       to,
+	})
+
+	if !to.After(from) {
+		return nil, ErrInvalidDateRange
+	}
+
+	member, err := mu.memberRepo.FindByID(ctx, memberID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case member == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	res, err := mu.invitationRepo.CountStatsByMemberID(ctx, memberID, from.UTC(), to.UTC(), time.Now())
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
+	res.ComputeRates()
 	return res, nil
 }
+
+func (mu *memberUsecase) waitlistPromoter() *waitlistPromoter {
+	return &waitlistPromoter{
+		gatheringRepo:  mu.gatheringRepo,
+		invitationRepo: mu.invitationRepo,
+		attendeeRepo:   mu.attendeeRepo,
+		waitlistRepo:   mu.waitlistRepo,
+		eventPublisher: mu.eventPublisher,
+	}
+}
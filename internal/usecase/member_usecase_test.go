@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model"
 	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
 	"github.com/golang/mock/gomock"
@@ -34,14 +35,41 @@ func TestRegister(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
 		mockMemberRepo.EXPECT().Create(ctx, member).Times(1).Return(nil)
+		mockInvitationUsecase := mock.NewMockInvitationUsecase(ctrl)
+		mockInvitationUsecase.EXPECT().LinkEmailInvitations(ctx, member).Times(1).Return(nil, nil)
+		eventRecorder := event.NewRecorder()
 
 		memberUsecase := memberUsecase{
-			memberRepo: mockMemberRepo,
+			memberRepo:         mockMemberRepo,
+			invitationUsecase:  mockInvitationUsecase,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		err := memberUsecase.Register(ctx, member)
 
 		assert.NoError(t, err)
+		assert.Equal(t, []model.EventType{model.MemberRegistered}, eventRecorder.Types())
+	})
+
+	t.Run("error link email invitations", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().Create(ctx, member).Times(1).Return(nil)
+		mockInvitationUsecase := mock.NewMockInvitationUsecase(ctrl)
+		mockInvitationUsecase.EXPECT().LinkEmailInvitations(ctx, member).Times(1).Return(nil, errors.New("error"))
+		eventRecorder := event.NewRecorder()
+
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			invitationUsecase:  mockInvitationUsecase,
+			eventPublisher:     eventRecorder,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+		}
+
+		err := memberUsecase.Register(ctx, member)
+
+		assert.Error(t, err)
+		assert.Empty(t, eventRecorder.Events())
 	})
 
 	t.Run("error create member", func(t *testing.T) {
@@ -49,7 +77,8 @@ func TestRegister(t *testing.T) {
 		mockMemberRepo.EXPECT().Create(ctx, member).Times(1).Return(errors.New("error"))
 
 		memberUsecase := memberUsecase{
-			memberRepo: mockMemberRepo,
+			memberRepo:         mockMemberRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
 		}
 
 		err := memberUsecase.Register(ctx, member)
@@ -222,14 +251,122 @@ func TestDeleteMemberByID(t *testing.T) {
 		DeletedAt: gorm.DeletedAt{},
 	}
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("success, cascade to invitations and attendees", func(t *testing.T) {
 		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
 		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockAttendeeRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
+		mockMemberRepo.EXPECT().DeleteByID(ctx, member.ID).Times(1).Return(member, nil)
+		eventRecorder := event.NewRecorder()
 
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     eventRecorder,
+			deletionPolicy:     model.CascadeDeletion,
+		}
+
+		res, err := memberUsecase.DeleteMemberByID(ctx, member.ID)
+		assert.NotNil(t, res)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventType{model.MemberDeleted}, eventRecorder.Types())
+	})
+
+	t.Run("success, the seats of the member go to the waitlists", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 222, Type: model.WithFixedNumberOfAttendees, Capacity: 2}
+		waitlisted := &model.Invitation{ID: 5, MemberID: 456, GatheringID: gathering.ID, Status: model.Active}
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockWaitlistRepo := mock.NewMockWaitlistRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).Return([]*model.Attendee{
+			{MemberID: member.ID, GatheringID: gathering.ID},
+		}, nil)
+		mockAttendeeRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
 		mockMemberRepo.EXPECT().DeleteByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockGatheringRepo.EXPECT().LockByID(ctx, gathering.ID).Times(1).Return(gathering, nil)
+		mockAttendeeRepo.EXPECT().CountSeatsByGatheringID(ctx, gathering.ID).Times(1).Return(int64(1), nil)
+		mockWaitlistRepo.EXPECT().FindFirstByGatheringID(ctx, gathering.ID).Times(1).
+			Return(&model.WaitlistEntry{GatheringID: gathering.ID, MemberID: waitlisted.MemberID}, nil)
+		mockInvitationRepo.EXPECT().FindByMemberIDAndGatheringID(ctx, waitlisted.MemberID, gathering.ID).Times(1).Return(waitlisted, nil)
+		mockWaitlistRepo.EXPECT().DeleteByGatheringIDAndMemberID(ctx, gathering.ID, waitlisted.MemberID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().Revive(ctx, gomock.Any()).Times(1).Return(nil)
+		eventRecorder := event.NewRecorder()
 
 		memberUsecase := memberUsecase{
-			memberRepo: mockMemberRepo,
+			memberRepo:         mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			gatheringRepo:      mockGatheringRepo,
+			waitlistRepo:       mockWaitlistRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			eventPublisher:     eventRecorder,
+			deletionPolicy:     model.CascadeDeletion,
+		}
+
+		res, err := memberUsecase.DeleteMemberByID(ctx, member.ID)
+		assert.NotNil(t, res)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventType{model.InvitationPromoted, model.MemberDeleted}, eventRecorder.Types())
+	})
+
+	t.Run("success, restrict without live invitations", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockInvitationRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockAttendeeRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
+		mockMemberRepo.EXPECT().DeleteByID(ctx, member.ID).Times(1).Return(member, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			deletionPolicy:     model.RestrictDeletion,
+		}
+
+		res, err := memberUsecase.DeleteMemberByID(ctx, member.ID)
+		assert.NotNil(t, res)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success, restrict ignores expired and declined invitations", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).Return([]*model.Invitation{
+			{ID: 1, MemberID: member.ID, Status: model.Expired},
+			{ID: 2, MemberID: member.ID, Status: model.Declined},
+		}, nil)
+		mockInvitationRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockAttendeeRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
+		mockMemberRepo.EXPECT().DeleteByID(ctx, member.ID).Times(1).Return(member, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			deletionPolicy:     model.RestrictDeletion,
 		}
 
 		res, err := memberUsecase.DeleteMemberByID(ctx, member.ID)
@@ -265,16 +402,66 @@ func TestDeleteMemberByID(t *testing.T) {
 		assert.EqualError(t, err, ErrRecordNotFound.Error())
 	})
 
-	t.Run("failed, error delete", func(t *testing.T) {
+	t.Run("failed, restricted by live invitations", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).
+			Return([]*model.Invitation{{ID: 1, MemberID: member.ID}}, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			deletionPolicy:     model.RestrictDeletion,
+		}
+
+		res, err := memberUsecase.DeleteMemberByID(ctx, member.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrDeletionRestricted)
+	})
+
+	t.Run("failed, error delete invitations", func(t *testing.T) {
 		errorDelete := errors.New("error delete")
 
 		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+
 		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(errorDelete)
 
+		memberUsecase := memberUsecase{
+			memberRepo:         mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			deletionPolicy:     model.CascadeDeletion,
+		}
+
+		res, err := memberUsecase.DeleteMemberByID(ctx, member.ID)
+		assert.Nil(t, res)
+		assert.EqualError(t, err, errorDelete.Error())
+	})
+
+	t.Run("failed, error delete", func(t *testing.T) {
+		errorDelete := errors.New("error delete")
+
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
+		mockAttendeeRepo.EXPECT().FindByMemberID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockAttendeeRepo.EXPECT().DeleteByMemberID(ctx, member.ID).Times(1).Return(nil)
 		mockMemberRepo.EXPECT().DeleteByID(ctx, member.ID).Times(1).Return(nil, errorDelete)
 
 		memberUsecase := memberUsecase{
-			memberRepo: mockMemberRepo,
+			memberRepo:         mockMemberRepo,
+			invitationRepo:     mockInvitationRepo,
+			attendeeRepo:       mockAttendeeRepo,
+			transactionManager: initializeTransactionManagerMock(ctrl),
+			deletionPolicy:     model.CascadeDeletion,
 		}
 
 		res, err := memberUsecase.DeleteMemberByID(ctx, member.ID)
@@ -283,3 +470,152 @@ func TestDeleteMemberByID(t *testing.T) {
 		assert.EqualError(t, err, errorDelete.Error())
 	})
 }
+
+func TestRestoreMemberByIDUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	member := &model.Member{ID: 123}
+
+	t.Run("success", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockMemberRepo.EXPECT().RestoreByID(ctx, member.ID).Times(1).Return(member, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberUsecase.RestoreMemberByID(ctx, member.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, member, res)
+	})
+
+	t.Run("failed, member is not deleted", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberUsecase.RestoreMemberByID(ctx, member.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotDeleted)
+	})
+
+	t.Run("failed, member not found", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockMemberRepo.EXPECT().RestoreByID(ctx, member.ID).Times(1).Return(nil, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberUsecase.RestoreMemberByID(ctx, member.ID)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("failed, error restore", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(nil, nil)
+		mockMemberRepo.EXPECT().RestoreByID(ctx, member.ID).Times(1).Return(nil, errors.New("error"))
+
+		memberUsecase := memberUsecase{
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberUsecase.RestoreMemberByID(ctx, member.ID)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindDeletedMembersUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+	mockMemberRepo.EXPECT().FindAllDeleted(ctx).Times(1).Return([]*model.Member{{ID: 123}}, nil)
+
+	memberUsecase := memberUsecase{
+		memberRepo: mockMemberRepo,
+	}
+
+	res, err := memberUsecase.FindDeletedMembers(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
+
+func TestFindMemberStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	member := &model.Member{ID: 321}
+
+	t.Run("success", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().CountStatsByMemberID(ctx, member.ID, from, to, gomock.Any()).Times(1).
+			Return(&model.MemberStats{MemberID: member.ID, From: from, To: to, Invited: 10, Accepted: 8, Attended: 3, NoShows: 1}, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo:     mockMemberRepo,
+			invitationRepo: mockInvitationRepo,
+		}
+
+		res, err := memberUsecase.FindMemberStats(ctx, member.ID, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, 0.8, *res.AcceptanceRate)
+		assert.Equal(t, 0.75, *res.AttendanceRate)
+		assert.Equal(t, 0.25, *res.NoShowRate)
+	})
+
+	t.Run("success, nothing to rate", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(member, nil)
+		mockInvitationRepo := mock.NewMockInvitationRepository(ctrl)
+		mockInvitationRepo.EXPECT().CountStatsByMemberID(ctx, member.ID, from, to, gomock.Any()).Times(1).
+			Return(&model.MemberStats{MemberID: member.ID, From: from, To: to}, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo:     mockMemberRepo,
+			invitationRepo: mockInvitationRepo,
+		}
+
+		res, err := memberUsecase.FindMemberStats(ctx, member.ID, from, to)
+		assert.NoError(t, err)
+		assert.Nil(t, res.AcceptanceRate)
+		assert.Nil(t, res.AttendanceRate)
+		assert.Nil(t, res.NoShowRate)
+	})
+
+	t.Run("failed, invalid date range", func(t *testing.T) {
+		memberUsecase := memberUsecase{}
+
+		res, err := memberUsecase.FindMemberStats(ctx, member.ID, to, from)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvalidDateRange)
+	})
+
+	t.Run("failed, member not found", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, member.ID).Times(1).Return(nil, nil)
+
+		memberUsecase := memberUsecase{
+			memberRepo: mockMemberRepo,
+		}
+
+		res, err := memberUsecase.FindMemberStats(ctx, member.ID, from, to)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type notificationUsecase struct {
+	memberRepo    model.MemberRepository
+	gatheringRepo model.GatheringRepository
+	deliveryRepo  model.NotificationDeliveryRepository
+	notifier      model.Notifier
+}
+
+func NewNotificationUsecase(
+	memberRepo model.MemberRepository,
+	gatheringRepo model.GatheringRepository,
+	deliveryRepo model.NotificationDeliveryRepository,
+	notifier model.Notifier,
+) model.NotificationUsecase {
+	return &notificationUsecase{
+		memberRepo:    memberRepo,
+		gatheringRepo: gatheringRepo,
+		deliveryRepo:  deliveryRepo,
+		notifier:      notifier,
+	}
+}
+
+// NotifyInvitationSent emails the invited member, it is an event handler.
+// Nothing is sent when the member or the gathering was deleted in the meantime
+func (nu *notificationUsecase) NotifyInvitationSent(ctx context.Context, event *model.Event) error {
+	return nu.notifyInvitedMember(ctx, model.InvitationEmail, event)
+}
+
+// NotifyInvitationPromoted tells the waitlisted member a seat was freed for them, it is an event handler
+func (nu *notificationUsecase) NotifyInvitationPromoted(ctx context.Context, event *model.Event) error {
+	return nu.notifyInvitedMember(ctx, model.PromotionEmail, event)
+}
+
+// NotifyEmailInvitationSent emails the invitation to someone who is not a member yet, it is an event handler.
+// Nothing is sent when the gathering was deleted in the meantime
+func (nu *notificationUsecase) NotifyEmailInvitationSent(ctx context.Context, event *model.Event) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"event": event,
+	})
+
+	var invitation model.EmailInvitation
+	if err := event.DecodePayload(&invitation); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	gathering, err := nu.gatheringRepo.FindByID(ctx, invitation.GatheringID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	if gathering == nil {
+		return nil
+	}
+
+	// the recipient is not a member, its delivery is recorded for no member
+	notified, err := nu.isNotified(ctx, event.ID, 0)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	if notified {
+		return nil
+	}
+
+	err = nu.notifier.Notify(ctx, model.EmailInvitationEmail, &model.EmailData{
+		Member:    &model.Member{Email: invitation.Email},
+		Gathering: gathering,
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	nu.recordDelivery(ctx, event.ID, 0)
+	return nil
+}
+
+// notifyInvitedMember emails the member of the invitation carried by the event
+func (nu *notificationUsecase) notifyInvitedMember(ctx context.Context, template model.EmailTemplate, event *model.Event) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"template": template,
+		"event":    event,
+	})
+
+	var invitation model.Invitation
+	if err := event.DecodePayload(&invitation); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	member, err := nu.memberRepo.FindByID(ctx, invitation.MemberID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	gathering, err := nu.gatheringRepo.FindByID(ctx, invitation.GatheringID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if member == nil || gathering == nil {
+		return nil
+	}
+
+	notified, err := nu.isNotified(ctx, event.ID, member.ID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	if notified {
+		return nil
+	}
+
+	err = nu.notifier.Notify(ctx, template, &model.EmailData{
+		Member:     member,
+		Gathering:  gathering,
+		Invitation: &invitation,
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	nu.recordDelivery(ctx, event.ID, member.ID)
+	return nil
+}
+
+// isNotified tells whether the email of the event already reached the member
+func (nu *notificationUsecase) isNotified(ctx context.Context, eventID, memberID int64) (bool, error) {
+	notifiedIDs, err := nu.deliveryRepo.FindMemberIDsByEventID(ctx, eventID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, notifiedID := range notifiedIDs {
+		if notifiedID == memberID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recordDelivery records that the email of the event reached the member
+func (nu *notificationUsecase) recordDelivery(ctx context.Context, eventID, memberID int64) {
+	err := nu.deliveryRepo.Create(ctx, &model.NotificationDelivery{
+		EventID:  eventID,
+		MemberID: memberID,
+	})
+	if err != nil {
+		// the email is out already, failing the event would only send it again
+		logrus.WithFields(logrus.Fields{
+			"ctx":      ctx,
+			"eventID":  eventID,
+			"memberID": memberID,
+		}).Error(err)
+	}
+}
+
+// NotifyGatheringCancelled emails every member who was invited to the cancelled gathering, it is an event handler
+func (nu *notificationUsecase) NotifyGatheringCancelled(ctx context.Context, event *model.Event) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"event": event,
+	})
+
+	var payload model.GatheringCancelledPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nu.notifyMembers(ctx, model.CancellationEmail, event, payload.Gathering, payload.MemberIDs)
+}
+
+// NotifyReminderDue reminds every attendee that the gathering is coming up, it is an event handler
+func (nu *notificationUsecase) NotifyReminderDue(ctx context.Context, event *model.Event) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"event": event,
+	})
+
+	var payload model.GatheringReminderDuePayload
+	if err := event.DecodePayload(&payload); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nu.notifyMembers(ctx, model.ReminderEmail, event, payload.Gathering, payload.MemberIDs)
+}
+
+// notifyMembers emails the members about the gathering, a failed email doesn't stop the others and the event is
+// retried with the joined errors. Every email sent is recorded, so the retry only emails the members it missed
+func (nu *notificationUsecase) notifyMembers(ctx context.Context, template model.EmailTemplate, event *model.Event,
+	gathering *model.Gathering, memberIDs []int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"template":  template,
+		"eventID":   event.ID,
+		"memberIDs": memberIDs,
+	})
+
+	notifiedIDs, err := nu.deliveryRepo.FindMemberIDsByEventID(ctx, event.ID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	notified := make(map[int64]bool, len(notifiedIDs))
+	for _, memberID := range notifiedIDs {
+		notified[memberID] = true
+	}
+
+	pendingIDs := make([]int64, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if !notified[memberID] {
+			pendingIDs = append(pendingIDs, memberID)
+		}
+	}
+	if len(pendingIDs) == 0 {
+		return nil
+	}
+
+	members, err := nu.memberRepo.FindByIDs(ctx, pendingIDs)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	var errs []error
+	for _, member := range members {
+		err = nu.notifier.Notify(ctx, template, &model.EmailData{
+			Member:    member,
+			Gathering: gathering,
+		})
+		if err != nil {
+			logger.Error(err)
+			errs = append(errs, err)
+			continue
+		}
+
+		nu.recordDelivery(ctx, event.ID, member.ID)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyInvitationSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	member := &model.Member{ID: 1, FirstName: "John", Email: "john@doe.com"}
+	gathering := &model.Gathering{ID: 2, Name: "Board games"}
+	e, err := model.NewEvent(model.InvitationSent, &model.Invitation{ID: 3, MemberID: 1, GatheringID: 2})
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(member, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).Return(gathering, nil)
+		mockNotifier := mock.NewMockNotifier(ctrl)
+		mockNotifier.EXPECT().Notify(ctx, model.InvitationEmail, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ model.EmailTemplate, data *model.EmailData) error {
+				assert.Equal(t, member, data.Member)
+				assert.Equal(t, gathering, data.Gathering)
+				assert.Equal(t, int64(3), data.Invitation.ID)
+				return nil
+			})
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return(nil, nil)
+		mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID, MemberID: 1}).Times(1).Return(nil)
+
+		notificationUsecase := NewNotificationUsecase(mockMemberRepo, mockGatheringRepo, mockDeliveryRepo, mockNotifier)
+
+		err := notificationUsecase.NotifyInvitationSent(ctx, e)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("the same event handled twice emails once", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(2).Return(member, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(2)).Times(2).Return(gathering, nil)
+		mockNotifier := mock.NewMockNotifier(ctrl)
+		mockNotifier.EXPECT().Notify(ctx, model.InvitationEmail, gomock.Any()).Times(1).Return(nil)
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		gomock.InOrder(
+			mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return(nil, nil),
+			mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID, MemberID: 1}).Times(1).Return(nil),
+			mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return([]int64{1}, nil),
+		)
+
+		notificationUsecase := NewNotificationUsecase(mockMemberRepo, mockGatheringRepo, mockDeliveryRepo, mockNotifier)
+
+		err := notificationUsecase.NotifyInvitationSent(ctx, e)
+		assert.NoError(t, err)
+
+		err = notificationUsecase.NotifyInvitationSent(ctx, e)
+		assert.NoError(t, err)
+	})
+
+	t.Run("deleted member is not notified", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).Return(gathering, nil)
+
+		notificationUsecase := NewNotificationUsecase(mockMemberRepo, mockGatheringRepo, nil, nil)
+
+		err := notificationUsecase.NotifyInvitationSent(ctx, e)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error notify", func(t *testing.T) {
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(member, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).Return(gathering, nil)
+		mockNotifier := mock.NewMockNotifier(ctrl)
+		mockNotifier.EXPECT().Notify(ctx, model.InvitationEmail, gomock.Any()).Times(1).Return(errors.New("error"))
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return(nil, nil)
+
+		notificationUsecase := NewNotificationUsecase(mockMemberRepo, mockGatheringRepo, mockDeliveryRepo, mockNotifier)
+
+		err := notificationUsecase.NotifyInvitationSent(ctx, e)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNotifyEmailInvitationSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 2, Name: "Board games"}
+	e, err := model.NewEvent(model.EmailInvitationSent, &model.EmailInvitation{ID: 3, GatheringID: 2, Email: "john@doe.com"})
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).Return(gathering, nil)
+		mockNotifier := mock.NewMockNotifier(ctrl)
+		mockNotifier.EXPECT().Notify(ctx, model.EmailInvitationEmail, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ model.EmailTemplate, data *model.EmailData) error {
+				assert.Equal(t, "john@doe.com", data.Member.Email)
+				assert.Equal(t, gathering, data.Gathering)
+				return nil
+			})
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return(nil, nil)
+		mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID}).Times(1).Return(nil)
+
+		notificationUsecase := NewNotificationUsecase(nil, mockGatheringRepo, mockDeliveryRepo, mockNotifier)
+
+		err := notificationUsecase.NotifyEmailInvitationSent(ctx, e)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("the same event handled twice emails once", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(2)).Times(2).Return(gathering, nil)
+		mockNotifier := mock.NewMockNotifier(ctrl)
+		mockNotifier.EXPECT().Notify(ctx, model.EmailInvitationEmail, gomock.Any()).Times(1).Return(nil)
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		gomock.InOrder(
+			mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return(nil, nil),
+			mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID}).Times(1).Return(nil),
+			mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return([]int64{0}, nil),
+		)
+
+		notificationUsecase := NewNotificationUsecase(nil, mockGatheringRepo, mockDeliveryRepo, mockNotifier)
+
+		err := notificationUsecase.NotifyEmailInvitationSent(ctx, e)
+		assert.NoError(t, err)
+
+		err = notificationUsecase.NotifyEmailInvitationSent(ctx, e)
+		assert.NoError(t, err)
+	})
+
+	t.Run("deleted gathering is not notified", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).Return(nil, nil)
+
+		notificationUsecase := NewNotificationUsecase(nil, mockGatheringRepo, nil, nil)
+
+		err := notificationUsecase.NotifyEmailInvitationSent(ctx, e)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestNotifyGatheringCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 2, Name: "Board games"}
+	members := []*model.Member{{ID: 1}, {ID: 4}}
+	e, err := model.NewEvent(model.GatheringCancelled, &model.GatheringCancelledPayload{
+		Gathering: gathering,
+		MemberIDs: []int64{1, 4},
+	})
+	assert.NoError(t, err)
+
+	t.Run("every member is notified", func(t *testing.T) {
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return(nil, nil)
+		mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID, MemberID: 1}).Times(1).Return(nil)
+		mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID, MemberID: 4}).Times(1).Return(nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1, 4}).Times(1).Return(members, nil)
+		mockNotifier := mock.NewMockNotifier(ctrl)
+		mockNotifier.EXPECT().Notify(ctx, model.CancellationEmail, gomock.Any()).Times(2).Return(nil)
+
+		notificationUsecase := NewNotificationUsecase(mockMemberRepo, nil, mockDeliveryRepo, mockNotifier)
+
+		err := notificationUsecase.NotifyGatheringCancelled(ctx, e)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("a failed email does not stop the others", func(t *testing.T) {
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return(nil, nil)
+		mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID, MemberID: 4}).Times(1).Return(nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1, 4}).Times(1).Return(members, nil)
+		mockNotifier := mock.NewMockNotifier(ctrl)
+		gomock.InOrder(
+			mockNotifier.EXPECT().Notify(ctx, model.CancellationEmail, gomock.Any()).Times(1).Return(errors.New("error")),
+			mockNotifier.EXPECT().Notify(ctx, model.CancellationEmail, gomock.Any()).Times(1).Return(nil),
+		)
+
+		notificationUsecase := NewNotificationUsecase(mockMemberRepo, nil, mockDeliveryRepo, mockNotifier)
+
+		err := notificationUsecase.NotifyGatheringCancelled(ctx, e)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("a retry only emails the members it missed", func(t *testing.T) {
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return([]int64{4}, nil)
+		mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID, MemberID: 1}).Times(1).Return(nil)
+		mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+		mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1}).Times(1).Return([]*model.Member{{ID: 1}}, nil)
+		mockNotifier := mock.NewMockNotifier(ctrl)
+		mockNotifier.EXPECT().Notify(ctx, model.CancellationEmail, gomock.Any()).Times(1).Return(nil)
+
+		notificationUsecase := NewNotificationUsecase(mockMemberRepo, nil, mockDeliveryRepo, mockNotifier)
+
+		err := notificationUsecase.NotifyGatheringCancelled(ctx, e)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("every member was already emailed", func(t *testing.T) {
+		mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return([]int64{1, 4}, nil)
+
+		notificationUsecase := NewNotificationUsecase(nil, nil, mockDeliveryRepo, nil)
+
+		err := notificationUsecase.NotifyGatheringCancelled(ctx, e)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestNotifyReminderDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	gathering := &model.Gathering{ID: 2, Name: "Board games"}
+	e, err := model.NewEvent(model.GatheringReminderDue, &model.GatheringReminderDuePayload{
+		Gathering:     gathering,
+		OffsetMinutes: 60,
+		MemberIDs:     []int64{1},
+	})
+	assert.NoError(t, err)
+
+	mockDeliveryRepo := mock.NewMockNotificationDeliveryRepository(ctrl)
+	mockDeliveryRepo.EXPECT().FindMemberIDsByEventID(ctx, e.ID).Times(1).Return(nil, nil)
+	mockDeliveryRepo.EXPECT().Create(ctx, &model.NotificationDelivery{EventID: e.ID, MemberID: 1}).Times(1).Return(nil)
+	mockMemberRepo := mock.NewMockMemberRepository(ctrl)
+	mockMemberRepo.EXPECT().FindByIDs(ctx, []int64{1}).Times(1).Return([]*model.Member{{ID: 1}}, nil)
+	mockNotifier := mock.NewMockNotifier(ctrl)
+	mockNotifier.EXPECT().Notify(ctx, model.ReminderEmail, gomock.Any()).Times(1).Return(nil)
+
+	notificationUsecase := NewNotificationUsecase(mockMemberRepo, nil, mockDeliveryRepo, mockNotifier)
+
+	err = notificationUsecase.NotifyReminderDue(ctx, e)
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type occurrenceUsecase struct {
+	gatheringRepo  model.GatheringRepository
+	occurrenceRepo model.GatheringOccurrenceRepository
+}
+
+func NewOccurrenceUsecase(
+	gatheringRepo model.GatheringRepository,
+	occurrenceRepo model.GatheringOccurrenceRepository,
+) model.OccurrenceUsecase {
+	return &occurrenceUsecase{
+		gatheringRepo:  gatheringRepo,
+		occurrenceRepo: occurrenceRepo,
+	}
+}
+
+// FindOccurrences lists the occurrences of the gathering starting between from and to, a gathering that does not
+// recur has its single occurrence listed when it starts in the window
+func (ou *occurrenceUsecase) FindOccurrences(ctx context.Context, gatheringID int64, from, to time.Time, limit int) ([]*model.Occurrence, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+		"from":        from,
+		"to":          to,
+		"limit":       limit,
+	})
+
+	switch {
+	case limit <= 0:
+		limit = model.DefaultOccurrencesLimit
+	case limit > model.MaxOccurrencesLimit:
+		limit = model.MaxOccurrencesLimit
+	}
+	if to.Before(from) {
+		return nil, ErrInvalidOccurrence
+	}
+
+	gathering, err := ou.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	var overrides []*model.GatheringOccurrenceOverride
+	if gathering.IsRecurring() {
+		overrides, err = ou.occurrenceRepo.FindByGatheringID(ctx, gatheringID)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	res, err := gathering.Occurrences(from, to, limit, overrides)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RescheduleOccurrence moves one occurrence of a recurring gathering, the other occurrences keep their schedule
+func (ou *occurrenceUsecase) RescheduleOccurrence(ctx context.Context, override *model.GatheringOccurrenceOverride) (*model.Occurrence, error) {
+	if override.ScheduledAt == nil {
+		return nil, ErrInvalidGatheringSchedule
+	}
+	if override.EndsAt != nil && !override.EndsAt.After(*override.ScheduledAt) {
+		return nil, ErrInvalidGatheringSchedule
+	}
+
+	scheduledAt := override.ScheduledAt.UTC()
+	override.ScheduledAt = &scheduledAt
+	if override.EndsAt != nil {
+		endsAt := override.EndsAt.UTC()
+		override.EndsAt = &endsAt
+	}
+	override.Cancelled = false
+
+	return ou.saveOverride(ctx, override)
+}
+
+// CancelOccurrence cancels one occurrence of a recurring gathering, the other occurrences still take place
+func (ou *occurrenceUsecase) CancelOccurrence(ctx context.Context, gatheringID int64, occurrenceAt time.Time) (*model.Occurrence, error) {
+	return ou.saveOverride(ctx, &model.GatheringOccurrenceOverride{
+		GatheringID:  gatheringID,
+		OccurrenceAt: occurrenceAt,
+		Cancelled:    true,
+	})
+}
+
+// ResetOccurrence drops the override of the occurrence, it follows the gathering schedule again
+func (ou *occurrenceUsecase) ResetOccurrence(ctx context.Context, gatheringID int64, occurrenceAt time.Time) (*model.Occurrence, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"gatheringID":  gatheringID,
+		"occurrenceAt": occurrenceAt,
+	})
+
+	gathering, err := ou.findRecurringGathering(ctx, gatheringID, occurrenceAt)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrenceAt = occurrenceAt.UTC()
+	if err = ou.occurrenceRepo.DeleteByGatheringIDAndOccurrenceAt(ctx, gatheringID, occurrenceAt); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return gathering.Occurrence(occurrenceAt, nil), nil
+}
+
+func (ou *occurrenceUsecase) saveOverride(ctx context.Context, override *model.GatheringOccurrenceOverride) (*model.Occurrence, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"override": override,
+	})
+
+	gathering, err := ou.findRecurringGathering(ctx, override.GatheringID, override.OccurrenceAt)
+	if err != nil {
+		return nil, err
+	}
+
+	override.ID = model.GenerateID()
+	override.OccurrenceAt = override.OccurrenceAt.UTC()
+	if err = ou.occurrenceRepo.Save(ctx, override); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return gathering.Occurrence(override.OccurrenceAt, []*model.GatheringOccurrenceOverride{override}), nil
+}
+
+// findRecurringGathering finds the gathering the occurrence belongs to, the occurrence has to be one its rule gives
+func (ou *occurrenceUsecase) findRecurringGathering(ctx context.Context, gatheringID int64, occurrenceAt time.Time) (*model.Gathering, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gathering, err := ou.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	case !gathering.HasOccurrence(occurrenceAt):
+		return nil, ErrInvalidOccurrence
+	}
+
+	return gathering, nil
+}
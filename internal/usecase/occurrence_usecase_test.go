@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindOccurrences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	t.Run("weekly gathering with overrides", func(t *testing.T) {
+		// Mondays at 19:00 WIB
+		scheduledAt := time.Date(2030, 1, 7, 12, 0, 0, 0, time.UTC)
+		endsAt := scheduledAt.Add(2 * time.Hour)
+		gathering := &model.Gathering{
+			ID:          1,
+			ScheduledAt: &scheduledAt,
+			EndsAt:      &endsAt,
+			TimeZone:    "Asia/Jakarta",
+			Recurrence:  "FREQ=WEEKLY;BYDAY=MO",
+		}
+		secondAt := scheduledAt.AddDate(0, 0, 7)
+		thirdAt := scheduledAt.AddDate(0, 0, 14)
+		movedAt := thirdAt.Add(-time.Hour)
+		overrides := []*model.GatheringOccurrenceOverride{
+			{GatheringID: 1, OccurrenceAt: secondAt, Cancelled: true},
+			{GatheringID: 1, OccurrenceAt: thirdAt, ScheduledAt: &movedAt},
+		}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).Return(overrides, nil)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, mockOccurrenceRepo)
+
+		res, err := occurrenceUsecase.FindOccurrences(ctx, 1, scheduledAt, scheduledAt.AddDate(0, 1, 0), 0)
+		assert.NoError(t, err)
+		if assert.Len(t, res, 5) {
+			assert.Equal(t, jakarta.String(), res[0].ScheduledAt.Location().String())
+			assert.Equal(t, 19, res[0].ScheduledAt.Hour())
+			assert.True(t, res[0].EndsAt.Equal(endsAt))
+
+			assert.True(t, res[1].Cancelled)
+			assert.True(t, res[1].OccurrenceAt.Equal(secondAt))
+
+			assert.True(t, res[2].Overridden)
+			assert.True(t, res[2].OccurrenceAt.Equal(thirdAt))
+			assert.True(t, res[2].ScheduledAt.Equal(movedAt))
+			// the rescheduled occurrence keeps the gathering duration
+			assert.True(t, res[2].EndsAt.Equal(movedAt.Add(2*time.Hour)))
+		}
+	})
+
+	t.Run("occurrences keep their local time across daylight saving", func(t *testing.T) {
+		// 18:00 in New York, EST then EDT from March 10th 2030
+		scheduledAt := time.Date(2030, 3, 4, 23, 0, 0, 0, time.UTC)
+		gathering := &model.Gathering{
+			ID:          1,
+			ScheduledAt: &scheduledAt,
+			TimeZone:    "America/New_York",
+			Recurrence:  "FREQ=WEEKLY;COUNT=2",
+		}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, mockOccurrenceRepo)
+
+		res, err := occurrenceUsecase.FindOccurrences(ctx, 1, scheduledAt, scheduledAt.AddDate(1, 0, 0), 10)
+		assert.NoError(t, err)
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, 18, res[1].ScheduledAt.In(newYork).Hour())
+			assert.Equal(t, 22, res[1].ScheduledAt.UTC().Hour())
+		}
+	})
+
+	t.Run("limit caps the occurrences of a never ending gathering", func(t *testing.T) {
+		scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt, Recurrence: "FREQ=DAILY"}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, mockOccurrenceRepo)
+
+		res, err := occurrenceUsecase.FindOccurrences(ctx, 1, scheduledAt, scheduledAt.AddDate(10, 0, 0), 1000)
+		assert.NoError(t, err)
+		assert.Len(t, res, model.MaxOccurrencesLimit)
+	})
+
+	t.Run("gathering that does not recur", func(t *testing.T) {
+		scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, nil)
+
+		res, err := occurrenceUsecase.FindOccurrences(ctx, 1, scheduledAt.Add(-time.Hour), scheduledAt.Add(time.Hour), 0)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, nil)
+
+		_, err := occurrenceUsecase.FindOccurrences(ctx, 1, time.Now(), time.Now().Add(time.Hour), 0)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestRescheduleOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt, Recurrence: "FREQ=DAILY;COUNT=5"}
+	occurrenceAt := scheduledAt.AddDate(0, 0, 2)
+	movedAt := occurrenceAt.Add(3 * time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().Save(ctx, gomock.Any()).Times(1).Return(nil)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, mockOccurrenceRepo)
+
+		res, err := occurrenceUsecase.RescheduleOccurrence(ctx, &model.GatheringOccurrenceOverride{
+			GatheringID:  1,
+			OccurrenceAt: occurrenceAt,
+			ScheduledAt:  &movedAt,
+		})
+		assert.NoError(t, err)
+		assert.True(t, res.Overridden)
+		assert.True(t, res.OccurrenceAt.Equal(occurrenceAt))
+		assert.True(t, res.ScheduledAt.Equal(movedAt))
+	})
+
+	t.Run("not an occurrence of the gathering", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, mockOccurrenceRepo)
+
+		_, err := occurrenceUsecase.RescheduleOccurrence(ctx, &model.GatheringOccurrenceOverride{
+			GatheringID:  1,
+			OccurrenceAt: occurrenceAt.Add(time.Minute),
+			ScheduledAt:  &movedAt,
+		})
+		assert.ErrorIs(t, err, ErrInvalidOccurrence)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		endsAt := movedAt.Add(-time.Hour)
+
+		occurrenceUsecase := NewOccurrenceUsecase(nil, nil)
+
+		_, err := occurrenceUsecase.RescheduleOccurrence(ctx, &model.GatheringOccurrenceOverride{
+			GatheringID:  1,
+			OccurrenceAt: occurrenceAt,
+			ScheduledAt:  &movedAt,
+			EndsAt:       &endsAt,
+		})
+		assert.ErrorIs(t, err, ErrInvalidGatheringSchedule)
+	})
+}
+
+func TestCancelOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	occurrenceAt := scheduledAt.AddDate(0, 0, 1)
+
+	t.Run("success", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt, Recurrence: "FREQ=DAILY"}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().Save(ctx, gomock.Any()).Times(1).Return(nil)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, mockOccurrenceRepo)
+
+		res, err := occurrenceUsecase.CancelOccurrence(ctx, 1, occurrenceAt)
+		assert.NoError(t, err)
+		assert.True(t, res.Cancelled)
+	})
+
+	t.Run("gathering does not recur", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, nil)
+
+		_, err := occurrenceUsecase.CancelOccurrence(ctx, 1, scheduledAt)
+		assert.ErrorIs(t, err, ErrInvalidOccurrence)
+	})
+
+	t.Run("error save override", func(t *testing.T) {
+		gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt, Recurrence: "FREQ=DAILY"}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo.EXPECT().Save(ctx, gomock.Any()).Times(1).Return(errors.New("error"))
+
+		occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, mockOccurrenceRepo)
+
+		_, err := occurrenceUsecase.CancelOccurrence(ctx, 1, occurrenceAt)
+		assert.Error(t, err)
+	})
+}
+
+func TestResetOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	scheduledAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt, Recurrence: "FREQ=DAILY"}
+	occurrenceAt := scheduledAt.AddDate(0, 0, 1)
+
+	mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+	mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+	mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+	mockOccurrenceRepo.EXPECT().DeleteByGatheringIDAndOccurrenceAt(ctx, int64(1), occurrenceAt).Times(1).Return(nil)
+
+	occurrenceUsecase := NewOccurrenceUsecase(mockGatheringRepo, mockOccurrenceRepo)
+
+	res, err := occurrenceUsecase.ResetOccurrence(ctx, 1, occurrenceAt)
+	assert.NoError(t, err)
+	assert.False(t, res.Overridden)
+	assert.True(t, res.ScheduledAt.Equal(occurrenceAt))
+}
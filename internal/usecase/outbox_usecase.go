@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type outboxUsecase struct {
+	outboxRepo model.OutboxRepository
+}
+
+func NewOutboxUsecase(outboxRepo model.OutboxRepository) model.OutboxUsecase {
+	return &outboxUsecase{
+		outboxRepo: outboxRepo,
+	}
+}
+
+// FindDeadLetteredMessages lists the latest outbox messages that ran out of attempts
+func (ou *outboxUsecase) FindDeadLetteredMessages(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"limit": limit,
+	})
+
+	switch {
+	case limit <= 0:
+		limit = model.DefaultDeadLetteredLimit
+	case limit > model.MaxDeadLetteredLimit:
+		limit = model.MaxDeadLetteredLimit
+	}
+
+	res, err := ou.outboxRepo.FindDeadLettered(ctx, limit)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReplayMessageByID relays a dead-lettered message again, with a fresh set of attempts
+func (ou *outboxUsecase) ReplayMessageByID(ctx context.Context, messageID int64) (*model.OutboxMessage, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       ctx,
+		"messageID": messageID,
+	})
+
+	message, err := ou.outboxRepo.FindByID(ctx, messageID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case message == nil:
+		return nil, ErrRecordNotFound
+	case message.DeadLetteredAt == nil:
+		return nil, ErrOutboxMessageNotDeadLettered
+	}
+
+	now := time.Now()
+	if err = ou.outboxRepo.Replay(ctx, messageID, now); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	message.Attempts = 0
+	message.NextAttemptAt = now
+	message.DeadLetteredAt = nil
+
+	return message, nil
+}
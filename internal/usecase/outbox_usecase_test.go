@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDeadLetteredMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("default limit", func(t *testing.T) {
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().FindDeadLettered(ctx, model.DefaultDeadLetteredLimit).Times(1).
+			Return([]*model.OutboxMessage{{ID: 1}}, nil)
+
+		outboxUsecase := NewOutboxUsecase(mockOutboxRepo)
+
+		res, err := outboxUsecase.FindDeadLetteredMessages(ctx, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().FindDeadLettered(ctx, model.MaxDeadLetteredLimit).Times(1).Return(nil, nil)
+
+		outboxUsecase := NewOutboxUsecase(mockOutboxRepo)
+
+		_, err := outboxUsecase.FindDeadLetteredMessages(ctx, 10000)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error find dead-lettered", func(t *testing.T) {
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().FindDeadLettered(ctx, gomock.Any()).Times(1).Return(nil, errors.New("error"))
+
+		outboxUsecase := NewOutboxUsecase(mockOutboxRepo)
+
+		_, err := outboxUsecase.FindDeadLetteredMessages(ctx, 10)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReplayMessageByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		deadLetteredAt := time.Now().Add(-time.Hour)
+
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).
+			Return(&model.OutboxMessage{ID: 1, Attempts: 10, DeadLetteredAt: &deadLetteredAt}, nil)
+		mockOutboxRepo.EXPECT().Replay(ctx, int64(1), gomock.Any()).Times(1).Return(nil)
+
+		outboxUsecase := NewOutboxUsecase(mockOutboxRepo)
+
+		res, err := outboxUsecase.ReplayMessageByID(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, res.Attempts)
+		assert.Nil(t, res.DeadLetteredAt)
+	})
+
+	t.Run("failed, not dead-lettered", func(t *testing.T) {
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(&model.OutboxMessage{ID: 1}, nil)
+
+		outboxUsecase := NewOutboxUsecase(mockOutboxRepo)
+
+		res, err := outboxUsecase.ReplayMessageByID(ctx, 1)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrOutboxMessageNotDeadLettered)
+	})
+
+	t.Run("failed, not found", func(t *testing.T) {
+		mockOutboxRepo := mock.NewMockOutboxRepository(ctrl)
+		mockOutboxRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		outboxUsecase := NewOutboxUsecase(mockOutboxRepo)
+
+		res, err := outboxUsecase.ReplayMessageByID(ctx, 1)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxRemindersPerGathering how many reminders a gathering can have
+	maxRemindersPerGathering = 10
+	// reminderDispatchBatchSize how many due reminders are dispatched in one transaction
+	reminderDispatchBatchSize = 100
+)
+
+type reminderUsecase struct {
+	reminderRepo       model.GatheringReminderRepository
+	gatheringRepo      model.GatheringRepository
+	attendeeRepo       model.AttendeeRepository
+	occurrenceRepo     model.GatheringOccurrenceRepository
+	transactionManager model.TransactionManager
+	eventPublisher     model.EventPublisher
+}
+
+func NewReminderUsecase(
+	reminderRepo model.GatheringReminderRepository,
+	gatheringRepo model.GatheringRepository,
+	attendeeRepo model.AttendeeRepository,
+	occurrenceRepo model.GatheringOccurrenceRepository,
+	transactionManager model.TransactionManager,
+	eventPublisher model.EventPublisher,
+) model.ReminderUsecase {
+	return &reminderUsecase{
+		reminderRepo:       reminderRepo,
+		gatheringRepo:      gatheringRepo,
+		attendeeRepo:       attendeeRepo,
+		occurrenceRepo:     occurrenceRepo,
+		transactionManager: transactionManager,
+		eventPublisher:     eventPublisher,
+	}
+}
+
+func (ru *reminderUsecase) FindRemindersByGatheringID(ctx context.Context, gatheringID int64) ([]*model.GatheringReminder, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gathering, err := ru.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	res, err := ru.reminderRepo.FindByGatheringID(ctx, gatheringID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateRemindersByGatheringID replaces the reminder schedule of the gathering,
+// the reminders whose offset is kept keep whether they were already sent
+func (ru *reminderUsecase) UpdateRemindersByGatheringID(ctx context.Context, gatheringID int64, offsetMinutes []int) ([]*model.GatheringReminder, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":           ctx,
+		"gatheringID":   gatheringID,
+		"offsetMinutes": offsetMinutes,
+	})
+
+	if len(offsetMinutes) > maxRemindersPerGathering {
+		return nil, ErrInvalidReminderOffset
+	}
+	seen := make(map[int]bool, len(offsetMinutes))
+	for _, offset := range offsetMinutes {
+		if offset <= 0 || offset > model.MaxReminderOffsetMinutes || seen[offset] {
+			return nil, ErrInvalidReminderOffset
+		}
+		seen[offset] = true
+	}
+
+	gathering, err := ru.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	var res []*model.GatheringReminder
+	err = ru.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		existing, err := ru.reminderRepo.FindByGatheringID(ctx, gatheringID)
+		if err != nil {
+			return err
+		}
+
+		existingByOffset := make(map[int]*model.GatheringReminder, len(existing))
+		for _, reminder := range existing {
+			existingByOffset[reminder.OffsetMinutes] = reminder
+		}
+
+		now := time.Now()
+		res = make([]*model.GatheringReminder, 0, len(offsetMinutes))
+		for _, offset := range offsetMinutes {
+			reminder, ok := existingByOffset[offset]
+			if !ok {
+				reminder = model.NewGatheringReminder(gathering, offset, now)
+			}
+			res = append(res, reminder)
+		}
+
+		return ru.reminderRepo.Save(ctx, gatheringID, res)
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DispatchDue publishes a gathering.reminder_due event for every due reminder and returns how many were published.
+// The reminders are locked, published and marked as sent in one transaction, so each one is dispatched exactly once
+// even when several schedulers run side by side. The reminder of a recurring gathering is rescheduled for its next
+// occurrence instead of being marked as sent
+func (ru *reminderUsecase) DispatchDue(ctx context.Context) (int, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	var dispatched int
+	err := ru.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		reminders, err := ru.reminderRepo.LockDue(ctx, now, reminderDispatchBatchSize)
+		if err != nil {
+			return err
+		}
+
+		reminderIDs := make([]int64, 0, len(reminders))
+		for _, reminder := range reminders {
+			remindAt, published, err := ru.dispatch(ctx, reminder, now)
+			if err != nil {
+				return err
+			}
+			if published {
+				dispatched++
+			}
+			if remindAt != nil {
+				if err = ru.reminderRepo.Reschedule(ctx, reminder.ID, *remindAt); err != nil {
+					return err
+				}
+				continue
+			}
+			reminderIDs = append(reminderIDs, reminder.ID)
+		}
+
+		return ru.reminderRepo.MarkSent(ctx, reminderIDs, now)
+	})
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return dispatched, nil
+}
+
+// dispatch publishes the reminder to the attendees of the occurrence it is due for, the first occurrence that is not
+// cancelled and starts after both now and the occurrence the reminder was scheduled for. It returns when the reminder
+// is due again, for the occurrence after that one, or nil once the gathering has no occurrence left, and whether an
+// event was published
+func (ru *reminderUsecase) dispatch(ctx context.Context, reminder *model.GatheringReminder, now time.Time) (*time.Time, bool, error) {
+	gathering, err := ru.gatheringRepo.FindByID(ctx, reminder.GatheringID)
+	if err != nil {
+		return nil, false, err
+	}
+	if gathering == nil || gathering.ScheduledAt == nil {
+		return nil, false, nil
+	}
+
+	overrides, err := ru.occurrenceRepo.FindByGatheringID(ctx, gathering.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	offset := time.Duration(reminder.OffsetMinutes) * time.Minute
+	after := now
+	if reminder.RemindAt != nil && reminder.RemindAt.Add(offset).After(now) {
+		after = reminder.RemindAt.Add(offset - time.Nanosecond)
+	}
+
+	occurrence, err := gathering.NextOccurrence(after, overrides)
+	if err != nil || occurrence == nil {
+		return nil, false, err
+	}
+	// the occurrence the reminder was scheduled for was cancelled or moved, the next one is not due yet
+	if remindAt := occurrence.ScheduledAt.Add(-offset); remindAt.After(now) {
+		return &remindAt, false, nil
+	}
+
+	attendees, err := ru.attendeeRepo.FindByGatheringID(ctx, reminder.GatheringID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	memberIDs := make([]int64, 0, len(attendees))
+	for _, attendee := range attendees {
+		if attendee.OccurrenceAt == nil || attendee.OccurrenceAt.Equal(occurrence.OccurrenceAt) {
+			memberIDs = append(memberIDs, attendee.MemberID)
+		}
+	}
+
+	published := len(memberIDs) > 0
+	if published {
+		occurrenceGathering := *gathering
+		occurrenceGathering.ScheduledAt = &occurrence.ScheduledAt
+		occurrenceGathering.EndsAt = occurrence.EndsAt
+
+		payload := &model.GatheringReminderDuePayload{
+			Gathering:     &occurrenceGathering,
+			OffsetMinutes: reminder.OffsetMinutes,
+			MemberIDs:     memberIDs,
+		}
+		if gathering.IsRecurring() {
+			payload.OccurrenceAt = &occurrence.OccurrenceAt
+		}
+		if err = publishEvent(ctx, ru.eventPublisher, model.GatheringReminderDue, payload); err != nil {
+			return nil, false, err
+		}
+	}
+
+	next, err := gathering.NextOccurrence(occurrence.ScheduledAt, overrides)
+	if err != nil || next == nil {
+		return nil, published, err
+	}
+	remindAt := next.ScheduledAt.Add(-offset)
+	return &remindAt, published, nil
+}
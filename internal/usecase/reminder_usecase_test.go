@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/event"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateRemindersByGatheringID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	scheduledAt := time.Now().Add(48 * time.Hour)
+	gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt}
+
+	t.Run("kept offsets keep their reminder", func(t *testing.T) {
+		sentAt := time.Now()
+		kept := &model.GatheringReminder{ID: 10, GatheringID: 1, OffsetMinutes: 60, SentAt: &sentAt}
+		dropped := &model.GatheringReminder{ID: 11, GatheringID: 1, OffsetMinutes: 1440}
+
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockReminderRepo := mock.NewMockGatheringReminderRepository(ctrl)
+		mockReminderRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).
+			Return([]*model.GatheringReminder{dropped, kept}, nil)
+		mockReminderRepo.EXPECT().Save(ctx, int64(1), gomock.Any()).Times(1).Return(nil)
+
+		reminderUsecase := NewReminderUsecase(mockReminderRepo, mockGatheringRepo, nil, nil, initializeTransactionManagerMock(ctrl), nil)
+
+		res, err := reminderUsecase.UpdateRemindersByGatheringID(ctx, 1, []int{60, 30})
+
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, kept, res[0])
+		assert.Equal(t, 30, res[1].OffsetMinutes)
+		assert.True(t, scheduledAt.Add(-30*time.Minute).Equal(*res[1].RemindAt))
+		assert.Nil(t, res[1].SentAt)
+	})
+
+	t.Run("invalid offsets", func(t *testing.T) {
+		reminderUsecase := NewReminderUsecase(nil, nil, nil, nil, nil, nil)
+
+		for _, offsets := range [][]int{
+			{0},
+			{60, 60},
+			{model.MaxReminderOffsetMinutes + 1},
+			{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		} {
+			_, err := reminderUsecase.UpdateRemindersByGatheringID(ctx, 1, offsets)
+			assert.ErrorIs(t, err, ErrInvalidReminderOffset)
+		}
+	})
+
+	t.Run("gathering not found", func(t *testing.T) {
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+
+		reminderUsecase := NewReminderUsecase(nil, mockGatheringRepo, nil, nil, nil, nil)
+
+		_, err := reminderUsecase.UpdateRemindersByGatheringID(ctx, 1, []int{60})
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestDispatchDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	scheduledAt := time.Now().Add(time.Hour)
+	gathering := &model.Gathering{ID: 1, ScheduledAt: &scheduledAt}
+	reminder := &model.GatheringReminder{ID: 10, GatheringID: 1, OffsetMinutes: 60}
+
+	t.Run("publishes to the attendees and marks sent", func(t *testing.T) {
+		mockReminderRepo := mock.NewMockGatheringReminderRepository(ctrl)
+		mockReminderRepo.EXPECT().LockDue(ctx, gomock.Any(), reminderDispatchBatchSize).Times(1).
+			Return([]*model.GatheringReminder{reminder}, nil)
+		mockReminderRepo.EXPECT().MarkSent(ctx, []int64{10}, gomock.Any()).Times(1).Return(nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockOccurrenceRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).Return(nil, nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).
+			Return([]*model.Attendee{{MemberID: 2, GatheringID: 1}, {MemberID: 3, GatheringID: 1}}, nil)
+		eventRecorder := event.NewRecorder()
+
+		reminderUsecase := NewReminderUsecase(mockReminderRepo, mockGatheringRepo, mockAttendeeRepo, mockOccurrenceRepo,
+			initializeTransactionManagerMock(ctrl), eventRecorder)
+
+		dispatched, err := reminderUsecase.DispatchDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		events := eventRecorder.Events()
+		assert.Len(t, events, 1)
+		var payload model.GatheringReminderDuePayload
+		assert.NoError(t, events[0].DecodePayload(&payload))
+		assert.Equal(t, []int64{2, 3}, payload.MemberIDs)
+		assert.Equal(t, 60, payload.OffsetMinutes)
+	})
+
+	t.Run("recurring gathering reminds the attendees of the next occurrence and is rescheduled", func(t *testing.T) {
+		firstAt := time.Now().Add(-24 * time.Hour).Add(30 * time.Minute).Truncate(time.Second)
+		secondAt := firstAt.Add(24 * time.Hour)
+		thirdAt := secondAt.Add(24 * time.Hour)
+		fourthAt := thirdAt.Add(24 * time.Hour)
+		recurring := &model.Gathering{ID: 1, ScheduledAt: &firstAt, Recurrence: "FREQ=DAILY", TimeZone: "UTC"}
+		// the reminder of the second occurrence is due, the third occurrence is cancelled
+		remindAt := secondAt.Add(-time.Hour)
+		reminder := &model.GatheringReminder{ID: 10, GatheringID: 1, OffsetMinutes: 60, RemindAt: &remindAt}
+		overrides := []*model.GatheringOccurrenceOverride{{GatheringID: 1, OccurrenceAt: thirdAt, Cancelled: true}}
+
+		mockReminderRepo := mock.NewMockGatheringReminderRepository(ctrl)
+		mockReminderRepo.EXPECT().LockDue(ctx, gomock.Any(), reminderDispatchBatchSize).Times(1).
+			Return([]*model.GatheringReminder{reminder}, nil)
+		var rescheduledAt time.Time
+		mockReminderRepo.EXPECT().Reschedule(ctx, int64(10), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ int64, remindAt time.Time) error {
+				rescheduledAt = remindAt
+				return nil
+			})
+		mockReminderRepo.EXPECT().MarkSent(ctx, []int64{}, gomock.Any()).Times(1).Return(nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(recurring, nil)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockOccurrenceRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).Return(overrides, nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).
+			Return([]*model.Attendee{
+				{MemberID: 2, GatheringID: 1},
+				{MemberID: 3, GatheringID: 1, OccurrenceAt: &secondAt},
+				{MemberID: 4, GatheringID: 1, OccurrenceAt: &fourthAt},
+			}, nil)
+		eventRecorder := event.NewRecorder()
+
+		reminderUsecase := NewReminderUsecase(mockReminderRepo, mockGatheringRepo, mockAttendeeRepo, mockOccurrenceRepo,
+			initializeTransactionManagerMock(ctrl), eventRecorder)
+
+		dispatched, err := reminderUsecase.DispatchDue(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		events := eventRecorder.Events()
+		assert.Len(t, events, 1)
+		var payload model.GatheringReminderDuePayload
+		assert.NoError(t, events[0].DecodePayload(&payload))
+		assert.Equal(t, []int64{2, 3}, payload.MemberIDs)
+		assert.True(t, secondAt.Equal(*payload.OccurrenceAt))
+		assert.True(t, secondAt.Equal(*payload.Gathering.ScheduledAt))
+		assert.True(t, fourthAt.Add(-time.Hour).Equal(rescheduledAt))
+	})
+
+	t.Run("deleted gathering is marked sent without publishing", func(t *testing.T) {
+		mockReminderRepo := mock.NewMockGatheringReminderRepository(ctrl)
+		mockReminderRepo.EXPECT().LockDue(ctx, gomock.Any(), reminderDispatchBatchSize).Times(1).
+			Return([]*model.GatheringReminder{reminder}, nil)
+		mockReminderRepo.EXPECT().MarkSent(ctx, []int64{10}, gomock.Any()).Times(1).Return(nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+		eventRecorder := event.NewRecorder()
+
+		reminderUsecase := NewReminderUsecase(mockReminderRepo, mockGatheringRepo, nil, nil,
+			initializeTransactionManagerMock(ctrl), eventRecorder)
+
+		dispatched, err := reminderUsecase.DispatchDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, dispatched)
+		assert.Empty(t, eventRecorder.Events())
+	})
+
+	t.Run("reminder of a moved occurrence is rescheduled without counting", func(t *testing.T) {
+		firstAt := time.Now().Add(-24 * time.Hour).Add(30 * time.Minute).Truncate(time.Second)
+		secondAt := firstAt.Add(24 * time.Hour)
+		movedAt := secondAt.Add(3 * time.Hour)
+		recurring := &model.Gathering{ID: 1, ScheduledAt: &firstAt, Recurrence: "FREQ=DAILY", TimeZone: "UTC"}
+		// the second occurrence was moved after its reminder fell due, the reminder is not due anymore
+		remindAt := secondAt.Add(-time.Hour)
+		reminder := &model.GatheringReminder{ID: 10, GatheringID: 1, OffsetMinutes: 60, RemindAt: &remindAt}
+		overrides := []*model.GatheringOccurrenceOverride{{GatheringID: 1, OccurrenceAt: secondAt, ScheduledAt: &movedAt}}
+
+		mockReminderRepo := mock.NewMockGatheringReminderRepository(ctrl)
+		mockReminderRepo.EXPECT().LockDue(ctx, gomock.Any(), reminderDispatchBatchSize).Times(1).
+			Return([]*model.GatheringReminder{reminder}, nil)
+		var rescheduledAt time.Time
+		mockReminderRepo.EXPECT().Reschedule(ctx, int64(10), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ int64, remindAt time.Time) error {
+				rescheduledAt = remindAt
+				return nil
+			})
+		mockReminderRepo.EXPECT().MarkSent(ctx, []int64{}, gomock.Any()).Times(1).Return(nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(recurring, nil)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockOccurrenceRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).Return(overrides, nil)
+		eventRecorder := event.NewRecorder()
+
+		reminderUsecase := NewReminderUsecase(mockReminderRepo, mockGatheringRepo, nil, mockOccurrenceRepo,
+			initializeTransactionManagerMock(ctrl), eventRecorder)
+
+		dispatched, err := reminderUsecase.DispatchDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, dispatched)
+		assert.Empty(t, eventRecorder.Events())
+		assert.True(t, movedAt.Add(-time.Hour).Equal(rescheduledAt))
+	})
+
+	t.Run("failed publish is not marked sent", func(t *testing.T) {
+		mockReminderRepo := mock.NewMockGatheringReminderRepository(ctrl)
+		mockReminderRepo.EXPECT().LockDue(ctx, gomock.Any(), reminderDispatchBatchSize).Times(1).
+			Return([]*model.GatheringReminder{reminder}, nil)
+		mockGatheringRepo := mock.NewMockGatheringRepository(ctrl)
+		mockGatheringRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(gathering, nil)
+		mockOccurrenceRepo := mock.NewMockGatheringOccurrenceRepository(ctrl)
+		mockOccurrenceRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).Return(nil, nil)
+		mockAttendeeRepo := mock.NewMockAttendeeRepository(ctrl)
+		mockAttendeeRepo.EXPECT().FindByGatheringID(ctx, int64(1)).Times(1).
+			Return([]*model.Attendee{{MemberID: 2, GatheringID: 1}}, nil)
+		mockEventPublisher := mock.NewMockEventPublisher(ctrl)
+		mockEventPublisher.EXPECT().Publish(ctx, gomock.Any()).Times(1).Return(errors.New("error"))
+
+		reminderUsecase := NewReminderUsecase(mockReminderRepo, mockGatheringRepo, mockAttendeeRepo, mockOccurrenceRepo,
+			initializeTransactionManagerMock(ctrl), mockEventPublisher)
+
+		dispatched, err := reminderUsecase.DispatchDue(ctx)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, dispatched)
+	})
+}
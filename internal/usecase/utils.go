@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+// uniqueIDs removes duplicated IDs while keeping their first occurrence order
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	res := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		res = append(res, id)
+	}
+	return res
+}
+
+// publishEvent publishes an event about a change, it is called inside the change transaction so the event is only
+// recorded when the change is committed
+func publishEvent(ctx context.Context, publisher model.EventPublisher, eventType model.EventType, payload interface{}) error {
+	if publisher == nil {
+		return nil
+	}
+
+	event, err := model.NewEvent(eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	return publisher.Publish(ctx, event)
+}
+
+// sameTime reports whether both optional times are unset or point to the same instant
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
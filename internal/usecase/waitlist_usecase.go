@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type waitlistUsecase struct {
+	waitlistRepo  model.WaitlistRepository
+	memberRepo    model.MemberRepository
+	gatheringRepo model.GatheringRepository
+}
+
+func NewWaitlistUsecase(
+	waitlistRepo model.WaitlistRepository,
+	memberRepo model.MemberRepository,
+	gatheringRepo model.GatheringRepository,
+) model.WaitlistUsecase {
+	return &waitlistUsecase{
+		waitlistRepo:  waitlistRepo,
+		memberRepo:    memberRepo,
+		gatheringRepo: gatheringRepo,
+	}
+}
+
+// FindWaitlistByGatheringID lists the members waiting for a seat of the gathering, in promotion order
+func (wu *waitlistUsecase) FindWaitlistByGatheringID(ctx context.Context, gatheringID int64) ([]*model.WaitlistEntry, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         ctx,
+		"gatheringID": gatheringID,
+	})
+
+	gathering, err := wu.gatheringRepo.FindByID(ctx, gatheringID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case gathering == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	res, err := wu.waitlistRepo.FindByGatheringID(ctx, gatheringID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// FindWaitlistByMemberID lists the gatherings the member waits for a seat of, with the member position
+func (wu *waitlistUsecase) FindWaitlistByMemberID(ctx context.Context, memberID int64) ([]*model.WaitlistEntry, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"memberID": memberID,
+	})
+
+	member, err := wu.memberRepo.FindByID(ctx, memberID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case member == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	res, err := wu.waitlistRepo.FindByMemberID(ctx, memberID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// waitlistPromoter gives the free seats of a gathering to its waitlisted members, it is shared by the usecases
+// freeing or adding seats
+type waitlistPromoter struct {
+	gatheringRepo  model.GatheringRepository
+	invitationRepo model.InvitationRepository
+	attendeeRepo   model.AttendeeRepository
+	waitlistRepo   model.WaitlistRepository
+	eventPublisher model.EventPublisher
+}
+
+// promote promotes the waitlisted members in waitlist order while the gathering has free seats, every member
+// waitlisted on a gathering that is no longer capacity limited is promoted. A member coming with guests waits, and
+// keeps the members behind waiting, until there are seats for all of them. It reports whether a seat is still free.
+// The gathering is locked so it is expected to run inside a transaction
+func (wp *waitlistPromoter) promote(ctx context.Context, gatheringID int64) (bool, error) {
+	gathering, err := wp.gatheringRepo.LockByID(ctx, gatheringID)
+	if err != nil || gathering == nil {
+		return false, err
+	}
+
+	var taken int64
+	if gathering.IsCapacityLimited() {
+		taken, err = wp.attendeeRepo.CountSeatsByGatheringID(ctx, gatheringID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for !gathering.IsCapacityLimited() || taken < int64(gathering.Capacity) {
+		entry, err := wp.waitlistRepo.FindFirstByGatheringID(ctx, gatheringID)
+		if err != nil {
+			return false, err
+		}
+		if entry == nil {
+			return true, nil
+		}
+
+		// the invitation may have been deleted with its member while waiting
+		invitation, err := wp.invitationRepo.FindByMemberIDAndGatheringID(ctx, entry.MemberID, gatheringID)
+		if err != nil {
+			return false, err
+		}
+		live := invitation != nil && invitation.IsLive()
+		if live && gathering.IsCapacityLimited() && taken+invitation.Seats() > int64(gathering.Capacity) {
+			return false, nil
+		}
+
+		if err = wp.waitlistRepo.DeleteByGatheringIDAndMemberID(ctx, gatheringID, entry.MemberID); err != nil {
+			return false, err
+		}
+		if !live {
+			continue
+		}
+
+		err = wp.attendeeRepo.Revive(ctx, &model.Attendee{
+			MemberID:     invitation.MemberID,
+			GatheringID:  invitation.GatheringID,
+			OccurrenceAt: invitation.OccurrenceAt,
+			Guests:       invitation.Guests,
+		})
+		if err != nil {
+			return false, err
+		}
+		taken += invitation.Seats()
+
+		if err = publishEvent(ctx, wp.eventPublisher, model.InvitationPromoted, invitation); err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
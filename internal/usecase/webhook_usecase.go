@@ -0,0 +1,375 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/jpillora/backoff"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookDeliveryLease how long a claimed delivery is hidden from the other dispatchers while it is sent
+	webhookDeliveryLease = 1 * time.Minute
+	// webhookDeliveryBatchSize how many deliveries are claimed at once, the ones the lease runs too short for are left
+	// to the next claim
+	webhookDeliveryBatchSize = 50
+)
+
+type webhookUsecase struct {
+	subscriptionRepo model.WebhookSubscriptionRepository
+	deliveryRepo     model.WebhookDeliveryRepository
+	roleRepo         model.GatheringRoleRepository
+	sender           model.WebhookSender
+	maxAttempts      int
+	sendTimeout      time.Duration
+	backoff          *backoff.Backoff
+}
+
+func NewWebhookUsecase(
+	subscriptionRepo model.WebhookSubscriptionRepository,
+	deliveryRepo model.WebhookDeliveryRepository,
+	roleRepo model.GatheringRoleRepository,
+	sender model.WebhookSender,
+	maxAttempts int,
+	sendTimeout time.Duration,
+) model.WebhookUsecase {
+	return &webhookUsecase{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		roleRepo:         roleRepo,
+		sender:           sender,
+		maxAttempts:      maxAttempts,
+		sendTimeout:      sendTimeout,
+		backoff: &backoff.Backoff{
+			Factor: 2,
+			Jitter: true,
+			Min:    10 * time.Second,
+			Max:    1 * time.Hour,
+		},
+	}
+}
+
+// CreateSubscription validates the subscription and generates its secret when none is given
+func (wu *webhookUsecase) CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          ctx,
+		"subscription": subscription,
+	})
+
+	u, err := url.Parse(subscription.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	err = wu.sender.CheckURL(ctx, subscription.URL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhookURL, err)
+	}
+
+	if len(subscription.EventTypes) == 0 {
+		return ErrInvalidWebhookEventType
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !model.IsEventType(eventType) {
+			return ErrInvalidWebhookEventType
+		}
+	}
+
+	if subscription.Secret == "" {
+		subscription.Secret, err = model.GenerateSecret()
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	err = wu.subscriptionRepo.Create(ctx, subscription)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (wu *webhookUsecase) FindSubscriptionByID(ctx context.Context, subscriptionID, owner int64) (*model.WebhookSubscription, error) {
+	return wu.findOwnedSubscription(ctx, subscriptionID, owner)
+}
+
+// findOwnedSubscription finds the subscription and refuses it to anyone but its owner
+func (wu *webhookUsecase) findOwnedSubscription(ctx context.Context, subscriptionID, owner int64) (*model.WebhookSubscription, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"subscriptionID": subscriptionID,
+		"owner":          owner,
+	})
+
+	res, err := wu.subscriptionRepo.FindByID(ctx, subscriptionID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case res == nil:
+		return nil, ErrRecordNotFound
+	case res.Owner != owner:
+		return nil, ErrWebhookForbidden
+	}
+
+	return res, nil
+}
+
+func (wu *webhookUsecase) FindSubscriptionsByOwner(ctx context.Context, owner int64) ([]*model.WebhookSubscription, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"owner": owner,
+	})
+
+	res, err := wu.subscriptionRepo.FindByOwner(ctx, owner)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (wu *webhookUsecase) DeleteSubscriptionByID(ctx context.Context, subscriptionID, owner int64) (*model.WebhookSubscription, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"subscriptionID": subscriptionID,
+		"owner":          owner,
+	})
+
+	_, err := wu.findOwnedSubscription(ctx, subscriptionID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := wu.subscriptionRepo.DeleteByID(ctx, subscriptionID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (wu *webhookUsecase) FindDeliveriesBySubscriptionID(ctx context.Context, subscriptionID, owner int64) ([]*model.WebhookDelivery, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            ctx,
+		"subscriptionID": subscriptionID,
+		"owner":          owner,
+	})
+
+	_, err := wu.findOwnedSubscription(ctx, subscriptionID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := wu.deliveryRepo.FindBySubscriptionID(ctx, subscriptionID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReplayDeliveryByID sends a finished delivery again, with a fresh set of attempts
+func (wu *webhookUsecase) ReplayDeliveryByID(ctx context.Context, deliveryID, owner int64) (*model.WebhookDelivery, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        ctx,
+		"deliveryID": deliveryID,
+		"owner":      owner,
+	})
+
+	delivery, err := wu.deliveryRepo.FindByID(ctx, deliveryID)
+	switch {
+	case err != nil:
+		logger.Error(err)
+		return nil, err
+	case delivery == nil:
+		return nil, ErrRecordNotFound
+	}
+
+	_, err = wu.findOwnedSubscription(ctx, delivery.SubscriptionID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.Status == model.WebhookDeliveryPending {
+		return nil, ErrWebhookDeliveryPending
+	}
+
+	delivery.Status = model.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.ResponseCode = 0
+	delivery.LastError = ""
+	delivery.DeliveredAt = nil
+	delivery.NextAttemptAt = time.Now()
+
+	err = wu.deliveryRepo.Update(ctx, delivery)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// EnqueueDeliveries creates a pending delivery of the event for every subscription of its type, it is an event handler
+func (wu *webhookUsecase) EnqueueDeliveries(ctx context.Context, event *model.Event) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   ctx,
+		"event": event,
+	})
+
+	subscriptions, err := wu.subscriptionRepo.FindByEventType(ctx, event.Type)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	subscriptions, err = wu.subscriptionsInScope(ctx, event, subscriptions)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]*model.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, &model.WebhookDelivery{
+			ID:             model.GenerateID(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         model.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+
+	err = wu.deliveryRepo.Create(ctx, deliveries)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// subscriptionsInScope keeps the subscriptions whose owner may see the event: the organizers of the gathering it
+// happened on and the member it concerns
+func (wu *webhookUsecase) subscriptionsInScope(ctx context.Context, event *model.Event, subscriptions []*model.WebhookSubscription) ([]*model.WebhookSubscription, error) {
+	scope, err := event.Scope()
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[int64]bool)
+	if scope.MemberID != 0 {
+		owners[scope.MemberID] = true
+	}
+	if scope.GatheringID != 0 {
+		roles, err := wu.roleRepo.FindByGatheringID(ctx, scope.GatheringID)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			owners[role.MemberID] = true
+		}
+	}
+
+	res := make([]*model.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if owners[subscription.Owner] {
+			res = append(res, subscription)
+		}
+	}
+	return res, nil
+}
+
+// DeliverDue sends one batch of due deliveries and returns how many were claimed
+func (wu *webhookUsecase) DeliverDue(ctx context.Context) (int, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": ctx,
+	})
+
+	now := time.Now()
+	deliveries, err := wu.deliveryRepo.ClaimDue(ctx, now, webhookDeliveryLease, webhookDeliveryBatchSize)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	leaseUntil := now.Add(webhookDeliveryLease)
+	for i, delivery := range deliveries {
+		// a send can take the whole timeout, one started closer to the end of the lease could still be running once
+		// another dispatcher claims the delivery again and it would be sent twice
+		if time.Until(leaseUntil) <= wu.sendTimeout {
+			logger.Warnf("lease ran out, %d deliveries are left to the next claim", len(deliveries)-i)
+			break
+		}
+		wu.deliver(ctx, delivery)
+	}
+
+	return len(deliveries), nil
+}
+
+func (wu *webhookUsecase) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      ctx,
+		"delivery": delivery,
+	})
+
+	subscription, err := wu.subscriptionRepo.FindByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+
+	if subscription == nil {
+		// the subscription was deleted after the delivery was enqueued
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.LastError = ErrRecordNotFound.Error()
+	} else {
+		delivery.ResponseCode, err = wu.sender.Send(ctx, subscription, delivery)
+		switch {
+		case err == nil:
+			delivery.Status = model.WebhookDeliverySucceeded
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+		case delivery.Attempts >= wu.maxAttempts:
+			delivery.Status = model.WebhookDeliveryFailed
+			delivery.LastError = err.Error()
+		default:
+			delivery.LastError = err.Error()
+			delivery.NextAttemptAt = now.Add(wu.backoff.ForAttempt(float64(delivery.Attempts - 1)))
+		}
+	}
+
+	if err = wu.deliveryRepo.Update(ctx, delivery); err != nil {
+		logger.Error(err)
+	}
+}
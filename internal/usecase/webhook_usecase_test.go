@@ -0,0 +1,385 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/fajarachmadyusup13/gathering-app/internal/model/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	t.Run("success generates a secret", func(t *testing.T) {
+		subscription := &model.WebhookSubscription{
+			ID:         123,
+			Owner:      321,
+			URL:        "https://example.com/hook",
+			EventTypes: []model.EventType{model.InvitationSent},
+		}
+
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().Create(ctx, subscription).Times(1).Return(nil)
+		mockSender := mock.NewMockWebhookSender(ctrl)
+		mockSender.EXPECT().CheckURL(ctx, subscription.URL).Times(1).Return(nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, nil, nil, mockSender, 3, 5*time.Second)
+
+		err := webhookUsecase.CreateSubscription(ctx, subscription)
+
+		assert.NoError(t, err)
+		assert.Len(t, subscription.Secret, 64)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		webhookUsecase := NewWebhookUsecase(nil, nil, nil, nil, 3, 5*time.Second)
+
+		err := webhookUsecase.CreateSubscription(ctx, &model.WebhookSubscription{
+			URL:        "ftp://example.com",
+			EventTypes: []model.EventType{model.InvitationSent},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+	})
+
+	t.Run("forbidden address", func(t *testing.T) {
+		mockSender := mock.NewMockWebhookSender(ctrl)
+		mockSender.EXPECT().CheckURL(ctx, "http://10.0.0.1/hook").Times(1).Return(errors.New("error"))
+
+		webhookUsecase := NewWebhookUsecase(nil, nil, nil, mockSender, 3, 5*time.Second)
+
+		err := webhookUsecase.CreateSubscription(ctx, &model.WebhookSubscription{
+			URL:        "http://10.0.0.1/hook",
+			EventTypes: []model.EventType{model.InvitationSent},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		mockSender := mock.NewMockWebhookSender(ctrl)
+		mockSender.EXPECT().CheckURL(ctx, "https://example.com/hook").Times(1).Return(nil)
+
+		webhookUsecase := NewWebhookUsecase(nil, nil, nil, mockSender, 3, 5*time.Second)
+
+		err := webhookUsecase.CreateSubscription(ctx, &model.WebhookSubscription{
+			URL:        "https://example.com/hook",
+			EventTypes: []model.EventType{"member.unknown"},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidWebhookEventType)
+	})
+}
+
+func TestEnqueueDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	e, err := model.NewEvent(model.InvitationSent, &model.Invitation{ID: 1, MemberID: 321, GatheringID: 444})
+	assert.NoError(t, err)
+
+	roles := []*model.GatheringRole{{GatheringID: 444, MemberID: 10, Role: model.GatheringOwner}}
+
+	t.Run("one delivery per subscription in scope", func(t *testing.T) {
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByEventType(ctx, model.InvitationSent).Times(1).
+			Return([]*model.WebhookSubscription{{ID: 1, Owner: 10}, {ID: 2, Owner: 321}, {ID: 3, Owner: 99}}, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().FindByGatheringID(ctx, int64(444)).Times(1).Return(roles, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, deliveries []*model.WebhookDelivery) error {
+				assert.Len(t, deliveries, 2)
+				assert.Equal(t, int64(1), deliveries[0].SubscriptionID)
+				assert.Equal(t, int64(2), deliveries[1].SubscriptionID)
+				for _, delivery := range deliveries {
+					assert.Equal(t, e.ID, delivery.EventID)
+					assert.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+				}
+				return nil
+			})
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, mockRoleRepo, nil, 3, 5*time.Second)
+
+		err := webhookUsecase.EnqueueDeliveries(ctx, e)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("subscriber of another gathering receives nothing", func(t *testing.T) {
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByEventType(ctx, model.InvitationSent).Times(1).
+			Return([]*model.WebhookSubscription{{ID: 3, Owner: 99}}, nil)
+		mockRoleRepo := mock.NewMockGatheringRoleRepository(ctrl)
+		mockRoleRepo.EXPECT().FindByGatheringID(ctx, int64(444)).Times(1).Return(roles, nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, nil, mockRoleRepo, nil, 3, 5*time.Second)
+
+		err := webhookUsecase.EnqueueDeliveries(ctx, e)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("member event only goes to the member", func(t *testing.T) {
+		registered, err := model.NewEvent(model.MemberRegistered, &model.Member{ID: 321, Email: "john@doe.com"})
+		assert.NoError(t, err)
+
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByEventType(ctx, model.MemberRegistered).Times(1).
+			Return([]*model.WebhookSubscription{{ID: 1, Owner: 10}, {ID: 2, Owner: 321}}, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, deliveries []*model.WebhookDelivery) error {
+				assert.Len(t, deliveries, 1)
+				assert.Equal(t, int64(2), deliveries[0].SubscriptionID)
+				return nil
+			})
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, nil, 3, 5*time.Second)
+
+		err = webhookUsecase.EnqueueDeliveries(ctx, registered)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("no subscription", func(t *testing.T) {
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByEventType(ctx, model.InvitationSent).Times(1).Return(nil, nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, nil, nil, nil, 3, 5*time.Second)
+
+		err := webhookUsecase.EnqueueDeliveries(ctx, e)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeliverDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	subscription := &model.WebhookSubscription{ID: 1, URL: "https://example.com/hook"}
+
+	t.Run("succeeded", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{ID: 2, SubscriptionID: 1, Status: model.WebhookDeliveryPending}
+
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(subscription, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().ClaimDue(ctx, gomock.Any(), webhookDeliveryLease, webhookDeliveryBatchSize).Times(1).
+			Return([]*model.WebhookDelivery{delivery}, nil)
+		mockDeliveryRepo.EXPECT().Update(ctx, delivery).Times(1).Return(nil)
+		mockSender := mock.NewMockWebhookSender(ctrl)
+		mockSender.EXPECT().Send(ctx, subscription, delivery).Times(1).Return(200, nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, mockSender, 3, 5*time.Second)
+
+		delivered, err := webhookUsecase.DeliverDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, model.WebhookDeliverySucceeded, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, 200, delivery.ResponseCode)
+		assert.NotNil(t, delivery.DeliveredAt)
+	})
+
+	t.Run("failed attempt is retried later", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{ID: 2, SubscriptionID: 1, Status: model.WebhookDeliveryPending}
+
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(subscription, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().ClaimDue(ctx, gomock.Any(), webhookDeliveryLease, webhookDeliveryBatchSize).Times(1).
+			Return([]*model.WebhookDelivery{delivery}, nil)
+		mockDeliveryRepo.EXPECT().Update(ctx, delivery).Times(1).Return(nil)
+		mockSender := mock.NewMockWebhookSender(ctrl)
+		mockSender.EXPECT().Send(ctx, subscription, delivery).Times(1).Return(500, errors.New("error"))
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, mockSender, 3, 5*time.Second)
+
+		_, err := webhookUsecase.DeliverDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, "error", delivery.LastError)
+		assert.True(t, delivery.NextAttemptAt.After(time.Now()))
+	})
+
+	t.Run("last attempt marks the delivery failed", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{ID: 2, SubscriptionID: 1, Status: model.WebhookDeliveryPending, Attempts: 2}
+
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(subscription, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().ClaimDue(ctx, gomock.Any(), webhookDeliveryLease, webhookDeliveryBatchSize).Times(1).
+			Return([]*model.WebhookDelivery{delivery}, nil)
+		mockDeliveryRepo.EXPECT().Update(ctx, delivery).Times(1).Return(nil)
+		mockSender := mock.NewMockWebhookSender(ctrl)
+		mockSender.EXPECT().Send(ctx, subscription, delivery).Times(1).Return(500, errors.New("error"))
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, mockSender, 3, 5*time.Second)
+
+		_, err := webhookUsecase.DeliverDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+	})
+
+	t.Run("deleted subscription", func(t *testing.T) {
+		delivery := &model.WebhookDelivery{ID: 2, SubscriptionID: 1, Status: model.WebhookDeliveryPending}
+
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(nil, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().ClaimDue(ctx, gomock.Any(), webhookDeliveryLease, webhookDeliveryBatchSize).Times(1).
+			Return([]*model.WebhookDelivery{delivery}, nil)
+		mockDeliveryRepo.EXPECT().Update(ctx, delivery).Times(1).Return(nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, nil, 3, 5*time.Second)
+
+		_, err := webhookUsecase.DeliverDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+	})
+
+	t.Run("deliveries the lease runs too short for are left", func(t *testing.T) {
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().ClaimDue(ctx, gomock.Any(), webhookDeliveryLease, webhookDeliveryBatchSize).Times(1).
+			Return([]*model.WebhookDelivery{{ID: 2, SubscriptionID: 1}}, nil)
+
+		webhookUsecase := NewWebhookUsecase(nil, mockDeliveryRepo, nil, nil, 3, webhookDeliveryLease)
+
+		delivered, err := webhookUsecase.DeliverDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+	})
+
+	t.Run("error claim", func(t *testing.T) {
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().ClaimDue(ctx, gomock.Any(), webhookDeliveryLease, webhookDeliveryBatchSize).Times(1).
+			Return(nil, errors.New("error"))
+
+		webhookUsecase := NewWebhookUsecase(nil, mockDeliveryRepo, nil, nil, 3, 5*time.Second)
+
+		_, err := webhookUsecase.DeliverDue(ctx)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReplayDeliveryByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	subscription := &model.WebhookSubscription{ID: 1, Owner: 321}
+
+	t.Run("success", func(t *testing.T) {
+		deliveredAt := time.Now()
+		delivery := &model.WebhookDelivery{ID: 2, SubscriptionID: 1, Status: model.WebhookDeliverySucceeded, Attempts: 1,
+			ResponseCode: 200, DeliveredAt: &deliveredAt}
+
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(subscription, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).Return(delivery, nil)
+		mockDeliveryRepo.EXPECT().Update(ctx, delivery).Times(1).Return(nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, nil, 3, 5*time.Second)
+
+		res, err := webhookUsecase.ReplayDeliveryByID(ctx, 2, 321)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.WebhookDeliveryPending, res.Status)
+		assert.Equal(t, 0, res.Attempts)
+		assert.Equal(t, 0, res.ResponseCode)
+		assert.Empty(t, res.LastError)
+		assert.Nil(t, res.DeliveredAt)
+	})
+
+	t.Run("still pending", func(t *testing.T) {
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(subscription, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).
+			Return(&model.WebhookDelivery{ID: 2, SubscriptionID: 1, Status: model.WebhookDeliveryPending}, nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, nil, 3, 5*time.Second)
+
+		_, err := webhookUsecase.ReplayDeliveryByID(ctx, 2, 321)
+
+		assert.ErrorIs(t, err, ErrWebhookDeliveryPending)
+	})
+
+	t.Run("another owner", func(t *testing.T) {
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(subscription, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).
+			Return(&model.WebhookDelivery{ID: 2, SubscriptionID: 1, Status: model.WebhookDeliveryFailed}, nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, nil, 3, 5*time.Second)
+
+		_, err := webhookUsecase.ReplayDeliveryByID(ctx, 2, 999)
+
+		assert.ErrorIs(t, err, ErrWebhookForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindByID(ctx, int64(2)).Times(1).Return(nil, nil)
+
+		webhookUsecase := NewWebhookUsecase(nil, mockDeliveryRepo, nil, nil, 3, 5*time.Second)
+
+		_, err := webhookUsecase.ReplayDeliveryByID(ctx, 2, 321)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestFindDeliveriesBySubscriptionID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+
+	subscription := &model.WebhookSubscription{ID: 1, Owner: 321}
+
+	t.Run("success", func(t *testing.T) {
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(subscription, nil)
+		mockDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+		mockDeliveryRepo.EXPECT().FindBySubscriptionID(ctx, int64(1)).Times(1).
+			Return([]*model.WebhookDelivery{{ID: 2}}, nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, mockDeliveryRepo, nil, nil, 3, 5*time.Second)
+
+		res, err := webhookUsecase.FindDeliveriesBySubscriptionID(ctx, 1, 321)
+
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("another owner", func(t *testing.T) {
+		mockSubscriptionRepo := mock.NewMockWebhookSubscriptionRepository(ctrl)
+		mockSubscriptionRepo.EXPECT().FindByID(ctx, int64(1)).Times(1).Return(subscription, nil)
+
+		webhookUsecase := NewWebhookUsecase(mockSubscriptionRepo, nil, nil, nil, 3, 5*time.Second)
+
+		_, err := webhookUsecase.FindDeliveriesBySubscriptionID(ctx, 1, 999)
+
+		assert.ErrorIs(t, err, ErrWebhookForbidden)
+	})
+}
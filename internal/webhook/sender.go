@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+)
+
+const (
+	// EventHeader carries the type of the delivered event
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader carries the delivery ID, it is the same on every retry of a delivery
+	DeliveryHeader = "X-Webhook-Delivery"
+	// TimestampHeader carries the unix time the request was signed at
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// ErrForbiddenAddress keeps subscriptions from making the service call hosts on its own network
+var ErrForbiddenAddress = errors.New("webhook URL must not point to a loopback, private or link-local address")
+
+type httpSender struct {
+	client       *http.Client
+	allowPrivate bool
+}
+
+func NewSender(timeout time.Duration) model.WebhookSender {
+	return newSender(timeout, false)
+}
+
+// newSender builds the sender, allowPrivate lets the tests post to their local receivers
+func newSender(timeout time.Duration, allowPrivate bool) *httpSender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		// checked on the address actually dialed, so a host re-resolving to a private address or a redirect to one
+		// is refused too
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if isForbiddenIP(net.ParseIP(host)) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be the address dialed instead of the receiver
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &httpSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+		},
+		allowPrivate: allowPrivate,
+	}
+}
+
+// CheckURL resolves the URL host and refuses it when any of its addresses is loopback, private or link-local
+func (h *httpSender) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if h.allowPrivate {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if isForbiddenIP(addr.IP) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// Send posts the delivery payload signed with the subscription secret, any non 2xx response is an error
+func (h *httpSender) Send(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(delivery.EventType))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign computes the signature header value receivers compare against to authenticate a delivery
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func isForbiddenIP(ip net.IP) bool {
+	return ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()
+}
@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fajarachmadyusup13/gathering-app/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	subscription := &model.WebhookSubscription{
+		ID:     1,
+		Secret: "secret",
+	}
+	delivery := &model.WebhookDelivery{
+		ID:        2,
+		EventType: model.InvitationSent,
+		Payload:   []byte(`{"id":3}`),
+	}
+
+	t.Run("signed request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, delivery.Payload, json.RawMessage(body))
+			assert.Equal(t, string(model.InvitationSent), r.Header.Get(EventHeader))
+			assert.Equal(t, "2", r.Header.Get(DeliveryHeader))
+			assert.Equal(t, Sign("secret", r.Header.Get(TimestampHeader), body), r.Header.Get(SignatureHeader))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		sub := *subscription
+		sub.URL = server.URL
+
+		code, err := newSender(time.Second, true).Send(context.TODO(), &sub, delivery)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, code)
+	})
+
+	t.Run("non 2xx response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		sub := *subscription
+		sub.URL = server.URL
+
+		code, err := newSender(time.Second, true).Send(context.TODO(), &sub, delivery)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("unreachable receiver", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		sub := *subscription
+		sub.URL = server.URL
+
+		code, err := newSender(time.Second, true).Send(context.TODO(), &sub, delivery)
+		assert.Error(t, err)
+		assert.Equal(t, 0, code)
+	})
+}
+
+func TestForbiddenAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request must not reach the receiver")
+	}))
+	defer server.Close()
+
+	sender := NewSender(time.Second)
+
+	t.Run("check url", func(t *testing.T) {
+		for _, rawURL := range []string{
+			server.URL,
+			"http://localhost/hook",
+			"http://10.0.0.1/hook",
+			"http://192.168.1.1/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::1]/hook",
+			"http://0.0.0.0/hook",
+		} {
+			assert.ErrorIs(t, sender.CheckURL(context.TODO(), rawURL), ErrForbiddenAddress, rawURL)
+		}
+
+		assert.NoError(t, sender.CheckURL(context.TODO(), "https://93.184.215.14/hook"))
+	})
+
+	t.Run("send", func(t *testing.T) {
+		code, err := sender.Send(context.TODO(), &model.WebhookSubscription{URL: server.URL}, &model.WebhookDelivery{})
+		assert.ErrorIs(t, err, ErrForbiddenAddress)
+		assert.Equal(t, 0, code)
+	})
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", Sign("secret", "1700000000", []byte("{}")))
+}